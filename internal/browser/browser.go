@@ -0,0 +1,47 @@
+// Package browser opens URLs in the user's web browser.
+package browser
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// execCommand allows mocking exec.Command for testing
+var execCommand = exec.Command
+
+// goos allows overriding runtime.GOOS for testing
+var goos = runtime.GOOS
+
+// Open opens url in the web browser. The BROWSER environment variable, if
+// set, names the command to use; otherwise the platform's default opener
+// is used. Open returns once the browser has been launched.
+func Open(url string) error {
+	name, args := command(url)
+	cmd := execCommand(name, args...)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open browser: %w", err)
+	}
+	// Don't leave a zombie behind; the exit status doesn't matter
+	go cmd.Wait()
+	return nil
+}
+
+// command returns the command and arguments that open url.
+func command(url string) (string, []string) {
+	if browser := strings.TrimSpace(os.Getenv("BROWSER")); browser != "" {
+		fields := strings.Fields(browser)
+		return fields[0], append(fields[1:], url)
+	}
+
+	switch goos {
+	case "darwin":
+		return "open", []string{url}
+	case "windows":
+		return "rundll32", []string{"url.dll,FileProtocolHandler", url}
+	default:
+		return "xdg-open", []string{url}
+	}
+}
@@ -0,0 +1,67 @@
+package browser
+
+import (
+	"os/exec"
+	"reflect"
+	"testing"
+)
+
+func TestCommand(t *testing.T) {
+	const url = "https://github.com/org/repo/pull/1"
+
+	tests := []struct {
+		name     string
+		browser  string
+		goos     string
+		wantName string
+		wantArgs []string
+	}{
+		{"macOS", "", "darwin", "open", []string{url}},
+		{"linux", "", "linux", "xdg-open", []string{url}},
+		{"windows", "", "windows", "rundll32", []string{"url.dll,FileProtocolHandler", url}},
+		{"BROWSER overrides platform", "firefox", "darwin", "firefox", []string{url}},
+		{"BROWSER with arguments", "  chromium --new-window ", "linux", "chromium", []string{"--new-window", url}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("BROWSER", tt.browser)
+			oldGOOS := goos
+			goos = tt.goos
+			defer func() { goos = oldGOOS }()
+
+			name, args := command(url)
+			if name != tt.wantName || !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("command() = %s %v, want %s %v", name, args, tt.wantName, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestOpen(t *testing.T) {
+	t.Setenv("BROWSER", "my-browser")
+
+	var gotName string
+	var gotArgs []string
+	oldExec := execCommand
+	execCommand = func(name string, arg ...string) *exec.Cmd {
+		gotName, gotArgs = name, arg
+		return exec.Command("true")
+	}
+	defer func() { execCommand = oldExec }()
+
+	if err := Open("https://example.com"); err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if gotName != "my-browser" || !reflect.DeepEqual(gotArgs, []string{"https://example.com"}) {
+		t.Errorf("ran %s %v", gotName, gotArgs)
+	}
+}
+
+func TestOpen_Error(t *testing.T) {
+	t.Setenv("BROWSER", "prt-no-such-browser-command")
+
+	if err := Open("https://example.com"); err == nil {
+		t.Error("expected an error when the browser can't be started")
+	}
+}
@@ -0,0 +1,108 @@
+// Package cache persists fetched pull requests on disk between runs,
+// so repositories that haven't changed don't need to be refetched.
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"prt/internal/config"
+	"prt/internal/github"
+	"prt/internal/models"
+)
+
+// version is bumped whenever the entry format changes, so entries written
+// by older versions of PRT are ignored instead of misread.
+const version = 8
+
+// Dir returns the default cache directory: ~/.prt/cache
+func Dir() string {
+	return filepath.Join(config.ConfigDir(), "cache")
+}
+
+// Store is an on-disk github.Cache with one JSON file per repository,
+// laid out as <dir>/<host>/<owner>/<name>.json. It is safe for concurrent
+// use across repositories.
+type Store struct {
+	dir string
+}
+
+// NewStore creates a store rooted at dir. The directory is created on the
+// first write.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// file is the on-disk format of a cache entry.
+type file struct {
+	Version  int                `json:"version"`
+	FullName string             `json:"full_name"`
+	Entry    *github.CacheEntry `json:"entry"`
+}
+
+// Get returns the cached entry for repo. Missing, unreadable, or outdated
+// entries are reported as absent.
+func (s *Store) Get(repo *models.Repository) (*github.CacheEntry, bool) {
+	data, err := os.ReadFile(s.path(repo))
+	if err != nil {
+		return nil, false
+	}
+
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, false
+	}
+	if f.Version != version || f.Entry == nil || f.FullName != repo.FullName() {
+		return nil, false
+	}
+
+	return f.Entry, true
+}
+
+// Put stores the entry for repo. The file is written atomically so a
+// concurrent or interrupted run never sees a partial entry.
+func (s *Store) Put(repo *models.Repository, entry *github.CacheEntry) error {
+	path := s.path(repo)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(file{Version: version, FullName: repo.FullName(), Entry: entry})
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".entry-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// Clear removes all cached entries.
+func (s *Store) Clear() error {
+	return os.RemoveAll(s.dir)
+}
+
+// path returns the file holding repo's entry.
+func (s *Store) path(repo *models.Repository) string {
+	host := repo.Host
+	if host == "" {
+		host = github.DefaultHost
+	}
+	// Ports (ghe.io:8443) aren't valid in file names on every platform
+	host = strings.ReplaceAll(host, ":", "_")
+	return filepath.Join(s.dir, host, repo.Owner, repo.Name+".json")
+}
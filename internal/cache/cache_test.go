@@ -0,0 +1,120 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"prt/internal/github"
+	"prt/internal/models"
+)
+
+func TestStore_PutGet(t *testing.T) {
+	s := NewStore(t.TempDir())
+	repo := &models.Repository{Owner: "org", Name: "api", Host: "github.com"}
+
+	if _, ok := s.Get(repo); ok {
+		t.Fatal("expected no entry before Put")
+	}
+
+	fetched := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	entry := &github.CacheEntry{
+		FetchedAt: fetched,
+		ETag:      `W/"abc"`,
+		Truncated: true,
+		PRs: []*models.PR{{
+			Number:    7,
+			Title:     "Add rate limiting",
+			CIStatus:  models.CIStatusFailing,
+			CreatedAt: fetched.Add(-time.Hour),
+			Reviews:   []models.Review{{Author: "bob", State: models.ReviewStateApproved}},
+		}},
+	}
+	if err := s.Put(repo, entry); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	got, ok := s.Get(repo)
+	if !ok {
+		t.Fatal("expected entry after Put")
+	}
+	if !got.FetchedAt.Equal(fetched) || got.ETag != entry.ETag || !got.Truncated {
+		t.Errorf("entry metadata = %+v, want %+v", got, entry)
+	}
+	if len(got.PRs) != 1 || got.PRs[0].Number != 7 || got.PRs[0].CIStatus != models.CIStatusFailing ||
+		len(got.PRs[0].Reviews) != 1 {
+		t.Errorf("PRs did not round-trip: %+v", got.PRs)
+	}
+}
+
+func TestStore_KeyedByHostAndFullName(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStore(dir)
+
+	public := &models.Repository{Owner: "org", Name: "api"}
+	enterprise := &models.Repository{Owner: "org", Name: "api", Host: "ghe.io:8443"}
+
+	s.Put(public, &github.CacheEntry{ETag: "public"})
+	s.Put(enterprise, &github.CacheEntry{ETag: "enterprise"})
+
+	if got, _ := s.Get(public); got == nil || got.ETag != "public" {
+		t.Errorf("public entry = %+v", got)
+	}
+	if got, _ := s.Get(enterprise); got == nil || got.ETag != "enterprise" {
+		t.Errorf("enterprise entry = %+v", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "github.com", "org", "api.json")); err != nil {
+		t.Errorf("expected entry file for github.com: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "ghe.io_8443", "org", "api.json")); err != nil {
+		t.Errorf("expected entry file for ghe.io:8443: %v", err)
+	}
+}
+
+func TestStore_IgnoresInvalidEntries(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStore(dir)
+	repo := &models.Repository{Owner: "org", Name: "api"}
+	path := filepath.Join(dir, "github.com", "org", "api.json")
+	os.MkdirAll(filepath.Dir(path), 0755)
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"corrupt", `{not json`},
+		{"old version", `{"version": 0, "full_name": "org/api", "entry": {"prs": []}}`},
+		{"other repo", `{"version": 1, "full_name": "org/web", "entry": {"prs": []}}`},
+		{"no entry", `{"version": 1, "full_name": "org/api"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.WriteFile(path, []byte(tt.content), 0644)
+			if _, ok := s.Get(repo); ok {
+				t.Error("expected invalid entry to be treated as a miss")
+			}
+		})
+	}
+}
+
+func TestStore_Clear(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cache")
+	s := NewStore(dir)
+	repo := &models.Repository{Owner: "org", Name: "api"}
+	s.Put(repo, &github.CacheEntry{})
+
+	if err := s.Clear(); err != nil {
+		t.Fatalf("Clear() error = %v", err)
+	}
+	if _, ok := s.Get(repo); ok {
+		t.Error("expected no entries after Clear")
+	}
+}
+
+func TestDir(t *testing.T) {
+	if filepath.Base(Dir()) != "cache" {
+		t.Errorf("Dir() = %q, want a cache directory", Dir())
+	}
+}
@@ -2,11 +2,16 @@
 package categorizer
 
 import (
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/gobwas/glob"
+
 	"prt/internal/config"
 	"prt/internal/models"
 	"prt/internal/stacks"
+	"prt/internal/state"
 )
 
 // Categorizer organizes PRs into meaningful buckets for display.
@@ -17,25 +22,66 @@ type Categorizer interface {
 }
 
 // categorizer implements the Categorizer interface.
-type categorizer struct{}
+type categorizer struct {
+	state       *state.State // nil = no snoozed, muted, or pinned PRs
+	showSnoozed bool
+}
+
+// Option configures a Categorizer.
+type Option func(*categorizer)
+
+// WithState applies the user's snoozed, muted, and pinned PRs from st.
+func WithState(st *state.State) Option {
+	return func(c *categorizer) {
+		c.state = st
+	}
+}
+
+// WithShowSnoozed keeps snoozed and muted PRs in their sections, marked as
+// such, instead of setting them aside in SnoozedPRs.
+func WithShowSnoozed(show bool) Option {
+	return func(c *categorizer) {
+		c.showSnoozed = show
+	}
+}
 
 // NewCategorizer creates a new Categorizer instance.
-func NewCategorizer() Categorizer {
-	return &categorizer{}
+func NewCategorizer(opts ...Option) Categorizer {
+	c := &categorizer{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
-// Categorize processes repositories and categorizes their PRs based on the user's
-// relationship to each PR:
+// Categorize processes repositories and sorts each PR into the first of the
+// configured sections it matches, or Other PRs if it matches none. The
+// default sections categorize PRs by the user's relationship to them:
 //   - My PRs: PRs authored by the current user
-//   - Needs My Attention: PRs where review is requested or user is assigned (and not yet approved)
+//   - Needs My Attention: PRs where review is requested (from the user or one of
+//     their teams) or user is assigned (and not yet approved),
+//     or that got new commits since the user approved or requested changes
 //   - Team PRs: PRs authored by team members
 //   - Other PRs: PRs from everyone else (including bots)
+//
+// Snoozed and muted PRs (see WithState) are set aside in SnoozedPRs.
 func (c *categorizer) Categorize(repos []*models.Repository, cfg *config.Config, username string) *models.ScanResult {
 	result := models.NewScanResult()
 	result.Username = username
 
-	teamSet := toSet(cfg.TeamMembers)
-	botSet := toSet(cfg.Bots)
+	rules := compileRules(cfg.Sections)
+	result.Sections = displaySections(rules)
+	ctx := &ruleContext{
+		username: username,
+		teamSet:  toSet(cfg.TeamMembers),
+		botSet:   toSet(cfg.Bots),
+		now:      time.Now(),
+	}
+	myTeams := make(map[string]bool, len(cfg.MyTeams))
+	for _, team := range cfg.MyTeams {
+		myTeams[config.NormalizeTeam(team)] = true
+	}
+	ignoredChecks := compileGlobs(cfg.CIIgnoreChecks)
 
 	for _, repo := range repos {
 		// Handle repos with errors
@@ -53,8 +99,17 @@ func (c *categorizer) Categorize(repos []*models.Repository, cfg *config.Config,
 		result.ReposWithPRs = append(result.ReposWithPRs, repo)
 		result.TotalPRsFound += len(repo.PRs)
 
-		// Detect stacks for this repo
-		result.Stacks[repo.FullName()] = stacks.DetectStacks(repo.PRs)
+		// Detect stacks for this repo, which matches head branches to base
+		// branches by repository owner, flagging PRs whose parent was
+		// merged or closed
+		for _, pr := range slices.Concat(repo.PRs, repo.ClosedPRs) {
+			pr.RepoName = repo.Name
+			pr.RepoOwner = repo.Owner
+			pr.RepoPath = repo.Path
+		}
+		stack := stacks.DetectStacks(repo.PRs)
+		stacks.MarkOrphans(stack, repo.ClosedPRs)
+		result.Stacks[repo.FullName()] = stack
 
 		// Categorize each PR
 		for _, pr := range repo.PRs {
@@ -63,57 +118,87 @@ func (c *categorizer) Categorize(repos []*models.Repository, cfg *config.Config,
 				continue
 			}
 
-			pr.RepoName = repo.Name
-			pr.RepoOwner = repo.Owner
-			pr.RepoPath = repo.Path
+			// Leave ignored checks out of the CI status
+			ignoreChecks(pr, ignoredChecks)
 
 			// Compute user-specific fields
 			pr.IsReviewRequestedFromMe = contains(pr.ReviewRequests, username)
+			if !pr.IsReviewRequestedFromMe {
+				pr.RequestedTeam = findMyTeam(pr.TeamReviewRequests, myTeams, repo.Owner)
+				pr.IsReviewRequestedFromMe = pr.RequestedTeam != ""
+			}
 			pr.IsAssignedToMe = contains(pr.Assignees, username)
 			pr.MyReviewStatus = findMyReviewStatus(pr.Reviews, username)
+			pr.NeedsReReview = needsReReview(pr, username)
+
+			// Set aside snoozed and muted PRs
+			if c.state != nil {
+				pr.Snoozed = c.state.IsSnoozed(pr, username, ctx.now)
+				pr.Muted = c.state.IsMuted(pr)
+				pr.Pinned = c.state.IsPinned(pr)
+				if (pr.Snoozed || pr.Muted) && !c.showSnoozed {
+					result.SnoozedPRs = append(result.SnoozedPRs, pr)
+					continue
+				}
+			}
 
 			// Categorize
-			c.categorizePR(pr, username, teamSet, botSet, result)
+			c.categorizePR(pr, rules, ctx, result)
 		}
 	}
 
 	result.TotalReposScanned = len(repos)
 
-	// Sort all categories by creation date
-	SortResult(result, cfg.DefaultSort)
+	// Link stacks through Depends-on trailers, across repositories
+	if cfg.LinkDependsOn {
+		stacks.LinkDependencies(result.Stacks)
+	}
+
+	// Sort all categories by the configured sort keys
+	SortResult(result, cfg, ctx.now)
 
 	return result
 }
 
-// categorizePR determines which category a PR belongs to and adds it to the result.
-func (c *categorizer) categorizePR(pr *models.PR, username string, teamSet, botSet map[string]bool, result *models.ScanResult) {
-	switch {
-	case pr.Author == username:
-		// My PR
-		result.MyPRs = append(result.MyPRs, pr)
+// ignoreChecks marks the PR's checks whose names match one of patterns as
+// ignored and recomputes its CI status from the rest. PRs without checks
+// (such as ones from older snapshots) keep their CI status.
+func ignoreChecks(pr *models.PR, patterns []glob.Glob) {
+	if len(pr.Checks) == 0 {
+		return
+	}
+	for i := range pr.Checks {
+		pr.Checks[i].Ignored = slices.ContainsFunc(patterns, func(g glob.Glob) bool {
+			return g.Match(pr.Checks[i].Name)
+		})
+	}
+	pr.CIStatus = models.ComputeCIStatus(pr.Checks)
+}
 
-	case pr.IsReviewRequestedFromMe || pr.IsAssignedToMe:
-		// Needs my attention (unless already approved by me)
-		if pr.MyReviewStatus != models.ReviewStateApproved {
+// categorizePR adds a PR to the section of the first rule it matches, or to
+// Other PRs if none matches.
+func (c *categorizer) categorizePR(pr *models.PR, rules []*rule, ctx *ruleContext, result *models.ScanResult) {
+	for _, r := range rules {
+		if !r.matches(pr, ctx) {
+			continue
+		}
+		switch r.section.Key {
+		case models.SectionMyPRs:
+			result.MyPRs = append(result.MyPRs, pr)
+		case models.SectionNeedsMyAttention:
+			// Includes PRs updated since my review; GitHub no longer lists me
+			// as a requested reviewer once I've reviewed
 			result.NeedsMyAttention = append(result.NeedsMyAttention, pr)
-		} else {
-			// I approved it, categorize based on author
-			if teamSet[pr.Author] {
-				result.TeamPRs = append(result.TeamPRs, pr)
-			} else {
-				result.OtherPRs = append(result.OtherPRs, pr)
-			}
+		case models.SectionTeamPRs:
+			result.TeamPRs = append(result.TeamPRs, pr)
+		case models.SectionOtherPRs:
+			result.OtherPRs = append(result.OtherPRs, pr)
+		default:
+			r.section.PRs = append(r.section.PRs, pr)
 		}
-
-	case teamSet[pr.Author]:
-		result.TeamPRs = append(result.TeamPRs, pr)
-
-	case botSet[pr.Author]:
-		result.OtherPRs = append(result.OtherPRs, pr)
-
-	default:
-		result.OtherPRs = append(result.OtherPRs, pr)
+		return
 	}
+	result.OtherPRs = append(result.OtherPRs, pr)
 }
 
 // toSet converts a slice of strings into a set (map) for O(1) lookup.
@@ -135,6 +220,21 @@ func contains(slice []string, item string) bool {
 	return false
 }
 
+// findMyTeam returns the first requested team that is one of myTeams
+// (normalized names), or "" if none is. Team names without an organization
+// are taken to belong to the repository's owner.
+func findMyTeam(requested []string, myTeams map[string]bool, owner string) string {
+	for _, team := range requested {
+		if !strings.Contains(team, "/") {
+			team = owner + "/" + team
+		}
+		if myTeams[config.NormalizeTeam(team)] {
+			return team
+		}
+	}
+	return ""
+}
+
 // findMyReviewStatus finds the user's most recent review status on a PR.
 // Returns ReviewStateNone if the user hasn't reviewed the PR.
 func findMyReviewStatus(reviews []models.Review, username string) models.ReviewState {
@@ -153,6 +253,30 @@ func findMyReviewStatus(reviews []models.Review, username string) models.ReviewS
 	return latest.State
 }
 
+// needsReReview reports whether commits were pushed to the PR after the
+// user's latest review, if that review approved, requested changes, or
+// was dismissed (as stale approvals are when new commits are pushed).
+// Comments alone don't ask for a re-review. PRs or reviews without commit
+// SHAs (e.g. from an older cache) never need re-review.
+func needsReReview(pr *models.PR, username string) bool {
+	if pr.HeadSHA == "" {
+		return false
+	}
+
+	var latest *models.Review
+	for i := range pr.Reviews {
+		r := &pr.Reviews[i]
+		if r.Author != username || r.State == models.ReviewStatePending || r.State == models.ReviewStateCommented {
+			continue
+		}
+		if latest == nil || r.Submitted.After(latest.Submitted) {
+			latest = r
+		}
+	}
+
+	return latest != nil && latest.CommitSHA != "" && latest.CommitSHA != pr.HeadSHA
+}
+
 // isTooOld returns true if the PR is older than maxAgeDays.
 // Returns false if maxAgeDays is 0 (no limit) or negative.
 func isTooOld(pr *models.PR, maxAgeDays int) bool {
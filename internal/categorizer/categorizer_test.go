@@ -2,11 +2,14 @@ package categorizer
 
 import (
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"prt/internal/config"
 	"prt/internal/models"
+	"prt/internal/stacks"
+	"prt/internal/state"
 )
 
 func TestCategorize_EmptyRepos(t *testing.T) {
@@ -81,6 +84,45 @@ func TestCategorize_NeedsMyAttention_ReviewRequested(t *testing.T) {
 	}
 }
 
+func TestCategorize_NeedsMyAttention_TeamReviewRequested(t *testing.T) {
+	c := NewCategorizer()
+	cfg := &config.Config{MyTeams: []string{"@Org/Backend", "other/web"}}
+
+	repos := []*models.Repository{
+		{
+			Owner: "org",
+			Name:  "test-repo",
+			PRs: []*models.PR{
+				{Number: 1, Author: "alice", TeamReviewRequests: []string{"org/frontend", "org/backend"}},
+				{Number: 2, Author: "alice", TeamReviewRequests: []string{"backend"}},
+				{Number: 3, Author: "alice", TeamReviewRequests: []string{"org/web"}},
+				{Number: 4, Author: "alice", ReviewRequests: []string{"testuser"}, TeamReviewRequests: []string{"org/backend"}},
+			},
+		},
+	}
+
+	result := c.Categorize(repos, cfg, "testuser")
+
+	if len(result.NeedsMyAttention) != 3 {
+		t.Fatalf("Expected 3 PRs in NeedsMyAttention, got %d", len(result.NeedsMyAttention))
+	}
+	wantTeams := map[int]string{1: "org/backend", 2: "org/backend", 4: ""}
+	for _, pr := range result.NeedsMyAttention {
+		want, ok := wantTeams[pr.Number]
+		if !ok {
+			t.Errorf("Unexpected PR #%d in NeedsMyAttention", pr.Number)
+			continue
+		}
+		if !pr.IsReviewRequestedFromMe || pr.RequestedTeam != want {
+			t.Errorf("PR #%d: IsReviewRequestedFromMe = %v, RequestedTeam = %q; want true, %q",
+				pr.Number, pr.IsReviewRequestedFromMe, pr.RequestedTeam, want)
+		}
+	}
+	if len(result.OtherPRs) != 1 || result.OtherPRs[0].Number != 3 {
+		t.Error("Expected PR #3, requested from another org's team, in OtherPRs")
+	}
+}
+
 func TestCategorize_NeedsMyAttention_Assigned(t *testing.T) {
 	c := NewCategorizer()
 	cfg := &config.Config{}
@@ -145,6 +187,89 @@ func TestCategorize_ApprovedPR_NotNeedsAttention(t *testing.T) {
 	}
 }
 
+func TestCategorize_ReReviewNeedsAttention(t *testing.T) {
+	c := NewCategorizer()
+	cfg := &config.Config{
+		TeamMembers: []string{"alice"},
+	}
+
+	now := time.Now()
+	approved := func(number int, reviewed, head string) *models.PR {
+		return &models.PR{
+			Number:  number,
+			Author:  "alice",
+			HeadSHA: head,
+			Reviews: []models.Review{
+				{Author: "testuser", State: models.ReviewStateApproved, Submitted: now, CommitSHA: reviewed},
+			},
+		}
+	}
+	repos := []*models.Repository{
+		{
+			Name: "test-repo",
+			PRs: []*models.PR{
+				approved(1, "aaa", "bbb"), // Pushed to since my approval
+				approved(2, "bbb", "bbb"), // Unchanged
+			},
+		},
+	}
+
+	result := c.Categorize(repos, cfg, "testuser")
+
+	// No longer a requested reviewer (GitHub drops the request once I review),
+	// but new commits since my approval still need my attention
+	if len(result.NeedsMyAttention) != 1 || result.NeedsMyAttention[0].Number != 1 {
+		t.Fatalf("Expected PR #1 in NeedsMyAttention, got %d PRs", len(result.NeedsMyAttention))
+	}
+	if !result.NeedsMyAttention[0].NeedsReReview {
+		t.Error("Expected NeedsReReview to be true")
+	}
+	if len(result.TeamPRs) != 1 || result.TeamPRs[0].NeedsReReview {
+		t.Errorf("Expected unchanged PR #2 in TeamPRs without re-review")
+	}
+}
+
+func TestNeedsReReview(t *testing.T) {
+	now := time.Now()
+	review := func(state models.ReviewState, sha string, ago time.Duration) models.Review {
+		return models.Review{Author: "testuser", State: state, Submitted: now.Add(-ago), CommitSHA: sha}
+	}
+
+	tests := []struct {
+		name    string
+		head    string
+		reviews []models.Review
+		want    bool
+	}{
+		{"not reviewed", "bbb", nil, false},
+		{"approved head", "bbb", []models.Review{review(models.ReviewStateApproved, "bbb", 0)}, false},
+		{"approved older commit", "bbb", []models.Review{review(models.ReviewStateApproved, "aaa", 0)}, true},
+		{"changes requested on older commit", "bbb", []models.Review{review(models.ReviewStateChangesRequested, "aaa", 0)}, true},
+		{"stale approval dismissed", "bbb", []models.Review{review(models.ReviewStateDismissed, "aaa", 0)}, true},
+		{"only commented", "bbb", []models.Review{review(models.ReviewStateCommented, "aaa", 0)}, false},
+		{"comment after approval", "bbb", []models.Review{
+			review(models.ReviewStateApproved, "aaa", time.Hour),
+			review(models.ReviewStateCommented, "bbb", 0),
+		}, true},
+		{"re-approved", "bbb", []models.Review{
+			review(models.ReviewStateApproved, "aaa", time.Hour),
+			review(models.ReviewStateApproved, "bbb", 0),
+		}, false},
+		{"other reviewer", "bbb", []models.Review{{Author: "other", State: models.ReviewStateApproved, CommitSHA: "aaa"}}, false},
+		{"unknown head", "", []models.Review{review(models.ReviewStateApproved, "aaa", 0)}, false},
+		{"unknown review commit", "bbb", []models.Review{review(models.ReviewStateApproved, "", 0)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := &models.PR{HeadSHA: tt.head, Reviews: tt.reviews}
+			if got := needsReReview(pr, "testuser"); got != tt.want {
+				t.Errorf("needsReReview() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestCategorize_TeamPRs(t *testing.T) {
 	c := NewCategorizer()
 	cfg := &config.Config{
@@ -556,3 +681,152 @@ func TestCategorize_MaxPRAgeDays_NoLimit(t *testing.T) {
 		t.Errorf("Expected 2 PRs in MyPRs (no age limit), got %d", len(result.MyPRs))
 	}
 }
+
+func TestCategorize_WithState(t *testing.T) {
+	newRepos := func() []*models.Repository {
+		return []*models.Repository{
+			{
+				Name:  "api",
+				Owner: "org",
+				PRs: []*models.PR{
+					{Number: 1, Author: "bob", ReviewRequests: []string{"testuser"}},
+					{Number: 2, Author: "bob", ReviewRequests: []string{"testuser"}},
+					{Number: 3, Author: "testuser"},
+					{Number: 4, Author: "testuser", CreatedAt: time.Now()},
+				},
+			},
+		}
+	}
+
+	st := state.New()
+	st.Update("org/api#1", func(m *state.PRMark) { m.Snooze = &state.Snooze{At: time.Now(), UntilUpdate: true} })
+	st.Update("org/api#3", func(m *state.PRMark) { m.Muted = true })
+	st.Update("org/api#4", func(m *state.PRMark) { m.Pinned = true })
+
+	result := NewCategorizer(WithState(st)).Categorize(newRepos(), &config.Config{}, "testuser")
+
+	if len(result.NeedsMyAttention) != 1 || result.NeedsMyAttention[0].Number != 2 {
+		t.Errorf("NeedsMyAttention = %d PRs, want only #2 (#1 is snoozed)", len(result.NeedsMyAttention))
+	}
+	if len(result.MyPRs) != 1 || !result.MyPRs[0].Pinned {
+		t.Errorf("MyPRs = %d PRs, want only the pinned #4 (#3 is muted)", len(result.MyPRs))
+	}
+	if len(result.SnoozedPRs) != 2 || !result.SnoozedPRs[0].Snoozed || !result.SnoozedPRs[1].Muted {
+		t.Errorf("SnoozedPRs = %+v, want #1 snoozed and #3 muted", result.SnoozedPRs)
+	}
+
+	shown := NewCategorizer(WithState(st), WithShowSnoozed(true)).Categorize(newRepos(), &config.Config{}, "testuser")
+
+	if len(shown.SnoozedPRs) != 0 {
+		t.Errorf("SnoozedPRs = %d PRs, want none with --show-snoozed", len(shown.SnoozedPRs))
+	}
+	if len(shown.NeedsMyAttention) != 2 || len(shown.MyPRs) != 2 {
+		t.Fatalf("expected all PRs in their sections with --show-snoozed, got %d needing attention and %d mine",
+			len(shown.NeedsMyAttention), len(shown.MyPRs))
+	}
+	if shown.MyPRs[0].Number != 4 {
+		t.Errorf("MyPRs[0] = #%d, want the pinned #4 first", shown.MyPRs[0].Number)
+	}
+}
+
+func TestCategorize_Stacks(t *testing.T) {
+	newRepos := func() []*models.Repository {
+		return []*models.Repository{
+			{
+				Name:  "lib",
+				Owner: "org",
+				PRs: []*models.PR{
+					{Number: 1, Author: "testuser", HeadOwner: "org", HeadBranch: "feature", BaseBranch: "main"},
+					// A fork's branch of the same name is not stacked on
+					{Number: 2, Author: "bob", HeadOwner: "bob", HeadBranch: "feature", BaseBranch: "main", IsCrossRepository: true},
+					{Number: 3, Author: "testuser", HeadOwner: "org", HeadBranch: "feature-2", BaseBranch: "feature"},
+				},
+			},
+			{
+				Name:  "app",
+				Owner: "org",
+				PRs: []*models.PR{
+					{Number: 4, Author: "testuser", HeadOwner: "org", HeadBranch: "use-feature", BaseBranch: "main", DependsOn: []string{"lib#1"}},
+					// Stacked on a PR that was merged since
+					{Number: 5, Author: "testuser", HeadBranch: "cleanup-tests", BaseBranch: "cleanup"},
+				},
+				ClosedPRs: []*models.PR{
+					{Number: 3, HeadBranch: "cleanup", BaseBranch: "main", State: models.PRStateMerged},
+				},
+			},
+		}
+	}
+
+	result := NewCategorizer().Categorize(newRepos(), &config.Config{}, "testuser")
+
+	lib := result.Stacks["org/lib"]
+	if node := stacks.GetStackForPR(lib, 3); node == nil || node.PR.Number != 1 {
+		t.Errorf("#3 should be stacked on #1, got root %+v", node)
+	}
+	if node := stacks.GetStackForPR(lib, 2); node == nil || node.PR.Number != 2 || node.HasChildren() {
+		t.Errorf("the fork's #2 should be a root without children, got %+v", node)
+	}
+	app := stacks.GetStackForPR(result.Stacks["org/app"], 4)
+	if app == nil || len(app.DependsOn) != 0 {
+		t.Errorf("#4 should not be linked to its dependency without link_depends_on, got %+v", app)
+	}
+	if node := stacks.GetStackForPR(result.Stacks["org/app"], 5); node == nil || !node.IsOrphan {
+		t.Errorf("#5 should be orphaned by the merged #3, got %+v", node)
+	}
+	if app.IsOrphan {
+		t.Error("#4 targets main and should not be orphaned")
+	}
+
+	result = NewCategorizer().Categorize(newRepos(), &config.Config{LinkDependsOn: true}, "testuser")
+
+	app = stacks.GetStackForPR(result.Stacks["org/app"], 4)
+	if app == nil || len(app.DependsOn) != 1 || app.DependsOn[0].PR.Key() != "org/lib#1" || !app.IsBlocked() {
+		t.Errorf("#4 should depend on and be blocked by org/lib#1, got %+v", app)
+	}
+}
+
+func TestCategorize_CIIgnoreChecks(t *testing.T) {
+	repos := []*models.Repository{
+		{
+			Name:  "api",
+			Owner: "org",
+			PRs: []*models.PR{
+				{
+					Number:   1,
+					Author:   "testuser",
+					CIStatus: models.CIStatusFailing,
+					Checks: []models.Check{
+						{Name: "build", State: "COMPLETED", Conclusion: "SUCCESS"},
+						{Name: "codecov/patch", State: "FAILURE"},
+					},
+				},
+				{
+					Number:   2,
+					Author:   "testuser",
+					CIStatus: models.CIStatusFailing,
+					Checks: []models.Check{
+						{Name: "build", State: "COMPLETED", Conclusion: "FAILURE"},
+						{Name: "codecov/project", State: "FAILURE"},
+					},
+				},
+				// Without checks (e.g. from an older snapshot) the status stays
+				{Number: 3, Author: "testuser", CIStatus: models.CIStatusFailing},
+			},
+		},
+	}
+
+	cfg := &config.Config{CIIgnoreChecks: []string{"codecov/*"}}
+	result := NewCategorizer().Categorize(repos, cfg, "testuser")
+
+	want := map[int]models.CIStatus{1: models.CIStatusPassing, 2: models.CIStatusFailing, 3: models.CIStatusFailing}
+	for _, pr := range result.MyPRs {
+		if pr.CIStatus != want[pr.Number] {
+			t.Errorf("#%d CIStatus = %q, want %q", pr.Number, pr.CIStatus, want[pr.Number])
+		}
+		for _, check := range pr.Checks {
+			if check.Ignored != strings.HasPrefix(check.Name, "codecov/") {
+				t.Errorf("#%d check %q Ignored = %v", pr.Number, check.Name, check.Ignored)
+			}
+		}
+	}
+}
@@ -0,0 +1,376 @@
+package categorizer
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gobwas/glob"
+
+	"prt/internal/config"
+	"prt/internal/history"
+	"prt/internal/models"
+	"prt/internal/stacks"
+)
+
+// Query is a parsed --query expression. A PR matches when every term of
+// the query holds for it.
+type Query struct {
+	source string
+	terms  []queryTerm
+}
+
+// queryTerm is one whitespace-separated term of a query.
+type queryTerm struct {
+	negate bool
+	match  func(pr *models.PR, ctx *ruleContext) bool
+}
+
+// QueryFields lists the fields accepted in field:value query terms.
+var QueryFields = []string{
+	"author", "label", "repo", "base", "head", "ci", "review",
+	"milestone", "title", "age", "updated", "size", "is",
+}
+
+// queryFlags are the properties accepted as bare terms ("draft") and with
+// is: ("is:draft").
+var queryFlags = map[string]func(pr *models.PR) bool{
+	"draft":     func(pr *models.PR) bool { return pr.IsDraft },
+	"conflicts": func(pr *models.PR) bool { return pr.HasConflicts() },
+	"fork":      func(pr *models.PR) bool { return pr.IsCrossRepository },
+	"pinned":    func(pr *models.PR) bool { return pr.Pinned },
+	"snoozed":   func(pr *models.PR) bool { return pr.Snoozed },
+	"muted":     func(pr *models.PR) bool { return pr.Muted },
+}
+
+// ParseQuery parses a query such as
+//
+//	author:alice ci:failing age:>7d label:urgent -draft repo:api-*
+//
+// Terms are separated by whitespace and must all hold. Each term is
+// field:value, a flag such as draft or conflicts, or the name of one of the
+// saved queries, which stands for all of its terms. A leading "-" negates a
+// term, comma-separated values match any of them, and values with spaces
+// can be double-quoted (title:"fix login"). Saved queries can't refer to
+// other saved queries.
+func ParseQuery(s string, saved map[string]string) (*Query, error) {
+	return parseQuery(s, saved, true)
+}
+
+// parseQuery parses a query, expanding saved queries if expand is set.
+func parseQuery(s string, saved map[string]string, expand bool) (*Query, error) {
+	tokens, err := splitQuery(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("invalid query %q: no terms", s)
+	}
+
+	q := &Query{source: strings.Join(strings.Fields(s), " ")}
+	for _, tok := range tokens {
+		t, err := parseQueryTerm(tok, saved, expand)
+		if err != nil {
+			return nil, err
+		}
+		q.terms = append(q.terms, t)
+	}
+	return q, nil
+}
+
+// splitQuery splits a query into terms at whitespace outside double
+// quotes, removing the quotes.
+func splitQuery(s string) ([]string, error) {
+	var tokens []string
+	var tok strings.Builder
+	inToken, quoted := false, false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			inToken = true
+		case !quoted && (r == ' ' || r == '\t' || r == '\n'):
+			if inToken {
+				tokens = append(tokens, tok.String())
+				tok.Reset()
+				inToken = false
+			}
+		default:
+			tok.WriteRune(r)
+			inToken = true
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("invalid query %q: unterminated quote", s)
+	}
+	if inToken {
+		tokens = append(tokens, tok.String())
+	}
+	return tokens, nil
+}
+
+// parseQueryTerm parses one term of a query.
+func parseQueryTerm(tok string, saved map[string]string, expand bool) (queryTerm, error) {
+	body, negate := strings.CutPrefix(tok, "-")
+	t := queryTerm{negate: negate}
+
+	field, value, ok := strings.Cut(body, ":")
+	if !ok {
+		name := strings.ToLower(body)
+		if flag, ok := queryFlags[name]; ok {
+			t.match = func(pr *models.PR, _ *ruleContext) bool { return flag(pr) }
+			return t, nil
+		}
+		if s, ok := saved[name]; ok && expand {
+			sub, err := parseQuery(s, nil, false)
+			if err != nil {
+				return t, fmt.Errorf("saved query %q: %w", name, err)
+			}
+			t.match = sub.matches
+			return t, nil
+		}
+		return t, fmt.Errorf("unknown query term %q (expected field:value, a flag like draft, or the name of a saved query)", tok)
+	}
+
+	field = strings.ToLower(field)
+	if value == "" {
+		return t, fmt.Errorf("invalid query term %q: missing value", tok)
+	}
+	values := strings.Split(value, ",")
+	if slices.Contains(values, "") {
+		return t, fmt.Errorf("invalid query term %q: empty value", tok)
+	}
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("invalid query term %q: "+format, append([]any{tok}, args...)...)
+	}
+
+	switch field {
+	case "author":
+		t.match = func(pr *models.PR, ctx *ruleContext) bool {
+			return matchesAny(values, func(a string) bool { return ctx.isAuthor(pr, a) })
+		}
+
+	case "label":
+		t.match = func(pr *models.PR, _ *ruleContext) bool {
+			return matchesAny(values, func(l string) bool { return hasLabel(pr, l) })
+		}
+
+	case "repo", "base", "head":
+		globs := make([]glob.Glob, 0, len(values))
+		for _, v := range values {
+			g, err := glob.Compile(v)
+			if err != nil {
+				return t, invalid("%v", err)
+			}
+			globs = append(globs, g)
+		}
+		t.match = func(pr *models.PR, _ *ruleContext) bool {
+			switch field {
+			case "repo":
+				return matchesGlob(globs, pr.RepoFullName(), pr.RepoName)
+			case "base":
+				return matchesGlob(globs, pr.BaseBranch)
+			}
+			return matchesGlob(globs, pr.HeadBranch)
+		}
+
+	case "ci":
+		for _, v := range values {
+			if !slices.Contains(config.ValidCIStatuses, v) {
+				return t, invalid("must be one of %s", strings.Join(config.ValidCIStatuses, ", "))
+			}
+		}
+		t.match = func(pr *models.PR, _ *ruleContext) bool {
+			return slices.Contains(values, string(ciStatus(pr)))
+		}
+
+	case "review":
+		for _, v := range values {
+			if !slices.Contains(config.ValidReviews, v) {
+				return t, invalid("must be one of %s", strings.Join(config.ValidReviews, ", "))
+			}
+		}
+		t.match = func(pr *models.PR, _ *ruleContext) bool {
+			return matchesAny(values, func(r string) bool { return hasReviewState(pr, r) })
+		}
+
+	case "milestone":
+		t.match = func(pr *models.PR, _ *ruleContext) bool {
+			return matchesAny(values, func(m string) bool { return strings.EqualFold(pr.Milestone, m) })
+		}
+
+	case "title":
+		// Titles may contain commas, so the value is matched whole
+		substr := strings.ToLower(value)
+		t.match = func(pr *models.PR, _ *ruleContext) bool {
+			return strings.Contains(strings.ToLower(pr.Title), substr)
+		}
+
+	case "age", "updated":
+		op, d, ok := cutComparison(value)
+		if !ok {
+			return t, invalid("expected a comparison with a duration, like >7d or <=12h")
+		}
+		dur, err := history.ParseDuration(d)
+		if err != nil || dur < 0 {
+			return t, invalid("invalid duration %q (expected e.g. 12h, 3d, or 2w)", d)
+		}
+		t.match = func(pr *models.PR, ctx *ruleContext) bool {
+			since := pr.CreatedAt
+			if field == "updated" {
+				since = lastActivity(pr)
+			}
+			return compare(op, ctx.now.Sub(since), dur)
+		}
+
+	case "size":
+		if op, n, ok := cutComparison(value); ok {
+			lines, err := strconv.Atoi(n)
+			if err != nil || lines < 0 {
+				return t, invalid("expected a number of changed lines after %s", op)
+			}
+			t.match = func(pr *models.PR, _ *ruleContext) bool {
+				return compare(op, pr.ChangedLines(), lines)
+			}
+			break
+		}
+		sizes := make([]models.PRSize, len(values))
+		for i, v := range values {
+			sizes[i] = models.PRSize(strings.ToUpper(v))
+			if !slices.Contains(models.PRSizes, sizes[i]) {
+				return t, invalid("expected a size class (XS, S, M, L, XL) or a comparison like >500")
+			}
+		}
+		t.match = func(pr *models.PR, _ *ruleContext) bool {
+			return slices.Contains(sizes, pr.Size())
+		}
+
+	case "is":
+		flags := make([]func(pr *models.PR) bool, len(values))
+		for i, v := range values {
+			flag, ok := queryFlags[strings.ToLower(v)]
+			if !ok {
+				return t, invalid("must be one of %s", strings.Join(sortedKeys(queryFlags), ", "))
+			}
+			flags[i] = flag
+		}
+		t.match = func(pr *models.PR, _ *ruleContext) bool {
+			return slices.ContainsFunc(flags, func(flag func(*models.PR) bool) bool { return flag(pr) })
+		}
+
+	default:
+		return t, fmt.Errorf("unknown query field %q in %q (must be one of %s)", field, tok, strings.Join(QueryFields, ", "))
+	}
+	return t, nil
+}
+
+// cutComparison splits a value such as ">=7d" into its comparison operator
+// and operand, reporting false if it doesn't start with one.
+func cutComparison(value string) (op, operand string, ok bool) {
+	for _, op := range []string{">=", "<=", ">", "<"} {
+		if operand, ok := strings.CutPrefix(value, op); ok && operand != "" {
+			return op, operand, true
+		}
+	}
+	return "", "", false
+}
+
+// compare reports whether a op b holds, for an operator from cutComparison.
+func compare[T int | time.Duration](op string, a, b T) bool {
+	switch op {
+	case ">=":
+		return a >= b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	}
+	return a < b
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// String returns the query as given, with whitespace normalized.
+func (q *Query) String() string {
+	return q.source
+}
+
+// matches reports whether every term of the query holds for pr.
+func (q *Query) matches(pr *models.PR, ctx *ruleContext) bool {
+	for _, t := range q.terms {
+		if t.match(pr, ctx) == t.negate {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter removes the PRs that don't match the query from every section of
+// result, and from its snoozed PRs, evaluating ages at now. Stacks are
+// rebuilt from the remaining PRs, so that a PR whose parent was filtered
+// out is shown as the root of its stack; orphaned PRs stay flagged.
+func (q *Query) Filter(result *models.ScanResult, cfg *config.Config, now time.Time) {
+	ctx := &ruleContext{
+		username: result.Username,
+		teamSet:  toSet(cfg.TeamMembers),
+		botSet:   toSet(cfg.Bots),
+		now:      now,
+	}
+
+	byRepo := make(map[string][]*models.PR)
+	keep := func(prs []*models.PR) []*models.PR {
+		kept := make([]*models.PR, 0, len(prs))
+		for _, pr := range prs {
+			if q.matches(pr, ctx) {
+				kept = append(kept, pr)
+				byRepo[pr.RepoFullName()] = append(byRepo[pr.RepoFullName()], pr)
+			}
+		}
+		return kept
+	}
+
+	result.MyPRs = keep(result.MyPRs)
+	result.NeedsMyAttention = keep(result.NeedsMyAttention)
+	result.TeamPRs = keep(result.TeamPRs)
+	result.OtherPRs = keep(result.OtherPRs)
+	for _, section := range result.CustomSections() {
+		section.PRs = keep(section.PRs)
+	}
+	result.SnoozedPRs = keep(result.SnoozedPRs)
+
+	// Orphaned PRs stay orphaned; their merged parents aren't in result
+	orphans := make(map[string]bool)
+	for _, stack := range result.Stacks {
+		if stack == nil {
+			continue
+		}
+		for _, node := range stack.AllNodes {
+			if node.IsOrphan {
+				orphans[node.PR.Key()] = true
+			}
+		}
+	}
+
+	result.Stacks = make(map[string]*models.Stack, len(byRepo))
+	for repo, prs := range byRepo {
+		stack := stacks.DetectStacks(prs)
+		for _, node := range stack.AllNodes {
+			node.IsOrphan = orphans[node.PR.Key()]
+		}
+		result.Stacks[repo] = stack
+	}
+	if cfg.LinkDependsOn {
+		stacks.LinkDependencies(result.Stacks)
+	}
+	result.Query = q.String()
+}
@@ -0,0 +1,217 @@
+package categorizer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"prt/internal/config"
+	"prt/internal/models"
+	"prt/internal/stacks"
+)
+
+func TestParseQuery_Matches(t *testing.T) {
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	ctx := &ruleContext{
+		username: "me",
+		teamSet:  map[string]bool{"alice": true},
+		botSet:   map[string]bool{"dependabot[bot]": true},
+		now:      now,
+	}
+	pr := &models.PR{
+		Number:            1,
+		Title:             "Fix login redirect, again",
+		Author:            "alice",
+		RepoOwner:         "org",
+		RepoName:          "api-server",
+		BaseBranch:        "main",
+		HeadBranch:        "fix/login",
+		Labels:            []string{"Urgent", "bug"},
+		CIStatus:          models.CIStatusFailing,
+		CreatedAt:         now.Add(-10 * 24 * time.Hour),
+		UpdatedAt:         now.Add(-2 * time.Hour),
+		Additions:         120,
+		Deletions:         30,
+		Milestone:         "v2.0",
+		Mergeable:         models.MergeableConflicting,
+		IsCrossRepository: true,
+		Pinned:            true,
+	}
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"author:alice", true},
+		{"author:ALICE", true},
+		{"author:bob,alice", true},
+		{"author:@team", true},
+		{"author:@me", false},
+		{"-author:@bots", true},
+		{"label:urgent", true},
+		{"label:urgent,docs", true},
+		{"label:docs", false},
+		{"repo:api-*", true},
+		{"repo:org/api-*", true},
+		{"repo:web", false},
+		{"base:main", true},
+		{"head:fix/*", true},
+		{"ci:failing", true},
+		{"ci:passing,pending", false},
+		{"review:unreviewed", true},
+		{"review:approved", false},
+		{"milestone:V2.0", true},
+		{"title:login", true},
+		{`title:"login redirect, again"`, true},
+		{"title:logout", false},
+		{"age:>7d", true},
+		{"age:>=1w", true},
+		{"age:<7d", false},
+		{"updated:<12h", true},
+		{"updated:>1d", false},
+		{"size:m", true},
+		{"size:xs,s", false},
+		{"size:>=150", true},
+		{"size:<100", false},
+		{"draft", false},
+		{"-draft", true},
+		{"conflicts", true},
+		{"fork", true},
+		{"pinned", true},
+		{"is:snoozed,muted", false},
+		{"is:draft,pinned", true},
+		{"author:alice ci:failing age:>7d label:urgent -draft repo:api-*", true},
+		{"author:alice ci:passing", false},
+		{"stale", true},
+		{"-stale", false},
+		{"stale -fork", false},
+	}
+
+	saved := map[string]string{"stale": "age:>7d -draft"}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := ParseQuery(tt.query, saved)
+			if err != nil {
+				t.Fatalf("ParseQuery(%q) error = %v", tt.query, err)
+			}
+			if got := q.matches(pr, ctx); got != tt.want {
+				t.Errorf("ParseQuery(%q) matches = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQuery_Errors(t *testing.T) {
+	saved := map[string]string{
+		"nested": "stale",
+		"broken": "ci:red",
+		"stale":  "age:>7d",
+	}
+
+	tests := []struct {
+		query   string
+		wantErr string
+	}{
+		{"", "no terms"},
+		{"   ", "no terms"},
+		{`title:"open`, "unterminated quote"},
+		{"alice", "unknown query term"},
+		{"-", "unknown query term"},
+		{"owner:alice", "unknown query field"},
+		{"author:", "missing value"},
+		{"label:bug,", "empty value"},
+		{"ci:red", "must be one of"},
+		{"review:lgtm", "must be one of"},
+		{"age:7d", "expected a comparison"},
+		{"age:>7x", "invalid duration"},
+		{"size:huge", "expected a size class"},
+		{"size:>many", "expected a number"},
+		{"is:stale", "must be one of"},
+		{"repo:[", "invalid query term"},
+		{"nested", `saved query "nested"`},
+		{"broken", `saved query "broken"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := ParseQuery(tt.query, saved)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseQuery(%q) error = %v, want %q", tt.query, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestQuery_String(t *testing.T) {
+	q, err := ParseQuery("  author:alice \t -draft ", nil)
+	if err != nil {
+		t.Fatalf("ParseQuery() error = %v", err)
+	}
+	if got := q.String(); got != "author:alice -draft" {
+		t.Errorf("String() = %q, want %q", got, "author:alice -draft")
+	}
+}
+
+func TestQuery_Filter(t *testing.T) {
+	now := time.Now()
+	newPR := func(number int, head, base string, labels ...string) *models.PR {
+		return &models.PR{
+			Number: number, RepoOwner: "org", RepoName: "api",
+			HeadBranch: head, BaseBranch: base, Labels: labels, CreatedAt: now,
+		}
+	}
+	parent := newPR(1, "feature", "main")
+	child := newPR(2, "feature-tests", "feature", "urgent")
+	other := newPR(3, "docs", "main", "urgent")
+	custom := newPR(4, "security", "main")
+	snoozed := newPR(5, "later", "main", "urgent")
+
+	result := models.NewScanResult()
+	result.MyPRs = []*models.PR{parent, child}
+	result.TeamPRs = []*models.PR{other}
+	result.Sections = []*models.Section{
+		{Key: models.SectionMyPRs},
+		{Key: "security", PRs: []*models.PR{custom}},
+	}
+	result.SnoozedPRs = []*models.PR{snoozed}
+	result.Stacks["org/api"] = stacks.DetectStacks([]*models.PR{other})
+	result.Stacks["org/api"].AllNodes[0].IsOrphan = true
+	result.Stacks["org/web"] = nil
+
+	q, err := ParseQuery("label:urgent", nil)
+	if err != nil {
+		t.Fatalf("ParseQuery() error = %v", err)
+	}
+	q.Filter(result, &config.Config{}, now)
+
+	if len(result.MyPRs) != 1 || result.MyPRs[0] != child {
+		t.Errorf("MyPRs = %v, want only #2", result.MyPRs)
+	}
+	if len(result.TeamPRs) != 1 || result.TeamPRs[0] != other {
+		t.Errorf("TeamPRs = %v, want only #3", result.TeamPRs)
+	}
+	if len(result.Sections[1].PRs) != 0 {
+		t.Errorf("custom section PRs = %v, want none", result.Sections[1].PRs)
+	}
+	if len(result.SnoozedPRs) != 1 {
+		t.Errorf("SnoozedPRs = %v, want #5", result.SnoozedPRs)
+	}
+	if result.Query != "label:urgent" {
+		t.Errorf("Query = %q, want %q", result.Query, "label:urgent")
+	}
+
+	// The child's parent was filtered out, so it is now a root
+	stack := result.Stacks["org/api"]
+	if stack == nil || len(stack.AllNodes) != 3 {
+		t.Fatalf("stack = %+v, want the 3 matching PRs", stack)
+	}
+	for _, node := range stack.AllNodes {
+		if node.Parent != nil {
+			t.Errorf("#%d has parent #%d, want a root", node.PR.Number, node.Parent.PR.Number)
+		}
+		// #3 was orphaned before filtering
+		if node.IsOrphan != (node.PR == other) {
+			t.Errorf("#%d IsOrphan = %v", node.PR.Number, node.IsOrphan)
+		}
+	}
+}
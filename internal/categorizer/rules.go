@@ -0,0 +1,201 @@
+package categorizer
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gobwas/glob"
+
+	"prt/internal/config"
+	"prt/internal/models"
+)
+
+// rule is a section from the sections config, ready for matching.
+type rule struct {
+	section      *models.Section
+	order        int
+	match        config.SectionMatch
+	repos        []glob.Glob
+	baseBranches []glob.Glob
+}
+
+// ruleContext holds what rules match against besides the PR itself.
+type ruleContext struct {
+	username string
+	teamSet  map[string]bool
+	botSet   map[string]bool
+	now      time.Time
+}
+
+// compileRules builds rules from the configured sections, or from the
+// built-in sections if none are configured. Invalid glob patterns, which
+// config validation reports, never match.
+func compileRules(sections []config.Section) []*rule {
+	if len(sections) == 0 {
+		sections = config.DefaultSections()
+	}
+
+	rules := make([]*rule, 0, len(sections))
+	for _, s := range sections {
+		r := &rule{
+			section: &models.Section{Key: s.SectionKey(), Name: s.Name, Icon: s.Icon},
+			order:   s.Order,
+			match:   s.Match,
+		}
+		if !r.section.IsBuiltin() {
+			r.section.PRs = make([]*models.PR, 0)
+		}
+		r.repos = compileGlobs(s.Match.Repos)
+		r.baseBranches = compileGlobs(s.Match.BaseBranches)
+		rules = append(rules, r)
+	}
+	return rules
+}
+
+// compileGlobs compiles patterns, skipping invalid ones.
+func compileGlobs(patterns []string) []glob.Glob {
+	var globs []glob.Glob
+	for _, pattern := range patterns {
+		if g, err := glob.Compile(pattern); err == nil {
+			globs = append(globs, g)
+		}
+	}
+	return globs
+}
+
+// displaySections returns the rules' sections sorted by their configured
+// order, keeping config order for ties. Other PRs is always included, as it
+// collects PRs that match no rule.
+func displaySections(rules []*rule) []*models.Section {
+	sorted := append([]*rule(nil), rules...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].order < sorted[j].order
+	})
+
+	result := make([]*models.Section, 0, len(rules)+1)
+	hasOther := false
+	for _, r := range sorted {
+		result = append(result, r.section)
+		hasOther = hasOther || r.section.Key == models.SectionOtherPRs
+	}
+	if !hasOther {
+		result = append(result, &models.Section{Key: models.SectionOtherPRs, Name: "Other PRs"})
+	}
+	return result
+}
+
+// matches reports whether every predicate set on the rule holds for pr.
+func (r *rule) matches(pr *models.PR, ctx *ruleContext) bool {
+	m := r.match
+
+	if len(m.Authors) > 0 && !matchesAny(m.Authors, func(a string) bool { return ctx.isAuthor(pr, a) }) {
+		return false
+	}
+	if len(m.Labels) > 0 && !matchesAny(m.Labels, func(l string) bool { return hasLabel(pr, l) }) {
+		return false
+	}
+	if len(r.repos) > 0 && !matchesGlob(r.repos, pr.RepoFullName(), pr.RepoName) {
+		return false
+	}
+	if len(r.baseBranches) > 0 && !matchesGlob(r.baseBranches, pr.BaseBranch) {
+		return false
+	}
+	if m.Draft != nil && pr.IsDraft != *m.Draft {
+		return false
+	}
+	if len(m.CI) > 0 && !matchesAny(m.CI, func(ci string) bool { return ci == string(ciStatus(pr)) }) {
+		return false
+	}
+
+	age := ctx.now.Sub(pr.CreatedAt)
+	if m.MinAgeDays > 0 && age < days(m.MinAgeDays) {
+		return false
+	}
+	if m.MaxAgeDays > 0 && age > days(m.MaxAgeDays) {
+		return false
+	}
+
+	if len(m.Review) > 0 && !matchesAny(m.Review, func(r string) bool { return hasReviewState(pr, r) }) {
+		return false
+	}
+	return true
+}
+
+// isAuthor reports whether pr's author is login, or belongs to one of the
+// special authors entries.
+func (ctx *ruleContext) isAuthor(pr *models.PR, login string) bool {
+	switch login {
+	case config.AuthorMe:
+		return pr.Author == ctx.username
+	case config.AuthorTeam:
+		return ctx.teamSet[pr.Author]
+	case config.AuthorBots:
+		return ctx.botSet[pr.Author]
+	}
+	return strings.EqualFold(pr.Author, login)
+}
+
+// hasLabel reports whether pr has the label, ignoring case like GitHub does.
+func hasLabel(pr *models.PR, label string) bool {
+	for _, l := range pr.Labels {
+		if strings.EqualFold(l, label) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasReviewState reports whether pr is in the given review match state.
+func hasReviewState(pr *models.PR, state string) bool {
+	switch state {
+	case config.ReviewRequested:
+		return pr.IsReviewRequestedFromMe && pr.MyReviewStatus != models.ReviewStateApproved
+	case config.ReviewAssigned:
+		return pr.IsAssignedToMe && pr.MyReviewStatus != models.ReviewStateApproved
+	case config.ReviewReReview:
+		return pr.NeedsReReview
+	case config.ReviewApproved:
+		return pr.OverallReview() == models.ReviewStateApproved
+	case config.ReviewChangesRequested:
+		return pr.OverallReview() == models.ReviewStateChangesRequested
+	case config.ReviewUnreviewed:
+		return pr.OverallReview() == models.ReviewStateNone
+	}
+	return false
+}
+
+// ciStatus returns the PR's CI status, treating unknown as none.
+func ciStatus(pr *models.PR) models.CIStatus {
+	if pr.CIStatus == "" {
+		return models.CIStatusNone
+	}
+	return pr.CIStatus
+}
+
+// matchesAny reports whether match holds for any of values.
+func matchesAny(values []string, match func(string) bool) bool {
+	for _, v := range values {
+		if match(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesGlob reports whether any of globs matches any of names.
+func matchesGlob(globs []glob.Glob, names ...string) bool {
+	for _, g := range globs {
+		for _, name := range names {
+			if name != "" && g.Match(name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// days converts a number of days to a duration.
+func days(n int) time.Duration {
+	return time.Duration(n) * 24 * time.Hour
+}
@@ -0,0 +1,158 @@
+package categorizer
+
+import (
+	"testing"
+	"time"
+
+	"prt/internal/config"
+	"prt/internal/models"
+)
+
+func TestRule_Matches(t *testing.T) {
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	ctx := &ruleContext{
+		username: "me",
+		teamSet:  map[string]bool{"alice": true},
+		botSet:   map[string]bool{"dependabot[bot]": true},
+		now:      now,
+	}
+	yes, no := true, false
+
+	pr := &models.PR{
+		Author:     "alice",
+		Labels:     []string{"Security", "backend"},
+		RepoOwner:  "org",
+		RepoName:   "api",
+		BaseBranch: "release/1.2",
+		IsDraft:    false,
+		CIStatus:   models.CIStatusFailing,
+		CreatedAt:  now.Add(-3 * 24 * time.Hour),
+		Reviews: []models.Review{
+			{Author: "bob", State: models.ReviewStateChangesRequested, Submitted: now.Add(-48 * time.Hour)},
+			{Author: "bob", State: models.ReviewStateApproved, Submitted: now.Add(-24 * time.Hour)},
+		},
+		IsReviewRequestedFromMe: true,
+	}
+
+	tests := []struct {
+		name  string
+		match config.SectionMatch
+		want  bool
+	}{
+		{"empty matches everything", config.SectionMatch{}, true},
+		{"author login", config.SectionMatch{Authors: []string{"bob", "Alice"}}, true},
+		{"author other", config.SectionMatch{Authors: []string{"bob"}}, false},
+		{"author @team", config.SectionMatch{Authors: []string{config.AuthorTeam}}, true},
+		{"author @me", config.SectionMatch{Authors: []string{config.AuthorMe}}, false},
+		{"author @bots", config.SectionMatch{Authors: []string{config.AuthorBots}}, false},
+		{"label ignores case", config.SectionMatch{Labels: []string{"security"}}, true},
+		{"label missing", config.SectionMatch{Labels: []string{"urgent"}}, false},
+		{"repo by name", config.SectionMatch{Repos: []string{"api*"}}, true},
+		{"repo by full name", config.SectionMatch{Repos: []string{"org/*"}}, true},
+		{"repo other", config.SectionMatch{Repos: []string{"web"}}, false},
+		{"base branch", config.SectionMatch{BaseBranches: []string{"release/*"}}, true},
+		{"base branch other", config.SectionMatch{BaseBranches: []string{"main"}}, false},
+		{"not draft", config.SectionMatch{Draft: &no}, true},
+		{"draft", config.SectionMatch{Draft: &yes}, false},
+		{"ci", config.SectionMatch{CI: []string{"pending", "failing"}}, true},
+		{"ci other", config.SectionMatch{CI: []string{"passing"}}, false},
+		{"older than", config.SectionMatch{MinAgeDays: 2}, true},
+		{"not old enough", config.SectionMatch{MinAgeDays: 4}, false},
+		{"newer than", config.SectionMatch{MaxAgeDays: 3}, true},
+		{"too old", config.SectionMatch{MaxAgeDays: 2}, false},
+		{"review requested", config.SectionMatch{Review: []string{config.ReviewRequested}}, true},
+		{"review assigned", config.SectionMatch{Review: []string{config.ReviewAssigned}}, false},
+		{"latest review wins", config.SectionMatch{Review: []string{config.ReviewApproved}}, true},
+		{"changes requested superseded", config.SectionMatch{Review: []string{config.ReviewChangesRequested}}, false},
+		{"all predicates", config.SectionMatch{Authors: []string{config.AuthorTeam}, Labels: []string{"backend"}, CI: []string{"failing"}}, true},
+		{"one predicate fails", config.SectionMatch{Authors: []string{config.AuthorTeam}, Labels: []string{"frontend"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := compileRules([]config.Section{{Name: "Test", Match: tt.match}})[0]
+			if got := r.matches(pr, ctx); got != tt.want {
+				t.Errorf("matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCategorize_CustomSections(t *testing.T) {
+	c := NewCategorizer()
+	cfg := &config.Config{
+		TeamMembers: []string{"alice"},
+		Sections: append([]config.Section{
+			{Name: "Security", Icon: "🔒", Order: 25, Match: config.SectionMatch{Labels: []string{"security"}}},
+			{Name: "Dependabot", Order: 50, Match: config.SectionMatch{Authors: []string{"dependabot[bot]"}}},
+		}, config.DefaultSections()[:3]...),
+	}
+
+	repos := []*models.Repository{
+		{
+			Owner: "org",
+			Name:  "api",
+			PRs: []*models.PR{
+				{Number: 1, Author: "testuser", Labels: []string{"security"}}, // First match wins
+				{Number: 2, Author: "alice"},
+				{Number: 3, Author: "dependabot[bot]"},
+				{Number: 4, Author: "alice", Labels: []string{"security"}},
+				{Number: 5, Author: "stranger"}, // No section matches
+			},
+		},
+	}
+
+	result := c.Categorize(repos, cfg, "testuser")
+
+	var keys []string
+	for _, s := range result.Sections {
+		keys = append(keys, s.Key)
+	}
+	want := []string{"my_prs", "needs_my_attention", "security", "team_prs", "dependabot", "other_prs"}
+	if len(keys) != len(want) {
+		t.Fatalf("Sections = %v, want %v", keys, want)
+	}
+	for i := range want {
+		if keys[i] != want[i] {
+			t.Fatalf("Sections = %v, want %v (by order)", keys, want)
+		}
+	}
+
+	numbers := func(prs []*models.PR) []int {
+		var n []int
+		for _, pr := range prs {
+			n = append(n, pr.Number)
+		}
+		return n
+	}
+	security := result.Sections[2]
+	if got := numbers(security.PRs); len(got) != 2 || got[0] != 1 || got[1] != 4 {
+		t.Errorf("Security PRs = %v, want [1 4]", got)
+	}
+	if security.Name != "Security" || security.Icon != "🔒" {
+		t.Errorf("Security section = %+v", security)
+	}
+	if got := numbers(result.Sections[4].PRs); len(got) != 1 || got[0] != 3 {
+		t.Errorf("Dependabot PRs = %v, want [3]", got)
+	}
+	if got := numbers(result.TeamPRs); len(got) != 1 || got[0] != 2 {
+		t.Errorf("TeamPRs = %v, want [2]", got)
+	}
+	if len(result.MyPRs) != 0 {
+		t.Errorf("MyPRs = %v, want none (PR #1 matched Security first)", numbers(result.MyPRs))
+	}
+	if got := numbers(result.OtherPRs); len(got) != 1 || got[0] != 5 {
+		t.Errorf("OtherPRs = %v, want [5]", got)
+	}
+	if result.TotalPRs() != 5 {
+		t.Errorf("TotalPRs() = %d, want 5", result.TotalPRs())
+	}
+}
+
+func TestCategorize_DefaultSectionsWhenUnset(t *testing.T) {
+	result := NewCategorizer().Categorize(nil, &config.Config{}, "testuser")
+
+	if len(result.Sections) != 4 || result.Sections[0].Key != models.SectionMyPRs || result.Sections[3].Key != models.SectionOtherPRs {
+		t.Errorf("Sections = %+v, want the built-in sections", result.Sections)
+	}
+}
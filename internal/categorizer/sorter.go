@@ -2,36 +2,230 @@
 package categorizer
 
 import (
+	"cmp"
 	"sort"
+	"strings"
+	"time"
 
 	"prt/internal/config"
 	"prt/internal/models"
+	"prt/internal/priority"
 )
 
-// SortPRs sorts a slice of PRs by creation date.
-// Order can be "oldest" (oldest first) or "newest" (newest first).
-// Uses stable sort with PR number as secondary key for deterministic ordering.
-func SortPRs(prs []*models.PR, order string) {
+// compareFunc compares two PRs by one sort key, returning a negative number
+// if a sorts before b, a positive number if after, and 0 if they tie.
+type compareFunc func(a, b *models.PR) int
+
+// sortKeys maps each sort key in config.SortKeys to its comparison, except
+// priority, which scores PRs against the whole result (see SortResult).
+var sortKeys = map[string]compareFunc{
+	config.SortOldest: func(a, b *models.PR) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	},
+	config.SortNewest: func(a, b *models.PR) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	},
+	config.SortUpdated: func(a, b *models.PR) int {
+		return lastActivity(b).Compare(lastActivity(a))
+	},
+	config.SortSize: func(a, b *models.PR) int {
+		return cmp.Compare(a.ChangedLines(), b.ChangedLines())
+	},
+	config.SortApprovals: func(a, b *models.PR) int {
+		return cmp.Compare(countApprovals(b), countApprovals(a))
+	},
+	config.SortCI: func(a, b *models.PR) int {
+		return cmp.Compare(ciRank(a.CIStatus), ciRank(b.CIStatus))
+	},
+	config.SortRepo: func(a, b *models.PR) int {
+		return strings.Compare(strings.ToLower(a.RepoFullName()), strings.ToLower(b.RepoFullName()))
+	},
+	config.SortAuthor: func(a, b *models.PR) int {
+		return strings.Compare(strings.ToLower(a.Author), strings.ToLower(b.Author))
+	},
+}
+
+// lastActivity returns when the PR was last updated, or created if unknown.
+func lastActivity(pr *models.PR) time.Time {
+	if pr.UpdatedAt.IsZero() {
+		return pr.CreatedAt
+	}
+	return pr.UpdatedAt
+}
+
+// countApprovals counts the PR's approving reviews.
+func countApprovals(pr *models.PR) int {
+	n := 0
+	for _, r := range pr.Reviews {
+		if r.State == models.ReviewStateApproved {
+			n++
+		}
+	}
+	return n
+}
+
+// ciRank orders CI statuses by urgency: failing, pending, passing, none.
+func ciRank(status models.CIStatus) int {
+	switch status {
+	case models.CIStatusFailing:
+		return 0
+	case models.CIStatusPending:
+		return 1
+	case models.CIStatusPassing:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// sorter orders PRs by a list of comparisons, pinned PRs first.
+type sorter struct {
+	compares []compareFunc
+}
+
+// newSorter creates a sorter for a sort spec such as "ci,-updated". score
+// gives the priority of a PR for the priority key, which is skipped if
+// score is nil. Unknown keys are skipped; ties are broken by creation date
+// (oldest first), then PR number, so the order is deterministic.
+func newSorter(spec string, score func(pr *models.PR) float64) *sorter {
+	s := &sorter{}
+	for _, key := range config.ParseSort(spec) {
+		name, reverse := strings.CutPrefix(key, "-")
+
+		compare := sortKeys[name]
+		if name == config.SortPriority && score != nil {
+			compare = func(a, b *models.PR) int {
+				return cmp.Compare(score(b), score(a))
+			}
+		}
+		if compare == nil {
+			continue
+		}
+		if reverse {
+			forward := compare
+			compare = func(a, b *models.PR) int { return forward(b, a) }
+		}
+		s.compares = append(s.compares, compare)
+	}
+
+	s.compares = append(s.compares, sortKeys[config.SortOldest], func(a, b *models.PR) int {
+		return cmp.Compare(a.Number, b.Number)
+	})
+	return s
+}
+
+// sort sorts prs in place.
+func (s *sorter) sort(prs []*models.PR) {
 	sort.SliceStable(prs, func(i, j int) bool {
-		// Secondary sort by PR number for stability when times are equal
-		if prs[i].CreatedAt.Equal(prs[j].CreatedAt) {
-			return prs[i].Number < prs[j].Number
+		if prs[i].Pinned != prs[j].Pinned {
+			return prs[i].Pinned
 		}
+		for _, compare := range s.compares {
+			if c := compare(prs[i], prs[j]); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+}
+
+// SortPRs sorts a slice of PRs by a sort spec, pinned PRs first. The spec
+// is a comma-separated list of sort keys (see config.SortKeys), such as
+// "ci,updated"; an invalid spec sorts oldest first. The priority key needs
+// the whole scan result and is skipped here; SortResult applies it.
+func SortPRs(prs []*models.PR, spec string) {
+	newSorter(spec, nil).sort(prs)
+}
 
-		// Primary sort by creation time
-		switch order {
-		case config.SortNewest:
-			return prs[i].CreatedAt.After(prs[j].CreatedAt)
-		default: // SortOldest is default
-			return prs[i].CreatedAt.Before(prs[j].CreatedAt)
+// SortResult sorts all PR categories in a ScanResult by cfg.DefaultSort,
+// scoring priority at now. PRs in the same stack are kept together, in
+// stack order, at the position of the first of them.
+func SortResult(result *models.ScanResult, cfg *config.Config, now time.Time) {
+	var scorer *priority.Scorer
+	s := newSorter(cfg.DefaultSort, func(pr *models.PR) float64 {
+		if scorer == nil {
+			scorer = priority.NewScorer(result, cfg, now)
 		}
+		return scorer.Score(pr).Score
 	})
+	stackOrder := stackPositions(result.Stacks)
+
+	lists := [][]*models.PR{result.MyPRs, result.NeedsMyAttention, result.TeamPRs, result.OtherPRs, result.SnoozedPRs}
+	for _, section := range result.CustomSections() {
+		lists = append(lists, section.PRs)
+	}
+	for _, prs := range lists {
+		s.sort(prs)
+		keepStacksTogether(prs, stackOrder)
+	}
+}
+
+// stackPosition is where a PR sits in its stack: the key of the stack's
+// root PR and the PR's index in a depth-first walk from the root.
+type stackPosition struct {
+	root  string
+	index int
+}
+
+// stackPositions returns the stack position of every stacked PR, by key.
+func stackPositions(stacks map[string]*models.Stack) map[string]stackPosition {
+	positions := make(map[string]stackPosition)
+	var walk func(node *models.StackNode, root string)
+	walk = func(node *models.StackNode, root string) {
+		if node.PR != nil {
+			positions[node.PR.Key()] = stackPosition{root: root, index: len(positions)}
+		}
+		for _, child := range node.Children {
+			walk(child, root)
+		}
+	}
+
+	for _, stack := range stacks {
+		if stack == nil {
+			continue
+		}
+		for _, root := range stack.Roots {
+			if root.PR != nil && len(root.Children) > 0 {
+				walk(root, root.PR.Key())
+			}
+		}
+	}
+	return positions
 }
 
-// SortResult sorts all PR categories in a ScanResult.
-func SortResult(result *models.ScanResult, order string) {
-	SortPRs(result.MyPRs, order)
-	SortPRs(result.NeedsMyAttention, order)
-	SortPRs(result.TeamPRs, order)
-	SortPRs(result.OtherPRs, order)
+// keepStacksTogether reorders sorted prs so that PRs of the same stack are
+// adjacent, in stack order, starting where the first of them was. The tree
+// view renders a stack at its root, so the whole stack then appears at the
+// position of its best-sorted PR.
+func keepStacksTogether(prs []*models.PR, positions map[string]stackPosition) {
+	members := make(map[string][]*models.PR)
+	for _, pr := range prs {
+		if pos, ok := positions[pr.Key()]; ok {
+			members[pos.root] = append(members[pos.root], pr)
+		}
+	}
+	if len(members) == 0 {
+		return
+	}
+
+	ordered := make([]*models.PR, 0, len(prs))
+	placed := make(map[string]bool)
+	for _, pr := range prs {
+		pos, ok := positions[pr.Key()]
+		if !ok {
+			ordered = append(ordered, pr)
+			continue
+		}
+		if placed[pos.root] {
+			continue
+		}
+		placed[pos.root] = true
+
+		stack := members[pos.root]
+		sort.SliceStable(stack, func(i, j int) bool {
+			return positions[stack[i].Key()].index < positions[stack[j].Key()].index
+		})
+		ordered = append(ordered, stack...)
+	}
+	copy(prs, ordered)
 }
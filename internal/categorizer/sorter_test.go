@@ -6,6 +6,7 @@ import (
 
 	"prt/internal/config"
 	"prt/internal/models"
+	"prt/internal/stacks"
 )
 
 func TestSortPRs_Oldest(t *testing.T) {
@@ -27,6 +28,25 @@ func TestSortPRs_Oldest(t *testing.T) {
 	}
 }
 
+func TestSortPRs_PinnedFirst(t *testing.T) {
+	now := time.Now()
+	prs := []*models.PR{
+		{Number: 1, CreatedAt: now.Add(-3 * time.Hour)},
+		{Number: 2, CreatedAt: now.Add(-1 * time.Hour), Pinned: true},
+		{Number: 3, CreatedAt: now.Add(-2 * time.Hour)},
+		{Number: 4, CreatedAt: now.Add(-4 * time.Hour), Pinned: true},
+	}
+
+	SortPRs(prs, config.SortOldest)
+
+	expected := []int{4, 2, 1, 3}
+	for i, pr := range prs {
+		if pr.Number != expected[i] {
+			t.Errorf("position %d: got PR #%d, want #%d", i, pr.Number, expected[i])
+		}
+	}
+}
+
 func TestSortPRs_Newest(t *testing.T) {
 	now := time.Now()
 	prs := []*models.PR{
@@ -141,7 +161,7 @@ func TestSortResult(t *testing.T) {
 		},
 	}
 
-	SortResult(result, config.SortOldest)
+	SortResult(result, &config.Config{DefaultSort: config.SortOldest}, now)
 
 	// All categories should be sorted oldest first
 	categories := []struct {
@@ -173,10 +193,124 @@ func TestSortResult_Newest(t *testing.T) {
 		},
 	}
 
-	SortResult(result, config.SortNewest)
+	SortResult(result, &config.Config{DefaultSort: config.SortNewest}, now)
 
 	if result.MyPRs[0].Number != 2 || result.MyPRs[1].Number != 1 {
 		t.Errorf("expected newest first [2, 1], got [%d, %d]",
 			result.MyPRs[0].Number, result.MyPRs[1].Number)
 	}
 }
+
+func TestSortPRs_Keys(t *testing.T) {
+	now := time.Now()
+	newPRs := func() []*models.PR {
+		return []*models.PR{
+			{
+				Number: 1, RepoOwner: "org", RepoName: "web", Author: "carol",
+				CreatedAt: now.Add(-3 * time.Hour), UpdatedAt: now.Add(-2 * time.Hour),
+				Additions: 400, CIStatus: models.CIStatusPassing,
+				Reviews: []models.Review{{State: models.ReviewStateApproved}},
+			},
+			{
+				Number: 2, RepoOwner: "org", RepoName: "api", Author: "bob",
+				CreatedAt: now.Add(-2 * time.Hour),
+				Additions: 10, CIStatus: models.CIStatusFailing,
+			},
+			{
+				Number: 3, RepoOwner: "org", RepoName: "cli", Author: "alice",
+				CreatedAt: now.Add(-1 * time.Hour), UpdatedAt: now.Add(-30 * time.Minute),
+				Additions: 50, CIStatus: models.CIStatusPending,
+				Reviews: []models.Review{{State: models.ReviewStateApproved}, {State: models.ReviewStateApproved}},
+			},
+			{
+				Number: 4, RepoOwner: "org", RepoName: "api", Author: "bob",
+				CreatedAt: now.Add(-4 * time.Hour),
+				Additions: 10, CIStatus: models.CIStatusFailing,
+			},
+		}
+	}
+
+	tests := []struct {
+		spec     string
+		expected []int
+	}{
+		{config.SortUpdated, []int{3, 1, 2, 4}},
+		{config.SortSize, []int{4, 2, 3, 1}},
+		{"-size", []int{1, 3, 4, 2}},
+		{config.SortApprovals, []int{3, 1, 4, 2}},
+		{config.SortCI, []int{4, 2, 3, 1}},
+		{config.SortRepo, []int{4, 2, 3, 1}},
+		{config.SortAuthor, []int{3, 4, 2, 1}},
+		{"ci,newest", []int{2, 4, 3, 1}},
+		{"repo, -oldest", []int{2, 4, 3, 1}},
+		{config.SortPriority, []int{4, 1, 2, 3}}, // Skipped without a scan result
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			prs := newPRs()
+			SortPRs(prs, tt.spec)
+			for i, pr := range prs {
+				if pr.Number != tt.expected[i] {
+					t.Errorf("position %d: got PR #%d, want #%d", i, pr.Number, tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSortResult_Priority(t *testing.T) {
+	now := time.Now()
+	requested := &models.PR{Number: 1, Author: "bob", CreatedAt: now, IsReviewRequestedFromMe: true, MyReviewStatus: models.ReviewStateNone}
+	idle := &models.PR{Number: 2, Author: "bob", CreatedAt: now.Add(-time.Hour), MyReviewStatus: models.ReviewStateNone}
+	result := models.NewScanResult()
+	result.Username = "me"
+	result.NeedsMyAttention = []*models.PR{idle, requested}
+
+	cfg := &config.Config{DefaultSort: config.SortPriority, Priority: config.DefaultPriorityWeights()}
+	SortResult(result, cfg, now)
+
+	if result.NeedsMyAttention[0].Number != 1 {
+		t.Errorf("expected the requested review first, got #%d", result.NeedsMyAttention[0].Number)
+	}
+}
+
+func TestSortResult_KeepsStacksTogether(t *testing.T) {
+	now := time.Now()
+	pr := func(number int, head, base string, updated time.Duration) *models.PR {
+		return &models.PR{
+			Number: number, RepoOwner: "org", RepoName: "api", HeadBranch: head, BaseBranch: base,
+			CreatedAt: now.Add(-24 * time.Hour), UpdatedAt: now.Add(-updated),
+		}
+	}
+	base := pr(1, "feature-a", "main", 5*time.Hour)
+	middle := pr(2, "feature-b", "feature-a", 4*time.Hour)
+	top := pr(3, "feature-c", "feature-b", 1*time.Hour)
+	other := pr(4, "fix", "main", 2*time.Hour)
+	loner := pr(5, "docs", "main", 3*time.Hour)
+
+	result := models.NewScanResult()
+	result.MyPRs = []*models.PR{base, middle, top, other, loner}
+	result.Stacks["org/api"] = stacks.DetectStacks(result.MyPRs)
+
+	SortResult(result, &config.Config{DefaultSort: config.SortUpdated}, now)
+
+	// The stack sorts where its most recently updated PR (#3) does, in
+	// stack order
+	expected := []int{1, 2, 3, 4, 5}
+	for i, pr := range result.MyPRs {
+		if pr.Number != expected[i] {
+			t.Errorf("position %d: got PR #%d, want #%d", i, pr.Number, expected[i])
+		}
+	}
+
+	result.MyPRs = []*models.PR{loner, other, top, middle, base}
+	SortResult(result, &config.Config{DefaultSort: "-updated"}, now)
+
+	expected = []int{1, 2, 3, 5, 4}
+	for i, pr := range result.MyPRs {
+		if pr.Number != expected[i] {
+			t.Errorf("reversed position %d: got PR #%d, want #%d", i, pr.Number, expected[i])
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Package changes compares two scans to find the PRs that changed in between,
+// so repeated scans (e.g. watch mode) can highlight what is new.
+package changes
+
+import (
+	"strings"
+
+	"prt/internal/models"
+)
+
+// Kind is a set of flags describing how a PR changed between two scans.
+type Kind uint8
+
+const (
+	// New means the PR was not in the previous scan.
+	New Kind = 1 << iota
+	// CIChanged means the PR's CI status differs from the previous scan.
+	CIChanged
+	// NewReviews means reviews were submitted since the previous scan.
+	NewReviews
+)
+
+// String returns a short human-readable description, e.g. "CI changed, new review".
+func (k Kind) String() string {
+	var labels []string
+	if k&New != 0 {
+		labels = append(labels, "new")
+	}
+	if k&CIChanged != 0 {
+		labels = append(labels, "CI changed")
+	}
+	if k&NewReviews != 0 {
+		labels = append(labels, "new review")
+	}
+	return strings.Join(labels, ", ")
+}
+
+// Set maps PR keys (see models.PR.Key) to how each PR changed.
+// PRs that didn't change are absent.
+type Set map[string]Kind
+
+// Labels returns the description of each changed PR, keyed by PR key.
+func (s Set) Labels() map[string]string {
+	labels := make(map[string]string, len(s))
+	for key, kind := range s {
+		labels[key] = kind.String()
+	}
+	return labels
+}
+
+// Diff returns the PRs in curr that are new or changed compared to prev.
+// A nil prev (no earlier scan) yields an empty set rather than marking
+// every PR as new.
+func Diff(prev, curr *models.ScanResult) Set {
+	set := make(Set)
+	if prev == nil || curr == nil {
+		return set
+	}
+
+	before := index(prev)
+	for key, pr := range index(curr) {
+		old, ok := before[key]
+		if !ok {
+			set[key] = New
+			continue
+		}
+
+		var kind Kind
+		if pr.CIStatus != old.CIStatus {
+			kind |= CIChanged
+		}
+		if hasNewReviews(old, pr) {
+			kind |= NewReviews
+		}
+		if kind != 0 {
+			set[key] = kind
+		}
+	}
+
+	return set
+}
+
+// index returns all PRs in the result keyed by PR key, regardless of which
+// section they were categorized into or whether they are snoozed.
+func index(result *models.ScanResult) map[string]*models.PR {
+	prs := make(map[string]*models.PR)
+	for _, section := range result.DisplaySections() {
+		for _, pr := range result.SectionPRs(section) {
+			prs[pr.Key()] = pr
+		}
+	}
+	for _, pr := range result.SnoozedPRs {
+		prs[pr.Key()] = pr
+	}
+	return prs
+}
+
+// hasNewReviews reports whether curr has reviews submitted after the latest
+// review on old.
+func hasNewReviews(old, curr *models.PR) bool {
+	if len(curr.Reviews) > len(old.Reviews) {
+		return true
+	}
+
+	var latest int64
+	for _, r := range old.Reviews {
+		if t := r.Submitted.UnixNano(); t > latest {
+			latest = t
+		}
+	}
+	for _, r := range curr.Reviews {
+		if r.Submitted.UnixNano() > latest {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package changes
+
+import (
+	"testing"
+	"time"
+
+	"prt/internal/models"
+)
+
+func TestKind_String(t *testing.T) {
+	tests := []struct {
+		kind Kind
+		want string
+	}{
+		{0, ""},
+		{New, "new"},
+		{CIChanged, "CI changed"},
+		{CIChanged | NewReviews, "CI changed, new review"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.kind.String(); got != tt.want {
+			t.Errorf("Kind(%d).String() = %q, want %q", tt.kind, got, tt.want)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	t1 := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+
+	pr := func(number int, ci models.CIStatus, reviews ...models.Review) *models.PR {
+		return &models.PR{Number: number, RepoOwner: "org", RepoName: "api", CIStatus: ci, Reviews: reviews}
+	}
+
+	prev := models.NewScanResult()
+	prev.MyPRs = []*models.PR{
+		pr(1, models.CIStatusPending),
+		pr(2, models.CIStatusPassing),
+		pr(3, models.CIStatusPassing, models.Review{Author: "bob", Submitted: t1}),
+	}
+	prev.TeamPRs = []*models.PR{pr(4, models.CIStatusNone)}
+
+	curr := models.NewScanResult()
+	curr.MyPRs = []*models.PR{
+		pr(1, models.CIStatusFailing),
+		pr(2, models.CIStatusPassing),
+		// Same review count, but bob's review was replaced by a newer one
+		pr(3, models.CIStatusPassing, models.Review{Author: "bob", Submitted: t2}),
+	}
+	// Moving between sections is not a change
+	curr.NeedsMyAttention = []*models.PR{pr(4, models.CIStatusNone)}
+	curr.OtherPRs = []*models.PR{pr(5, models.CIStatusNone, models.Review{Author: "carol", Submitted: t2})}
+
+	got := Diff(prev, curr)
+	want := Set{
+		"org/api#1": CIChanged,
+		"org/api#3": NewReviews,
+		"org/api#5": New,
+	}
+
+	if len(got) != len(want) {
+		t.Errorf("Diff() = %v, want %v", got, want)
+	}
+	for key, kind := range want {
+		if got[key] != kind {
+			t.Errorf("Diff()[%s] = %v, want %v", key, got[key], kind)
+		}
+	}
+}
+
+func TestDiff_NoPreviousScan(t *testing.T) {
+	curr := models.NewScanResult()
+	curr.MyPRs = []*models.PR{{Number: 1, RepoName: "api"}}
+
+	if got := Diff(nil, curr); len(got) != 0 {
+		t.Errorf("Diff(nil, curr) = %v, want empty", got)
+	}
+}
+
+func TestSet_Labels(t *testing.T) {
+	labels := Set{"org/api#1": New | NewReviews}.Labels()
+	if labels["org/api#1"] != "new, new review" {
+		t.Errorf("Labels() = %v", labels)
+	}
+}
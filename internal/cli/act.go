@@ -0,0 +1,384 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"prt/internal/categorizer"
+	"prt/internal/config"
+	"prt/internal/display"
+	"prt/internal/github"
+	"prt/internal/models"
+
+	"github.com/spf13/cobra"
+)
+
+const actPRsHelp = `PRs are given as owner/repo#123, repo#123, or pull request URLs, or picked
+with --query, which takes the same queries as prt --query. Like the
+dashboard, --query leaves out snoozed and muted PRs unless --show-snoozed is
+given. Before acting, the PRs are listed and you are asked to confirm; --yes
+skips the prompt and --dry-run only lists them.`
+
+var approveCmd = &cobra.Command{
+	Use:   "approve [<pr>...]",
+	Short: "Approve PRs",
+	Long: `Approve PRs, with an optional review comment (--body).
+
+` + actPRsHelp + `
+
+  prt approve api#123
+  prt approve api#123 web#45 --body "LGTM"
+  prt approve --query "author:dependabot[bot] ci:passing" --dry-run`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAction(cmd, args, func(cfg *config.Config) prAction {
+			return approveAction(flagActBody, cfg.GitHubUsername)
+		})
+	},
+}
+
+var commentCmd = &cobra.Command{
+	Use:   "comment [<pr>...] --body <text>",
+	Short: "Comment on PRs",
+	Long: `Add a comment to the conversation of PRs.
+
+` + actPRsHelp + `
+
+  prt comment api#123 --body "Rebased on main"
+  prt comment --query "author:@me updated:>7d" --body "Still on it"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if strings.TrimSpace(flagActBody) == "" {
+			return fmt.Errorf("--body is required")
+		}
+		return runAction(cmd, args, func(*config.Config) prAction {
+			return commentAction(flagActBody)
+		})
+	},
+}
+
+var mergeCmd = &cobra.Command{
+	Use:   "merge [<pr>...]",
+	Short: "Merge PRs",
+	Long: `Merge PRs with a merge commit, or squash or rebase them (--method).
+Drafts and PRs with conflicts are skipped; GitHub refuses PRs that don't
+meet their branch's protection rules.
+
+` + actPRsHelp + `
+
+  prt merge api#123 --method squash
+  prt merge --query "author:@me review:approved ci:passing"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		method := github.MergeMethod(flagMergeMethod)
+		if !slices.Contains(github.MergeMethods, method) {
+			return fmt.Errorf("invalid --method: %q (must be merge, squash, or rebase)", flagMergeMethod)
+		}
+		return runAction(cmd, args, func(*config.Config) prAction {
+			return mergeAction(method)
+		})
+	},
+}
+
+var rerunCmd = &cobra.Command{
+	Use:   "rerun [<pr>...]",
+	Short: "Re-run the failed checks of PRs",
+	Long: `Re-run the failed jobs of the GitHub Actions workflow runs behind PRs'
+failing checks. Checks from other CI systems can't be re-run through GitHub.
+
+` + actPRsHelp + `
+
+  prt rerun api#123
+  prt rerun --query "author:@me ci:failing"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAction(cmd, args, func(*config.Config) prAction {
+			return rerunAction()
+		})
+	},
+}
+
+var (
+	flagActQuery       string
+	flagActDryRun      bool
+	flagActYes         bool
+	flagActShowSnoozed bool
+	flagActBody        string
+	flagMergeMethod    string
+)
+
+func init() {
+	for _, cmd := range []*cobra.Command{approveCmd, commentCmd, mergeCmd, rerunCmd} {
+		cmd.Flags().StringVarP(&flagActQuery, "query", "q", "", "Act on the PRs matching a query (e.g. \"author:alice ci:passing\") or saved query")
+		cmd.Flags().BoolVar(&flagActDryRun, "dry-run", false, "List what would be done without doing it")
+		cmd.Flags().BoolVarP(&flagActYes, "yes", "y", false, "Don't ask for confirmation")
+		cmd.Flags().BoolVar(&flagActShowSnoozed, "show-snoozed", false, "Include snoozed and muted PRs in --query")
+	}
+	approveCmd.Flags().StringVarP(&flagActBody, "body", "b", "", "Review comment")
+	commentCmd.Flags().StringVarP(&flagActBody, "body", "b", "", "Comment text")
+	mergeCmd.Flags().StringVarP(&flagMergeMethod, "method", "m", string(github.MergeMethodMerge), "Merge method: merge, squash, or rebase")
+}
+
+// prAction is what one of the act commands does to each PR.
+type prAction struct {
+	verb string // e.g. "Approve", continued by the PR count
+	done string // e.g. "Approved", continued by the PR key
+	// plan returns details of what will be done to pr, if any, or why it
+	// is skipped
+	plan  func(pr *models.PR) (string, error)
+	apply func(c github.WriteClient, pr *models.PR) error
+}
+
+// approveAction approves PRs with an optional review comment. The user's
+// (username's) own PRs are skipped, since GitHub doesn't allow approving
+// them.
+func approveAction(body, username string) prAction {
+	return prAction{
+		verb: "Approve",
+		done: "Approved",
+		plan: func(pr *models.PR) (string, error) {
+			if pr.Author != "" && strings.EqualFold(pr.Author, username) {
+				return "", errors.New("you can't approve your own PR")
+			}
+			return "", nil
+		},
+		apply: func(c github.WriteClient, pr *models.PR) error {
+			return c.ApprovePR(pr, body)
+		},
+	}
+}
+
+// commentAction comments on PRs.
+func commentAction(body string) prAction {
+	return prAction{
+		verb: "Comment on",
+		done: "Commented on",
+		plan: func(*models.PR) (string, error) { return "", nil },
+		apply: func(c github.WriteClient, pr *models.PR) error {
+			return c.CommentPR(pr, body)
+		},
+	}
+}
+
+// mergeAction merges PRs using method, skipping drafts and PRs with
+// conflicts.
+func mergeAction(method github.MergeMethod) prAction {
+	return prAction{
+		verb: "Merge",
+		done: "Merged",
+		plan: func(pr *models.PR) (string, error) {
+			switch {
+			case pr.IsDraft:
+				return "", errors.New("it is a draft")
+			case pr.HasConflicts():
+				return "", errors.New("it has conflicts")
+			}
+			return string(method), nil
+		},
+		apply: func(c github.WriteClient, pr *models.PR) error {
+			return c.MergePR(pr, method)
+		},
+	}
+}
+
+// rerunAction re-runs the failed jobs of the workflow runs behind PRs'
+// failing checks, skipping PRs without any.
+func rerunAction() prAction {
+	return prAction{
+		verb: "Re-run failed checks of",
+		done: "Re-ran failed checks of",
+		plan: func(pr *models.PR) (string, error) {
+			runs := github.FailedWorkflowRuns(pr)
+			if len(runs) == 0 {
+				return "", errors.New("no failed GitHub Actions runs")
+			}
+			ids := make([]string, len(runs))
+			for i, id := range runs {
+				ids[i] = fmt.Sprint(id)
+			}
+			return "workflow runs " + strings.Join(ids, ", "), nil
+		},
+		apply: func(c github.WriteClient, pr *models.PR) error {
+			for _, id := range github.FailedWorkflowRuns(pr) {
+				if err := c.RerunFailedJobs(pr, id); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// runAction resolves the PRs given on the command line, or matching
+// --query, in a fresh scan (see newActionPipeline) and applies the action
+// newAction returns for the loaded config (with the username detected, if
+// it wasn't set).
+func runAction(cmd *cobra.Command, args []string, newAction func(cfg *config.Config) prAction) error {
+	switch {
+	case len(args) == 0 && flagActQuery == "":
+		return fmt.Errorf("give one or more PRs, or --query")
+	case len(args) > 0 && flagActQuery != "":
+		return fmt.Errorf("give either PRs or --query, not both")
+	}
+
+	isTTY := display.IsTTY(os.Stdout)
+	noColor := os.Getenv("NO_COLOR") != ""
+	if noColor {
+		display.DisableColors()
+	}
+
+	cfg, err := config.Load(nil)
+	if err != nil {
+		return fmt.Errorf("config error: %w", err)
+	}
+	if config.NeedsSetup(cfg) {
+		return fmt.Errorf("prt is not set up yet; run prt to configure it")
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	p, err := newActionPipeline(cfg, noColor)
+	if err != nil {
+		return err
+	}
+	if flagActQuery != "" {
+		if p.query, err = categorizer.ParseQuery(flagActQuery, cfg.Queries); err != nil {
+			return err
+		}
+	}
+	p.showSnoozed = flagActShowSnoozed
+	writer, ok := p.client.(github.WriteClient)
+	if !ok {
+		return fmt.Errorf("the %s backend can't act on PRs", cfg.Backend)
+	}
+
+	result, err := p.run(isTTY)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		fmt.Println("No Git repositories found in configured paths.")
+		return nil
+	}
+	if err := p.afterScan(result); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	// A query picks the PRs the dashboard would show; PRs given by
+	// reference are acted on even if snoozed or muted
+	prs := sectionPRs(result)
+	if flagActQuery == "" {
+		all := resultPRs(result)
+		prs = nil
+		for _, arg := range args {
+			ref, pr, err := findPR(arg, all)
+			if err != nil {
+				return err
+			}
+			if pr == nil {
+				return fmt.Errorf("%s was not found among the open PRs", ref.Key())
+			}
+			if !slices.Contains(prs, pr) {
+				prs = append(prs, pr)
+			}
+		}
+	}
+
+	var confirm func(prompt string) bool
+	if !flagActYes {
+		confirm = promptConfirm(cmd.InOrStdin(), cmd.OutOrStdout())
+	}
+	return runActions(cmd.OutOrStdout(), writer, newAction(cfg), prs, flagActDryRun, confirm)
+}
+
+// newActionPipeline creates the pipeline that finds the PRs to act on. It
+// bypasses the PR cache: PRs fetched minutes ago may since have failed CI
+// or gotten new commits, which must not be merged or approved unseen.
+func newActionPipeline(cfg *config.Config, useASCII bool) (*pipeline, error) {
+	return newPipeline(cfg, true, 0, useASCII)
+}
+
+// runActions lists what action will do to prs and, unless dryRun is set
+// or confirm (if not nil) says no, applies it to each PR in turn. Failures
+// are reported per PR and don't stop the others.
+func runActions(w io.Writer, c github.WriteClient, action prAction, prs []*models.PR, dryRun bool, confirm func(prompt string) bool) error {
+	var targets []*models.PR
+	var details []string
+	for _, pr := range prs {
+		detail, err := action.plan(pr)
+		if err != nil {
+			fmt.Fprintf(w, "Skipping %s: %v\n", pr.Key(), err)
+			continue
+		}
+		targets = append(targets, pr)
+		details = append(details, detail)
+	}
+	if len(targets) == 0 {
+		fmt.Fprintln(w, "No PRs to act on.")
+		return nil
+	}
+
+	count := fmt.Sprintf("%d PR%s", len(targets), plural(len(targets)))
+	if dryRun {
+		fmt.Fprintf(w, "Would %s %s:\n", strings.ToLower(action.verb[:1])+action.verb[1:], count)
+	} else {
+		fmt.Fprintf(w, "%s %s:\n", action.verb, count)
+	}
+	for i, pr := range targets {
+		line := "  " + pr.Key() + " " + pr.Title
+		if details[i] != "" {
+			line += " (" + details[i] + ")"
+		}
+		fmt.Fprintln(w, line)
+	}
+
+	if dryRun {
+		return nil
+	}
+	if confirm != nil && !confirm(fmt.Sprintf("%s %s?", action.verb, count)) {
+		fmt.Fprintln(w, "Aborted; nothing was changed.")
+		return nil
+	}
+
+	var failed int
+	for _, pr := range targets {
+		if err := action.apply(c, pr); err != nil {
+			var actionErr *github.ActionError
+			if errors.As(err, &actionErr) {
+				fmt.Fprintf(w, "✗ %v\n", err)
+			} else {
+				fmt.Fprintf(w, "✗ %s: %v\n", pr.Key(), err)
+			}
+			failed++
+			continue
+		}
+		fmt.Fprintf(w, "✓ %s %s\n", action.done, pr.Key())
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %s failed", failed, count)
+	}
+	return nil
+}
+
+// promptConfirm returns a confirm function for runActions that asks on
+// out and reads the answer from in. Anything but "y" or "yes" (including
+// no answer at all) is a no.
+func promptConfirm(in io.Reader, out io.Writer) func(prompt string) bool {
+	reader := bufio.NewReader(in)
+	return func(prompt string) bool {
+		fmt.Fprintf(out, "%s [y/N] ", prompt)
+		answer, _ := reader.ReadString('\n')
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		return answer == "y" || answer == "yes"
+	}
+}
+
+// plural returns "s" unless n is 1.
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
@@ -0,0 +1,291 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"prt/internal/cache"
+	"prt/internal/categorizer"
+	"prt/internal/config"
+	"prt/internal/github"
+	"prt/internal/models"
+)
+
+func TestActCmds(t *testing.T) {
+	registered := make(map[string]bool)
+	for _, cmd := range rootCmd.Commands() {
+		registered[cmd.Name()] = true
+	}
+	for _, name := range []string{"approve", "comment", "merge", "rerun"} {
+		if !registered[name] {
+			t.Errorf("%s subcommand should be registered", name)
+		}
+	}
+
+	for _, cmd := range []string{"approve", "comment", "merge", "rerun"} {
+		c, _, err := rootCmd.Find([]string{cmd})
+		if err != nil {
+			t.Fatalf("Find(%q) error = %v", cmd, err)
+		}
+		for _, name := range []string{"query", "dry-run", "yes"} {
+			flag := c.Flags().Lookup(name)
+			if flag == nil {
+				t.Errorf("expected flag --%s on %s", name, cmd)
+				continue
+			}
+			if flag.Usage == "" {
+				t.Errorf("flag --%s on %s should have a usage description", name, cmd)
+			}
+		}
+	}
+	if flag := approveCmd.Flags().Lookup("body"); flag == nil {
+		t.Error("expected flag --body on approve")
+	}
+	if flag := commentCmd.Flags().Lookup("body"); flag == nil {
+		t.Error("expected flag --body on comment")
+	}
+	if flag := mergeCmd.Flags().Lookup("method"); flag == nil || flag.DefValue != "merge" {
+		t.Error("expected flag --method on merge, defaulting to merge")
+	}
+}
+
+// fakeWriter is a github.WriteClient that records the actions it is asked
+// for, failing those on PRs in fail with the given message.
+type fakeWriter struct {
+	github.Client
+	actions []string
+	fail    map[int]string
+}
+
+// record records the action described by label, or fails it the way the
+// real clients do, with action as the verb of the ActionError.
+func (f *fakeWriter) record(pr *models.PR, action, label string) error {
+	if msg, ok := f.fail[pr.Number]; ok {
+		return &github.ActionError{Action: action, PR: pr.Key(), Message: msg}
+	}
+	f.actions = append(f.actions, label+" "+pr.Key())
+	return nil
+}
+
+func (f *fakeWriter) ApprovePR(pr *models.PR, body string) error {
+	return f.record(pr, "approve", "approve")
+}
+
+func (f *fakeWriter) CommentPR(pr *models.PR, body string) error {
+	return f.record(pr, "comment on", "comment "+body)
+}
+
+func (f *fakeWriter) MergePR(pr *models.PR, method github.MergeMethod) error {
+	return f.record(pr, "merge", "merge "+string(method))
+}
+
+func (f *fakeWriter) RerunFailedJobs(pr *models.PR, runID int64) error {
+	return f.record(pr, "re-run checks of", "rerun "+strconv.FormatInt(runID, 10))
+}
+
+func actTestPRs() []*models.PR {
+	return []*models.PR{
+		{Number: 1, Title: "Add login", Author: "alice", RepoOwner: "org", RepoName: "api"},
+		{Number: 2, Title: "WIP", Author: "me", RepoOwner: "org", RepoName: "api", IsDraft: true},
+		{Number: 3, Title: "Fix crash", Author: "bob", RepoOwner: "org", RepoName: "web",
+			Checks: []models.Check{
+				{Name: "test", Conclusion: "FAILURE", URL: "https://github.com/org/web/actions/runs/5/job/1"},
+			}},
+	}
+}
+
+func TestSectionPRs_LeavesOutSnoozed(t *testing.T) {
+	query, err := categorizer.ParseQuery("repo:api", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prs := actTestPRs()
+	result := models.NewScanResult()
+	result.MyPRs = []*models.PR{prs[0]}
+	result.SnoozedPRs = []*models.PR{prs[1]}
+
+	// The query keeps the muted #2, which --query must still leave out
+	query.Filter(result, &config.Config{}, time.Now())
+	if len(result.SnoozedPRs) != 1 {
+		t.Fatalf("SnoozedPRs = %v, want the muted PR kept by the query", result.SnoozedPRs)
+	}
+	if got := sectionPRs(result); len(got) != 1 || got[0] != prs[0] {
+		t.Errorf("sectionPRs() = %v, want only org/api#1", got)
+	}
+	if got := resultPRs(result); len(got) != 2 {
+		t.Errorf("resultPRs() = %v, want both PRs for lookups by reference", got)
+	}
+}
+
+func TestRunActions(t *testing.T) {
+	tests := []struct {
+		name        string
+		action      prAction
+		dryRun      bool
+		answer      string
+		wantActions []string
+		wantOutput  []string
+	}{
+		{
+			name:        "approve skips own PRs",
+			action:      approveAction("", "me"),
+			answer:      "y\n",
+			wantActions: []string{"approve org/api#1", "approve org/web#3"},
+			wantOutput: []string{
+				"Skipping org/api#2: you can't approve your own PR",
+				"Approve 2 PRs:\n  org/api#1 Add login\n  org/web#3 Fix crash\n",
+				"Approve 2 PRs? [y/N] ",
+				"✓ Approved org/api#1",
+			},
+		},
+		{
+			name:       "dry run",
+			action:     mergeAction(github.MergeMethodSquash),
+			dryRun:     true,
+			wantOutput: []string{"Skipping org/api#2: it is a draft", "Would merge 2 PRs:\n  org/api#1 Add login (squash)\n"},
+		},
+		{
+			name:       "declined",
+			action:     commentAction("Rebased"),
+			answer:     "n\n",
+			wantOutput: []string{"Comment on 3 PRs? [y/N] ", "Aborted; nothing was changed."},
+		},
+		{
+			name:       "no answer",
+			action:     commentAction("Rebased"),
+			wantOutput: []string{"Aborted; nothing was changed."},
+		},
+		{
+			name:        "rerun",
+			action:      rerunAction(),
+			answer:      "yes\n",
+			wantActions: []string{"rerun 5 org/web#3"},
+			wantOutput:  []string{"Skipping org/api#1: no failed GitHub Actions runs", "  org/web#3 Fix crash (workflow runs 5)", "✓ Re-ran failed checks of org/web#3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			writer := &fakeWriter{}
+			confirm := promptConfirm(strings.NewReader(tt.answer), &out)
+
+			if err := runActions(&out, writer, tt.action, actTestPRs(), tt.dryRun, confirm); err != nil {
+				t.Fatalf("runActions() error = %v", err)
+			}
+			if strings.Join(writer.actions, "\n") != strings.Join(tt.wantActions, "\n") {
+				t.Errorf("actions = %q, want %q", writer.actions, tt.wantActions)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output should contain %q, got:\n%s", want, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestRunActions_Failures(t *testing.T) {
+	var out bytes.Buffer
+	writer := &fakeWriter{fail: map[int]string{1: "Pull Request is not mergeable"}}
+	prs := actTestPRs()[:1]
+	prs = append(prs, &models.PR{Number: 4, RepoOwner: "org", RepoName: "web"})
+
+	err := runActions(&out, writer, mergeAction(github.MergeMethodMerge), prs, false, nil)
+	if err == nil || !strings.Contains(err.Error(), "1 of 2 PRs failed") {
+		t.Errorf("runActions() error = %v, want 1 of 2 failed", err)
+	}
+	if len(writer.actions) != 1 || writer.actions[0] != "merge merge org/web#4" {
+		t.Errorf("actions = %q, want only org/web#4 merged", writer.actions)
+	}
+	for _, want := range []string{"✗ failed to merge org/api#1: Pull Request is not mergeable", "✓ Merged org/web#4"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output should contain %q, got:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "[y/N]") {
+		t.Error("without a confirm function, runActions should not prompt")
+	}
+}
+
+func TestRunActions_FailureWording(t *testing.T) {
+	tests := []struct {
+		name   string
+		action prAction
+		want   string
+	}{
+		{"approve", approveAction("", "me"), "✗ failed to approve org/web#3: Resource not accessible by integration"},
+		{"comment", commentAction("Rebased"), "✗ failed to comment on org/web#3: Resource not accessible by integration"},
+		{"rerun", rerunAction(), "✗ failed to re-run checks of org/web#3: Resource not accessible by integration"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			writer := &fakeWriter{fail: map[int]string{3: "Resource not accessible by integration"}}
+
+			err := runActions(&out, writer, tt.action, actTestPRs()[2:], false, nil)
+			if err == nil {
+				t.Error("runActions() should report the failure")
+			}
+			if !strings.Contains(out.String(), tt.want) {
+				t.Errorf("output should contain %q, got:\n%s", tt.want, out.String())
+			}
+		})
+	}
+}
+
+func TestNewActionPipeline_BypassesCache(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// A gh that finds no open PRs
+	bin := t.TempDir()
+	script := "#!/bin/sh\necho '{\"data\": {\"r0\": {\"pullRequests\": {\"nodes\": []}}}}'\n"
+	if err := os.WriteFile(filepath.Join(bin, "gh"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	// A fresh cache entry still listing a PR
+	repo := &models.Repository{Owner: "org", Name: "api", Host: github.DefaultHost}
+	entry := &github.CacheEntry{FetchedAt: time.Now(), PRs: []*models.PR{{Number: 1, RepoOwner: "org", RepoName: "api"}}}
+	if err := cache.NewStore(cache.Dir()).Put(repo, entry); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.DefaultConfig
+	cfg.Backend = config.BackendGH
+	cfg.CacheTTLMinutes = 5
+
+	p, err := newPipeline(&cfg, false, 0, true)
+	if err != nil {
+		t.Fatalf("newPipeline() error = %v", err)
+	}
+	if prs, _, err := p.client.ListPRs(repo); err != nil || len(prs) != 1 {
+		t.Fatalf("scan pipeline ListPRs() = %v, %v, want the cached PR", prs, err)
+	}
+
+	p, err = newActionPipeline(&cfg, true)
+	if err != nil {
+		t.Fatalf("newActionPipeline() error = %v", err)
+	}
+	if prs, _, err := p.client.ListPRs(repo); err != nil || len(prs) != 0 {
+		t.Errorf("action pipeline ListPRs() = %v, %v, want the PRs fetched from GitHub, not the cache", prs, err)
+	}
+}
+
+func TestRunActions_NothingToDo(t *testing.T) {
+	var out bytes.Buffer
+	err := runActions(&out, &fakeWriter{}, rerunAction(), actTestPRs()[:1], false, func(string) bool {
+		t.Error("should not ask for confirmation")
+		return false
+	})
+	if err != nil || !strings.Contains(out.String(), "No PRs to act on.") {
+		t.Errorf("runActions() = %v, output %q", err, out.String())
+	}
+}
@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"prt/internal/config"
+	"prt/internal/display"
+	"prt/internal/history"
+
+	"github.com/spf13/cobra"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Show what changed since an earlier run",
+	Long: `Scan for PRs and show what changed since an earlier run: PRs that were
+opened, merged or closed, moved between sections, changed CI status, or
+received reviews.
+
+Every run of prt records a snapshot in ~/.prt/history. By default the
+comparison is against the previous run; --since picks an earlier one:
+
+  prt diff --since 3            Three runs back
+  prt diff --since 12h          The last run at least 12 hours ago (also 2d, 1w)
+  prt diff --since 2025-01-02   The last run before that date (or "2025-01-02 09:00")`,
+	Args: cobra.NoArgs,
+	RunE: runDiff,
+}
+
+var (
+	flagDiffSince string
+	flagDiffJSON  bool
+)
+
+func init() {
+	diffCmd.Flags().StringVar(&flagDiffSince, "since", "", "Compare against this run: a number of runs back, a duration (12h, 2d), or a date")
+	diffCmd.Flags().BoolVar(&flagDiffJSON, "json", false, "Output as JSON")
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	isTTY := display.IsTTY(os.Stdout)
+	noColor := os.Getenv("NO_COLOR") != ""
+	if noColor {
+		display.DisableColors()
+	}
+
+	cfg, err := config.Load(nil)
+	if err != nil {
+		return fmt.Errorf("config error: %w", err)
+	}
+	if config.NeedsSetup(cfg) {
+		return fmt.Errorf("prt is not set up yet; run prt to configure it")
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if cfg.HistoryRetentionDays == 0 {
+		return fmt.Errorf("scan history is disabled (history_retention_days is 0 in %s)", config.ConfigPath())
+	}
+
+	since, err := history.ParseSince(flagDiffSince, time.Now())
+	if err != nil {
+		return err
+	}
+
+	// Pick the earlier snapshot before this run records its own
+	store := history.NewStore(history.Dir())
+	entries, err := store.List()
+	if err != nil {
+		return fmt.Errorf("failed to read scan history: %w", err)
+	}
+	entry, err := since.Select(entries)
+	if err != nil {
+		return err
+	}
+	from, err := store.Load(entry)
+	if err != nil {
+		return err
+	}
+
+	p, err := newPipeline(cfg, false, 0, noColor)
+	if err != nil {
+		return err
+	}
+	result, err := p.run(isTTY && !flagDiffJSON)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		fmt.Println("No Git repositories found in configured paths.")
+		return nil
+	}
+	if err := p.afterScan(result); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	report := history.Diff(from, &history.Snapshot{TakenAt: time.Now(), Result: result}, cfg.MaxPRAgeDays)
+
+	if flagDiffJSON {
+		output, err := display.RenderDiffJSON(report)
+		if err != nil {
+			return fmt.Errorf("render error: %w", err)
+		}
+		fmt.Print(output)
+		return nil
+	}
+
+	fmt.Print(display.RenderDiff(report, cfg.ShowIcons))
+	return nil
+}
@@ -0,0 +1,27 @@
+package cli
+
+import "testing"
+
+func TestDiffCmd(t *testing.T) {
+	found := false
+	for _, cmd := range rootCmd.Commands() {
+		if cmd == diffCmd {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("diff subcommand should be registered")
+	}
+
+	for _, name := range []string{"since", "json"} {
+		flag := diffCmd.Flags().Lookup(name)
+		if flag == nil {
+			t.Errorf("expected flag --%s on diff", name)
+			continue
+		}
+		if flag.Usage == "" {
+			t.Errorf("flag --%s should have a usage description", name)
+		}
+	}
+}
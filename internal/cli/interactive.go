@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"prt/internal/display"
+	"prt/internal/tui"
+)
+
+// validateInteractive checks that --interactive can be used with the other flags.
+func validateInteractive(interactive bool, watch time.Duration, format string, isTTY bool) error {
+	if !interactive {
+		return nil
+	}
+	if format != display.FormatText {
+		return fmt.Errorf("--interactive cannot be combined with %s", formatFlag(format))
+	}
+	if watch > 0 {
+		return fmt.Errorf("--interactive cannot be combined with --watch")
+	}
+	if !isTTY {
+		return fmt.Errorf("--interactive requires a terminal")
+	}
+	return nil
+}
+
+// runInteractive scans once, with the usual progress display, and then
+// opens the interactive dashboard on the result.
+func runInteractive(p *pipeline, opts display.RenderOptions) error {
+	result, err := p.run(true)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		fmt.Println("No Git repositories found in configured paths.")
+		return nil
+	}
+	if err := p.afterScan(result); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	return tui.Run(result, tui.Options{
+		ShowIcons:    opts.ShowIcons,
+		ShowOtherPRs: opts.ShowOtherPRs,
+		GroupBy:      opts.GroupBy,
+	})
+}
@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"prt/internal/display"
+)
+
+func TestValidateInteractive(t *testing.T) {
+	tests := []struct {
+		name        string
+		interactive bool
+		watch       time.Duration
+		format      string
+		isTTY       bool
+		wantErr     string
+	}{
+		{"disabled", false, time.Minute, display.FormatJSON, false, ""},
+		{"valid", true, 0, display.FormatText, true, ""},
+		{"with json", true, 0, display.FormatJSON, true, "--json"},
+		{"with markdown", true, 0, display.FormatMarkdown, true, "--format markdown"},
+		{"with watch", true, time.Minute, display.FormatText, true, "--watch"},
+		{"not a terminal", true, 0, display.FormatText, false, "terminal"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateInteractive(tt.interactive, tt.watch, tt.format, tt.isTTY)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateInteractive() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateInteractive() error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
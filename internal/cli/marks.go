@@ -0,0 +1,299 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"prt/internal/config"
+	"prt/internal/github"
+	"prt/internal/history"
+	"prt/internal/models"
+	"prt/internal/state"
+
+	"github.com/spf13/cobra"
+)
+
+var snoozeCmd = &cobra.Command{
+	Use:   "snooze <pr>",
+	Short: "Hide a PR until a date or until it gets new activity",
+	Long: `Hide a PR from the dashboard until a deadline passes (--until), until it
+gets new activity (--until-update: new commits, or a review by someone else),
+or whichever comes first if both are given. Without --until, the PR is
+snoozed until it gets new activity.
+
+The PR is given as owner/repo#123, repo#123, or a pull request URL:
+
+  prt snooze org/api#123 --until 3d
+  prt snooze api#123 --until 2025-01-06
+  prt snooze api#123 --until-update
+
+Snoozed PRs are listed with --show-snoozed; prt unsnooze brings one back.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSnooze,
+}
+
+var (
+	flagSnoozeUntil       string
+	flagSnoozeUntilUpdate bool
+)
+
+var unsnoozeCmd = newMarkCmd("unsnooze <pr>", "Bring back a snoozed PR",
+	func(m *state.PRMark) bool {
+		was := m.Snooze != nil
+		m.Snooze = nil
+		return was
+	}, "%s is no longer snoozed", "%s was not snoozed")
+
+var muteCmd = newMarkCmd("mute <pr>", "Hide a PR until it is unmuted",
+	func(m *state.PRMark) bool {
+		was := m.Muted
+		m.Muted = true
+		return !was
+	}, "Muted %s (prt unmute to undo)", "%s was already muted")
+
+var unmuteCmd = newMarkCmd("unmute <pr>", "Show a muted PR again",
+	func(m *state.PRMark) bool {
+		was := m.Muted
+		m.Muted = false
+		return was
+	}, "%s is no longer muted", "%s was not muted")
+
+var pinCmd = newMarkCmd("pin <pr>", "Keep a PR at the top of its section",
+	func(m *state.PRMark) bool {
+		was := m.Pinned
+		m.Pinned = true
+		return !was
+	}, "Pinned %s", "%s was already pinned")
+
+var unpinCmd = newMarkCmd("unpin <pr>", "Unpin a PR",
+	func(m *state.PRMark) bool {
+		was := m.Pinned
+		m.Pinned = false
+		return was
+	}, "%s is no longer pinned", "%s was not pinned")
+
+func init() {
+	snoozeCmd.Flags().StringVar(&flagSnoozeUntil, "until", "", "Snooze until this date (2025-01-06, \"2025-01-06 09:00\") or for a duration (12h, 3d, 1w)")
+	snoozeCmd.Flags().BoolVar(&flagSnoozeUntilUpdate, "until-update", false, "Snooze until the PR gets new commits or reviews (the default without --until)")
+}
+
+// newMarkCmd creates a command that changes one mark on a PR. apply changes
+// the mark and reports whether anything changed; changed and unchanged are
+// the messages printed in either case, formatted with the PR key.
+func newMarkCmd(use, short string, apply func(m *state.PRMark) bool, changed, unchanged string) *cobra.Command {
+	return &cobra.Command{
+		Use:   use,
+		Short: short,
+		Long: short + `.
+
+The PR is given as owner/repo#123, repo#123, or a pull request URL.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key, _, err := lookupPR(args[0])
+			if err != nil {
+				return err
+			}
+
+			var didChange bool
+			err = updateState(func(st *state.State) {
+				st.Update(key, func(m *state.PRMark) { didChange = apply(m) })
+			})
+			if err != nil {
+				return err
+			}
+
+			if didChange {
+				fmt.Fprintf(cmd.OutOrStdout(), changed+"\n", key)
+			} else {
+				fmt.Fprintf(cmd.OutOrStdout(), unchanged+"\n", key)
+			}
+			return nil
+		},
+	}
+}
+
+func runSnooze(cmd *cobra.Command, args []string) error {
+	now := time.Now()
+	snooze := &state.Snooze{At: now, UntilUpdate: flagSnoozeUntilUpdate || flagSnoozeUntil == ""}
+	if flagSnoozeUntil != "" {
+		until, err := state.ParseUntil(flagSnoozeUntil, now)
+		if err != nil {
+			return err
+		}
+		snooze.Until = &until
+	}
+
+	key, pr, err := lookupPR(args[0])
+	if err != nil {
+		return err
+	}
+	switch {
+	case pr != nil:
+		snooze.HeadSHA = pr.HeadSHA
+	case snooze.UntilUpdate:
+		// Without the PR in the last scan, new commits could never end the
+		// snooze; ask GitHub for its head commit instead
+		ref, _ := models.ParsePRRef(args[0]) // valid, as lookupPR parsed it
+		if snooze.HeadSHA, err = fetchHeadSHA(ref); err != nil {
+			return fmt.Errorf("can't snooze %s until new commits: %w; give --until instead", key, err)
+		}
+	}
+
+	err = updateState(func(st *state.State) {
+		st.Update(key, func(m *state.PRMark) { m.Snooze = snooze })
+	})
+	if err != nil {
+		return err
+	}
+
+	var until []string
+	if snooze.Until != nil {
+		until = append(until, snooze.Until.Format("Mon Jan 2 15:04"))
+	}
+	if snooze.UntilUpdate {
+		until = append(until, "new activity")
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Snoozed %s until %s\n", key, strings.Join(until, " or "))
+	return nil
+}
+
+// fetchHeadSHA fetches the head commit of the PR ref names from GitHub,
+// on the host its repository is on (see refHost). It is a variable so
+// tests can replace it.
+var fetchHeadSHA = func(ref models.PRRef) (string, error) {
+	cfg, err := config.Load(nil)
+	if err != nil {
+		return "", fmt.Errorf("config error: %w", err)
+	}
+
+	client, ok := newGitHubClient(cfg, true, 0).(github.HeadClient)
+	if !ok {
+		return "", fmt.Errorf("the %s backend can't look up single PRs", cfg.Backend)
+	}
+	repo := &models.Repository{Owner: ref.Owner, Name: ref.Repo, Host: refHost(ref, lastScanResult(), cfg)}
+	return client.HeadSHA(repo, ref.Number)
+}
+
+// refHost returns the host of the repository ref names: the host of its
+// URL, else that of the repository in the last scan (which may have found
+// it without the PR), else the first configured host.
+func refHost(ref models.PRRef, last *models.ScanResult, cfg *config.Config) string {
+	if ref.Host != "" {
+		return ref.Host
+	}
+	if last != nil {
+		for _, list := range [][]*models.Repository{last.ReposWithPRs, last.ReposWithoutPRs} {
+			for _, repo := range list {
+				if repo.Host != "" && strings.EqualFold(repo.Owner, ref.Owner) && strings.EqualFold(repo.Name, ref.Repo) {
+					return repo.Host
+				}
+			}
+		}
+	}
+	return cfg.Hosts()[0]
+}
+
+// updateState loads the state file, applies fn, drops expired snoozes, and
+// saves it.
+func updateState(fn func(st *state.State)) error {
+	store := state.NewStore(state.Path())
+	st, err := store.Load()
+	if err != nil {
+		return err
+	}
+
+	fn(st)
+	st.PruneExpired(time.Now())
+
+	if err := store.Save(st); err != nil {
+		return fmt.Errorf("failed to save %s: %w", state.Path(), err)
+	}
+	return nil
+}
+
+// lookupPR resolves a PR given on the command line against the most recent
+// scan in the history, returning its key and the PR as last scanned. A PR
+// given with its owner that isn't in the last scan (or with history
+// disabled) resolves to its key alone.
+func lookupPR(arg string) (string, *models.PR, error) {
+	ref, pr, err := findPR(arg, lastScannedPRs())
+	if err != nil {
+		return "", nil, err
+	}
+	if pr != nil {
+		return pr.Key(), pr, nil
+	}
+	if ref.Owner == "" {
+		return "", nil, fmt.Errorf("%s was not found in the last scan; give it as owner/repo#%d", ref.Key(), ref.Number)
+	}
+	return ref.Key(), nil, nil
+}
+
+// findPR parses a PR given on the command line and finds it among prs,
+// returning a nil PR if it isn't there and an error if it is ambiguous.
+func findPR(arg string, prs []*models.PR) (models.PRRef, *models.PR, error) {
+	ref, err := models.ParsePRRef(arg)
+	if err != nil {
+		return ref, nil, err
+	}
+
+	var matches []*models.PR
+	for _, pr := range prs {
+		if ref.Matches(pr) {
+			matches = append(matches, pr)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return ref, nil, nil
+	case 1:
+		return ref, matches[0], nil
+	}
+	keys := make([]string, len(matches))
+	for i, pr := range matches {
+		keys[i] = pr.Key()
+	}
+	return ref, nil, fmt.Errorf("%s is ambiguous: %s", arg, strings.Join(keys, ", "))
+}
+
+// lastScannedPRs returns the PRs of the most recent scan in the history, or
+// none if there is no readable history.
+func lastScannedPRs() []*models.PR {
+	result := lastScanResult()
+	if result == nil {
+		return nil
+	}
+	return resultPRs(result)
+}
+
+// lastScanResult returns the result of the most recent scan in the
+// history, or nil if there is no readable history.
+func lastScanResult() *models.ScanResult {
+	store := history.NewStore(history.Dir())
+	entries, err := store.List()
+	if err != nil || len(entries) == 0 {
+		return nil
+	}
+	snap, err := store.Load(entries[len(entries)-1])
+	if err != nil {
+		return nil
+	}
+	return snap.Result
+}
+
+// resultPRs returns all PRs shown in result's sections, and its snoozed PRs.
+func resultPRs(result *models.ScanResult) []*models.PR {
+	return append(append([]*models.PR(nil), result.SnoozedPRs...), sectionPRs(result)...)
+}
+
+// sectionPRs returns the PRs shown in result's sections, leaving out the
+// snoozed and muted PRs hidden from them.
+func sectionPRs(result *models.ScanResult) []*models.PR {
+	var prs []*models.PR
+	for _, section := range result.DisplaySections() {
+		prs = append(prs, result.SectionPRs(section)...)
+	}
+	return prs
+}
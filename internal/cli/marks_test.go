@@ -0,0 +1,241 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"prt/internal/config"
+	"prt/internal/history"
+	"prt/internal/models"
+	"prt/internal/state"
+)
+
+func TestMarkCmds(t *testing.T) {
+	registered := make(map[string]bool)
+	for _, cmd := range rootCmd.Commands() {
+		registered[cmd.Name()] = true
+	}
+	for _, name := range []string{"snooze", "unsnooze", "mute", "unmute", "pin", "unpin"} {
+		if !registered[name] {
+			t.Errorf("%s subcommand should be registered", name)
+		}
+	}
+
+	for _, name := range []string{"until", "until-update"} {
+		flag := snoozeCmd.Flags().Lookup(name)
+		if flag == nil {
+			t.Errorf("expected flag --%s on snooze", name)
+			continue
+		}
+		if flag.Usage == "" {
+			t.Errorf("flag --%s should have a usage description", name)
+		}
+	}
+}
+
+// withLastScan points the home directory at a temporary one whose history
+// holds a single scan with prs.
+func withLastScan(t *testing.T, prs ...*models.PR) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	if len(prs) == 0 {
+		return
+	}
+	result := models.NewScanResult()
+	result.NeedsMyAttention = prs
+	if err := history.NewStore(history.Dir()).Save(result, time.Now()); err != nil {
+		t.Fatalf("failed to save scan: %v", err)
+	}
+}
+
+func TestLookupPR(t *testing.T) {
+	withLastScan(t,
+		&models.PR{Number: 1, RepoOwner: "org", RepoName: "api", HeadSHA: "abc"},
+		&models.PR{Number: 2, RepoOwner: "org", RepoName: "web"},
+		&models.PR{Number: 2, RepoOwner: "fork", RepoName: "web"},
+	)
+
+	tests := []struct {
+		arg     string
+		wantKey string
+		wantPR  bool
+		wantErr string
+	}{
+		{arg: "api#1", wantKey: "org/api#1", wantPR: true},
+		{arg: "ORG/API#1", wantKey: "org/api#1", wantPR: true},
+		{arg: "https://github.com/org/api/pull/1", wantKey: "org/api#1", wantPR: true},
+		{arg: "fork/web#2", wantKey: "fork/web#2", wantPR: true},
+		{arg: "web#2", wantErr: "ambiguous"},
+		{arg: "other/api#9", wantKey: "other/api#9"},
+		{arg: "api#9", wantErr: "not found"},
+		{arg: "api", wantErr: "invalid PR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			key, pr, err := lookupPR(tt.arg)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("lookupPR(%q) error = %v, want %q", tt.arg, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("lookupPR(%q) error = %v", tt.arg, err)
+			}
+			if key != tt.wantKey || (pr != nil) != tt.wantPR {
+				t.Errorf("lookupPR(%q) = %q, %v, want %q (found: %v)", tt.arg, key, pr, tt.wantKey, tt.wantPR)
+			}
+		})
+	}
+}
+
+func TestRefHost(t *testing.T) {
+	last := models.NewScanResult()
+	last.ReposWithoutPRs = []*models.Repository{{Owner: "org", Name: "tools", Host: "ghe.corp.com"}}
+	cfg := &config.Config{GitHubHosts: []string{"github.com", "ghe.corp.com"}}
+
+	tests := []struct {
+		arg  string
+		last *models.ScanResult
+		want string
+	}{
+		{"https://ghe.corp.com/org/api/pull/1", last, "ghe.corp.com"},
+		{"org/tools#1", last, "ghe.corp.com"},
+		{"Org/Tools#1", last, "ghe.corp.com"},
+		{"org/api#1", last, "github.com"},
+		{"org/tools#1", nil, "github.com"},
+	}
+
+	for _, tt := range tests {
+		ref, err := models.ParsePRRef(tt.arg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := refHost(ref, tt.last, cfg); got != tt.want {
+			t.Errorf("refHost(%q) = %q, want %q", tt.arg, got, tt.want)
+		}
+	}
+}
+
+func TestMarkCmd_PinAndUnpin(t *testing.T) {
+	withLastScan(t, &models.PR{Number: 1, RepoOwner: "org", RepoName: "api"})
+
+	run := func(cmdName string) string {
+		t.Helper()
+		for _, cmd := range rootCmd.Commands() {
+			if cmd.Name() == cmdName {
+				var out bytes.Buffer
+				cmd.SetOut(&out)
+				defer cmd.SetOut(nil)
+				if err := cmd.RunE(cmd, []string{"api#1"}); err != nil {
+					t.Fatalf("%s: %v", cmdName, err)
+				}
+				return out.String()
+			}
+		}
+		t.Fatalf("%s subcommand not found", cmdName)
+		return ""
+	}
+
+	if out := run("pin"); out != "Pinned org/api#1\n" {
+		t.Errorf("pin printed %q", out)
+	}
+	if out := run("pin"); out != "org/api#1 was already pinned\n" {
+		t.Errorf("second pin printed %q", out)
+	}
+
+	st, err := state.NewStore(state.Path()).Load()
+	if err != nil {
+		t.Fatalf("failed to load state: %v", err)
+	}
+	if m := st.Mark("org/api#1"); m == nil || !m.Pinned {
+		t.Errorf("state mark = %+v, want pinned", m)
+	}
+
+	if out := run("unpin"); out != "org/api#1 is no longer pinned\n" {
+		t.Errorf("unpin printed %q", out)
+	}
+	st, err = state.NewStore(state.Path()).Load()
+	if err != nil {
+		t.Fatalf("failed to load state: %v", err)
+	}
+	if len(st.PRs) != 0 {
+		t.Errorf("state = %+v, want no marks after unpin", st.PRs)
+	}
+}
+
+func TestRunSnooze(t *testing.T) {
+	withLastScan(t, &models.PR{Number: 1, RepoOwner: "org", RepoName: "api", HeadSHA: "abc"})
+
+	flagSnoozeUntil, flagSnoozeUntilUpdate = "2d", false
+	defer func() { flagSnoozeUntil, flagSnoozeUntilUpdate = "", false }()
+
+	var out bytes.Buffer
+	snoozeCmd.SetOut(&out)
+	defer snoozeCmd.SetOut(nil)
+	if err := runSnooze(snoozeCmd, []string{"api#1"}); err != nil {
+		t.Fatalf("runSnooze: %v", err)
+	}
+	if !strings.HasPrefix(out.String(), "Snoozed org/api#1 until ") || strings.Contains(out.String(), "new activity") {
+		t.Errorf("snooze printed %q, want a deadline only", out.String())
+	}
+
+	st, err := state.NewStore(state.Path()).Load()
+	if err != nil {
+		t.Fatalf("failed to load state: %v", err)
+	}
+	m := st.Mark("org/api#1")
+	if m == nil || m.Snooze == nil {
+		t.Fatalf("state mark = %+v, want a snooze", m)
+	}
+	if m.Snooze.Until == nil || m.Snooze.UntilUpdate || m.Snooze.HeadSHA != "abc" {
+		t.Errorf("snooze = %+v, want a deadline and the head commit from the last scan", m.Snooze)
+	}
+}
+
+func TestRunSnooze_NotInLastScan(t *testing.T) {
+	withLastScan(t)
+
+	fetched := map[string]string{"org/api#1": "def"}
+	defer func(orig func(models.PRRef) (string, error)) { fetchHeadSHA = orig }(fetchHeadSHA)
+	fetchHeadSHA = func(ref models.PRRef) (string, error) {
+		if sha, ok := fetched[ref.Key()]; ok {
+			return sha, nil
+		}
+		return "", errors.New("it is not among the open PRs of org/api")
+	}
+
+	snoozeCmd.SetOut(io.Discard)
+	defer snoozeCmd.SetOut(nil)
+
+	// Snoozed until an update, the head commit comes from GitHub
+	if err := runSnooze(snoozeCmd, []string{"org/api#1"}); err != nil {
+		t.Fatalf("runSnooze: %v", err)
+	}
+	st, err := state.NewStore(state.Path()).Load()
+	if err != nil {
+		t.Fatalf("failed to load state: %v", err)
+	}
+	if m := st.Mark("org/api#1"); m == nil || m.Snooze == nil || !m.Snooze.UntilUpdate || m.Snooze.HeadSHA != "def" {
+		t.Errorf("state mark = %+v, want a snooze until an update from the fetched head commit", m)
+	}
+
+	// A PR whose head commit can't be fetched can't be snoozed until an update
+	err = runSnooze(snoozeCmd, []string{"org/api#2"})
+	if err == nil || !strings.Contains(err.Error(), "--until") {
+		t.Errorf("runSnooze() error = %v, want it to suggest --until", err)
+	}
+
+	// A deadline alone doesn't need the head commit
+	flagSnoozeUntil = "2d"
+	defer func() { flagSnoozeUntil = "" }()
+	if err := runSnooze(snoozeCmd, []string{"org/api#2"}); err != nil {
+		t.Errorf("runSnooze() with --until error = %v", err)
+	}
+}
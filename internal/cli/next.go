@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"prt/internal/config"
+	"prt/internal/display"
+	"prt/internal/priority"
+
+	"github.com/spf13/cobra"
+)
+
+var nextCmd = &cobra.Command{
+	Use:   "next",
+	Short: "Show the PRs to act on next",
+	Long: `Scan for PRs and list the ones to act on next, across all sections, most
+important first. Each comes with a one-line reason for its rank.
+
+PRs are scored by review requests, failing CI, requested changes, approved
+PRs ready to merge, teammates' PRs awaiting approval, PRs stacked on top,
+age, and size. The weights are set under "priority" in ~/.prt/config.yaml.`,
+	Args: cobra.NoArgs,
+	RunE: runNext,
+}
+
+var (
+	flagNextLimit int
+	flagNextJSON  bool
+)
+
+func init() {
+	nextCmd.Flags().IntVarP(&flagNextLimit, "limit", "n", 5, "Number of PRs to show (0 = all)")
+	nextCmd.Flags().BoolVar(&flagNextJSON, "json", false, "Output as JSON")
+}
+
+func runNext(cmd *cobra.Command, args []string) error {
+	isTTY := display.IsTTY(os.Stdout)
+	noColor := os.Getenv("NO_COLOR") != ""
+	if noColor {
+		display.DisableColors()
+	}
+	if flagNextLimit < 0 {
+		return fmt.Errorf("--limit must not be negative")
+	}
+
+	cfg, err := config.Load(nil)
+	if err != nil {
+		return fmt.Errorf("config error: %w", err)
+	}
+	if config.NeedsSetup(cfg) {
+		return fmt.Errorf("prt is not set up yet; run prt to configure it")
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	p, err := newPipeline(cfg, false, 0, noColor)
+	if err != nil {
+		return err
+	}
+	result, err := p.run(isTTY && !flagNextJSON)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		fmt.Println("No Git repositories found in configured paths.")
+		return nil
+	}
+	if err := p.afterScan(result); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	items := priority.Next(result, cfg, time.Now(), flagNextLimit)
+
+	if flagNextJSON {
+		output, err := display.RenderNextJSON(items)
+		if err != nil {
+			return fmt.Errorf("render error: %w", err)
+		}
+		fmt.Print(output)
+		return nil
+	}
+
+	fmt.Print(display.RenderNext(items))
+	return nil
+}
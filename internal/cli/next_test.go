@@ -0,0 +1,30 @@
+package cli
+
+import "testing"
+
+func TestNextCmd(t *testing.T) {
+	found := false
+	for _, cmd := range rootCmd.Commands() {
+		if cmd == nextCmd {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("next subcommand should be registered")
+	}
+
+	for _, name := range []string{"limit", "json"} {
+		flag := nextCmd.Flags().Lookup(name)
+		if flag == nil {
+			t.Errorf("expected flag --%s on next", name)
+			continue
+		}
+		if flag.Usage == "" {
+			t.Errorf("flag --%s should have a usage description", name)
+		}
+	}
+	if flag := nextCmd.Flags().ShorthandLookup("n"); flag == nil || flag.Name != "limit" {
+		t.Error("expected -n as shorthand for --limit")
+	}
+}
@@ -0,0 +1,199 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"prt/internal/browser"
+	"prt/internal/config"
+	"prt/internal/models"
+	"prt/internal/priority"
+
+	"github.com/spf13/cobra"
+)
+
+var openCmd = &cobra.Command{
+	Use:   "open [<pr>|<n>...]",
+	Short: "Open PRs in the browser",
+	Long: `Open PRs in the browser ($BROWSER if set), from the last scan in the history,
+without scanning again.
+
+PRs are given as owner/repo#123, repo#123, or pull request URLs, or by their
+number in the prt next list. --next opens the first PR in that list, and
+--section limits it to one section. --section with --all opens every PR in
+the section. Sections are named by key (needs_my_attention), name ("Needs My
+Attention"), or a unique part of the key (attention).
+
+  prt open api#123
+  prt open 2
+  prt open --next
+  prt open --next --section team
+  prt open --section attention --all`,
+	RunE: runOpen,
+}
+
+var (
+	flagOpenNext    bool
+	flagOpenSection string
+	flagOpenAll     bool
+)
+
+func init() {
+	openCmd.Flags().BoolVar(&flagOpenNext, "next", false, "Open the first PR in the prt next list")
+	openCmd.Flags().StringVarP(&flagOpenSection, "section", "s", "", "Pick PRs from this section only")
+	openCmd.Flags().BoolVarP(&flagOpenAll, "all", "a", false, "Open every PR in --section")
+}
+
+// openURL opens a URL in the browser; replaced in tests.
+var openURL = browser.Open
+
+func runOpen(cmd *cobra.Command, args []string) error {
+	picks := 0
+	for _, given := range []bool{len(args) > 0, flagOpenNext, flagOpenAll} {
+		if given {
+			picks++
+		}
+	}
+	switch {
+	case picks == 0:
+		return fmt.Errorf("give one or more PRs, --next, or --section with --all")
+	case picks > 1:
+		return fmt.Errorf("give only one of PRs, --next, and --all")
+	case flagOpenAll && flagOpenSection == "":
+		return fmt.Errorf("--all needs --section")
+	}
+
+	cfg, err := config.Load(nil)
+	if err != nil {
+		return fmt.Errorf("config error: %w", err)
+	}
+	if cfg.HistoryRetentionDays <= 0 {
+		return fmt.Errorf("prt open needs the scan history, which is disabled; set history_retention_days to enable it")
+	}
+	result := lastScanResult()
+	if result == nil {
+		return fmt.Errorf("no scan found in the history; run prt first")
+	}
+
+	prs, err := pickPRs(result, cfg, openSelection{
+		args:    args,
+		next:    flagOpenNext,
+		section: flagOpenSection,
+		all:     flagOpenAll,
+	}, time.Now())
+	if err != nil {
+		return err
+	}
+	if len(prs) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No PRs to open.")
+		return nil
+	}
+	for _, pr := range prs {
+		if err := openURL(pr.URL); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Opened %s %s\n", pr.Key(), pr.URL)
+	}
+	return nil
+}
+
+// openSelection is the PRs picked by the open command's arguments and
+// flags.
+type openSelection struct {
+	args    []string // PR refs, or numbers in the prt next list
+	next    bool     // the first PR in the prt next list
+	section string   // limits the prt next list to one section
+	all     bool     // every PR in section
+}
+
+// pickPRs returns the PRs of result that sel picks, with numbers counting
+// from 1 in the prt next list (limited to sel.section, if set).
+func pickPRs(result *models.ScanResult, cfg *config.Config, sel openSelection, now time.Time) ([]*models.PR, error) {
+	var section *models.Section
+	if sel.section != "" {
+		var err error
+		if section, err = findSection(sel.section, result); err != nil {
+			return nil, err
+		}
+	}
+	if sel.all {
+		return result.SectionPRs(section), nil
+	}
+
+	var next []*models.PR
+	for _, item := range priority.Next(result, cfg, now, 0) {
+		if section == nil || slices.Contains(result.SectionPRs(section), item.PR) {
+			next = append(next, item.PR)
+		}
+	}
+	if sel.next {
+		if len(next) == 0 {
+			return nil, nil
+		}
+		return next[:1], nil
+	}
+
+	all := resultPRs(result)
+	var prs []*models.PR
+	for _, arg := range sel.args {
+		var pr *models.PR
+		if n, err := strconv.Atoi(arg); err == nil {
+			if n < 1 || n > len(next) {
+				return nil, fmt.Errorf("there is no PR %d in the prt next list (it has %d)", n, len(next))
+			}
+			pr = next[n-1]
+		} else {
+			ref, found, err := findPR(arg, all)
+			if err != nil {
+				return nil, err
+			}
+			if found == nil {
+				return nil, fmt.Errorf("%s was not found in the last scan", ref.Key())
+			}
+			pr = found
+		}
+		if !slices.Contains(prs, pr) {
+			prs = append(prs, pr)
+		}
+	}
+	return prs, nil
+}
+
+// findSection finds the section of result named by key or display name
+// (ignoring case), or by a part of its key that only one section's key
+// contains, e.g. "attention" for needs_my_attention.
+func findSection(name string, result *models.ScanResult) (*models.Section, error) {
+	sections := result.DisplaySections()
+	for _, s := range sections {
+		if strings.EqualFold(s.Key, name) || strings.EqualFold(s.Name, name) {
+			return s, nil
+		}
+	}
+
+	part := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(name), " ", "_"))
+	var matches []*models.Section
+	for _, s := range sections {
+		if part != "" && strings.Contains(s.Key, part) {
+			matches = append(matches, s)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("unknown section %q (sections: %s)", name, sectionKeys(sections))
+	case 1:
+		return matches[0], nil
+	}
+	return nil, fmt.Errorf("section %q is ambiguous: %s", name, sectionKeys(matches))
+}
+
+// sectionKeys lists the keys of sections, separated by commas.
+func sectionKeys(sections []*models.Section) string {
+	keys := make([]string, len(sections))
+	for i, s := range sections {
+		keys[i] = s.Key
+	}
+	return strings.Join(keys, ", ")
+}
@@ -0,0 +1,215 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"prt/internal/config"
+	"prt/internal/history"
+	"prt/internal/models"
+)
+
+func TestOpenCmd(t *testing.T) {
+	found := false
+	for _, cmd := range rootCmd.Commands() {
+		if cmd == openCmd {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("open subcommand should be registered")
+	}
+
+	for _, name := range []string{"next", "section", "all"} {
+		flag := openCmd.Flags().Lookup(name)
+		if flag == nil {
+			t.Errorf("expected flag --%s on open", name)
+			continue
+		}
+		if flag.Usage == "" {
+			t.Errorf("flag --%s should have a usage description", name)
+		}
+	}
+}
+
+// openTestResult returns a scan result for "me" with my failing PR, two
+// review requests (the older one ranking first), and a PR needing nothing.
+func openTestResult() *models.ScanResult {
+	now := time.Now()
+	result := models.NewScanResult()
+	result.Username = "me"
+	result.MyPRs = []*models.PR{
+		{Number: 1, RepoOwner: "org", RepoName: "api", Author: "me", CIStatus: models.CIStatusFailing, CreatedAt: now},
+	}
+	result.NeedsMyAttention = []*models.PR{
+		{Number: 2, RepoOwner: "org", RepoName: "api", Author: "bob", IsReviewRequestedFromMe: true, CreatedAt: now},
+		{Number: 3, RepoOwner: "org", RepoName: "web", Author: "carol", IsReviewRequestedFromMe: true, CreatedAt: now.Add(-72 * time.Hour)},
+	}
+	result.OtherPRs = []*models.PR{
+		{Number: 4, RepoOwner: "org", RepoName: "web", Author: "dave", CreatedAt: now},
+	}
+	return result
+}
+
+func TestPickPRs(t *testing.T) {
+	cfg := &config.Config{Priority: config.DefaultPriorityWeights()}
+
+	tests := []struct {
+		name     string
+		sel      openSelection
+		wantKeys []string
+		wantErr  string
+	}{
+		{name: "refs", sel: openSelection{args: []string{"web#4", "org/api#1", "api#1"}}, wantKeys: []string{"org/web#4", "org/api#1"}},
+		{name: "next list numbers", sel: openSelection{args: []string{"1", "3"}}, wantKeys: []string{"org/web#3", "org/api#1"}},
+		{name: "number out of range", sel: openSelection{args: []string{"4"}}, wantErr: "no PR 4"},
+		{name: "unknown ref", sel: openSelection{args: []string{"api#9"}}, wantErr: "not found"},
+		{name: "next", sel: openSelection{next: true}, wantKeys: []string{"org/web#3"}},
+		{name: "next in section", sel: openSelection{next: true, section: "mine"}, wantErr: "unknown section"},
+		{name: "next in my PRs", sel: openSelection{next: true, section: "my_prs"}, wantKeys: []string{"org/api#1"}},
+		{name: "number in section", sel: openSelection{args: []string{"2"}, section: "attention"}, wantKeys: []string{"org/api#2"}},
+		{name: "next with nothing to do", sel: openSelection{next: true, section: "other"}},
+		{name: "all in section", sel: openSelection{all: true, section: "Needs My Attention"}, wantKeys: []string{"org/api#2", "org/web#3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prs, err := pickPRs(openTestResult(), cfg, tt.sel, time.Now())
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("pickPRs() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("pickPRs() error = %v", err)
+			}
+			var keys []string
+			for _, pr := range prs {
+				keys = append(keys, pr.Key())
+			}
+			if strings.Join(keys, " ") != strings.Join(tt.wantKeys, " ") {
+				t.Errorf("pickPRs() = %v, want %v", keys, tt.wantKeys)
+			}
+		})
+	}
+}
+
+func TestFindSection(t *testing.T) {
+	result := models.NewScanResult()
+	result.Sections = []*models.Section{
+		{Key: models.SectionMyPRs, Name: "My PRs"},
+		{Key: models.SectionNeedsMyAttention, Name: "Needs My Attention"},
+		{Key: "release_blockers", Name: "Release blockers"},
+	}
+
+	tests := []struct {
+		name    string
+		wantKey string
+		wantErr string
+	}{
+		{name: "needs_my_attention", wantKey: models.SectionNeedsMyAttention},
+		{name: "my prs", wantKey: models.SectionMyPRs},
+		{name: "attention", wantKey: models.SectionNeedsMyAttention},
+		{name: "Release Blockers", wantKey: "release_blockers"},
+		{name: "blockers", wantKey: "release_blockers"},
+		{name: "my", wantErr: "ambiguous: my_prs, needs_my_attention"},
+		{name: "team", wantErr: "unknown section"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			section, err := findSection(tt.name, result)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("findSection(%q) error = %v, want %q", tt.name, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("findSection(%q) error = %v", tt.name, err)
+			}
+			if section.Key != tt.wantKey {
+				t.Errorf("findSection(%q) = %q, want %q", tt.name, section.Key, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestRunOpen(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	var opened []string
+	orig := openURL
+	openURL = func(url string) error {
+		opened = append(opened, url)
+		return nil
+	}
+	defer func() { openURL = orig }()
+
+	var out bytes.Buffer
+	openCmd.SetOut(&out)
+	defer openCmd.SetOut(nil)
+
+	if err := runOpen(openCmd, []string{"api#1"}); err == nil || !strings.Contains(err.Error(), "run prt first") {
+		t.Errorf("runOpen() without a scan error = %v", err)
+	}
+
+	result := openTestResult()
+	for _, pr := range resultPRs(result) {
+		pr.URL = fmt.Sprintf("https://github.com/%s/pull/%d", pr.RepoFullName(), pr.Number)
+	}
+	if err := history.NewStore(history.Dir()).Save(result, time.Now()); err != nil {
+		t.Fatalf("failed to save scan: %v", err)
+	}
+
+	if err := runOpen(openCmd, []string{"api#1"}); err != nil {
+		t.Fatalf("runOpen() error = %v", err)
+	}
+	if len(opened) != 1 || opened[0] != "https://github.com/org/api/pull/1" {
+		t.Errorf("opened %v, want org/api#1", opened)
+	}
+	if out.String() != "Opened org/api#1 https://github.com/org/api/pull/1\n" {
+		t.Errorf("runOpen() printed %q", out.String())
+	}
+
+	if err := runOpen(openCmd, nil); err == nil {
+		t.Error("runOpen() without PRs or flags should fail")
+	}
+
+	// With history disabled, running prt again wouldn't help
+	t.Setenv("PRT_HISTORY_RETENTION_DAYS", "0")
+	if err := runOpen(openCmd, []string{"api#1"}); err == nil || !strings.Contains(err.Error(), "history_retention_days") {
+		t.Errorf("runOpen() with history disabled error = %v, want it to name history_retention_days", err)
+	}
+}
+
+func TestRunOpen_FlagConflicts(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		next    bool
+		all     bool
+		wantErr string
+	}{
+		{name: "PRs and --next", args: []string{"api#1"}, next: true, wantErr: "only one of"},
+		{name: "--next and --all", next: true, all: true, wantErr: "only one of"},
+		{name: "--all without --section", all: true, wantErr: "--all needs --section"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flagOpenNext, flagOpenAll = tt.next, tt.all
+			defer func() { flagOpenNext, flagOpenAll = false, false }()
+
+			err := runOpen(openCmd, tt.args)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("runOpen() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
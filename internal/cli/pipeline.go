@@ -0,0 +1,278 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"prt/internal/categorizer"
+	"prt/internal/config"
+	"prt/internal/display"
+	"prt/internal/github"
+	"prt/internal/history"
+	"prt/internal/models"
+	"prt/internal/notify"
+	"prt/internal/scanner"
+	"prt/internal/state"
+)
+
+// pipeline runs the discovery → fetch → categorize steps that produce a
+// ScanResult. A single run of prt executes it once; watch mode executes it
+// on every refresh.
+type pipeline struct {
+	cfg      *config.Config
+	scanner  scanner.Scanner
+	client   github.Client
+	useASCII bool
+
+	// history records a snapshot of each result; nil if disabled
+	history *history.Store
+	// snapshotEvery, if set, skips snapshots of results that show no
+	// change from the latest snapshot until that one is snapshotEvery old
+	// (see needsSnapshot); set by watch mode and prt serve
+	snapshotEvery time.Duration
+	// notifier sends notifications for each result; nil unless --notify
+	notifier *notify.Notifier
+	// state holds the snoozed, muted, and pinned PRs; nil if none apply
+	state *state.Store
+	// showSnoozed keeps snoozed and muted PRs in their sections
+	showSnoozed bool
+	// query filters each result after it is recorded; nil shows all PRs
+	query *categorizer.Query
+
+	// checked is set once the GitHub client check (and username and team
+	// lookups, if needed) succeeded, so later runs skip it.
+	checked bool
+}
+
+// unchangedSnapshotInterval is how often watch mode and prt serve record a
+// scan that changed nothing. Skipping most of them keeps a long session from
+// filling the history (and its MaxSnapshots cap) with identical snapshots,
+// while saving one every hour keeps a recent one within the retention window.
+const unchangedSnapshotInterval = time.Hour
+
+// newPipeline creates the pipeline for cfg, using the GitHub client from
+// newGitHubClient. Unless disabled in config, every scan is recorded in
+// the scan history.
+func newPipeline(cfg *config.Config, refresh bool, watch time.Duration, useASCII bool) (*pipeline, error) {
+	scnr, err := scanner.NewScanner(cfg.ScanDepth, cfg.IncludeRepos)
+	if err != nil {
+		return nil, fmt.Errorf("scanner error: %w", err)
+	}
+
+	p := &pipeline{
+		cfg:      cfg,
+		scanner:  scnr,
+		client:   newGitHubClient(cfg, refresh, watch),
+		useASCII: useASCII,
+		state:    state.NewStore(state.Path()),
+	}
+	if cfg.HistoryRetentionDays > 0 {
+		p.history = history.NewStore(history.Dir())
+	}
+	return p, nil
+}
+
+// run performs one scan. With showProgress, the discovery spinner and the
+// per-repo progress display are shown on stdout. A nil result means no
+// repositories were found.
+func (p *pipeline) run(showProgress bool) (*models.ScanResult, error) {
+	startTime := time.Now()
+
+	// Show discovery spinner while scanning
+	var spinner *display.Spinner
+	if showProgress {
+		spinner = display.NewSpinner(os.Stdout)
+		spinner.SetASCII(p.useASCII)
+		spinner.Start("Discovering repositories...")
+	}
+
+	// Run gh CLI check and repo scanning in parallel
+	// This saves time by scanning repos while waiting for gh API calls
+	needsUsername := p.cfg.GitHubUsername == ""
+	teamLister, canListTeams := p.client.(github.TeamLister)
+	needsTeams := len(p.cfg.MyTeams) == 0 && canListTeams
+
+	var wg sync.WaitGroup
+	var ghErr error
+	var scanErr error
+	var repos []*models.Repository
+	var username string
+	var teams []string
+	var teamsErr error
+
+	// Goroutine A: gh CLI check + optional username fetch
+	if !p.checked {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if needsUsername {
+				// Combined check + user fetch (parallel internally)
+				user, err := p.client.CheckAndGetUser()
+				if err != nil {
+					ghErr = err
+					return
+				}
+				username = user
+			} else {
+				// Just check gh CLI
+				if err := p.client.Check(); err != nil {
+					ghErr = err
+					return
+				}
+			}
+			if needsTeams {
+				teams, teamsErr = teamLister.ListMyTeams()
+			}
+		}()
+	}
+
+	// Goroutine B: Scan for repositories
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		r, err := p.scanner.Scan(p.cfg)
+		if err != nil {
+			scanErr = fmt.Errorf("scan error: %w", err)
+			return
+		}
+		repos = r
+		// Update spinner count as repos are found
+		if spinner != nil {
+			spinner.UpdateCount(len(r))
+		}
+	}()
+
+	wg.Wait()
+
+	// Stop spinner
+	if spinner != nil {
+		spinner.Stop()
+	}
+
+	// Check for errors (gh errors take priority)
+	if ghErr != nil {
+		return nil, ghErr
+	}
+	if scanErr != nil {
+		return nil, scanErr
+	}
+
+	// Apply username and teams if they were fetched. Failing to list teams
+	// (e.g. a token without read:org) only loses team review requests.
+	if !p.checked && needsUsername {
+		p.cfg.GitHubUsername = username
+	}
+	if !p.checked && needsTeams {
+		if teamsErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not detect your teams (set my_teams in config): %v\n", teamsErr)
+		}
+		p.cfg.MyTeams = teams
+	}
+	p.checked = true
+
+	if len(repos) == 0 {
+		return nil, nil
+	}
+
+	// Fetch PRs with progress display
+	var progress *display.ProgressDisplay
+	if showProgress {
+		progress = display.NewProgressDisplay(len(repos),
+			display.WithWriter(os.Stdout),
+			display.WithTTY(true),
+			display.WithASCII(p.useASCII),
+		)
+	}
+
+	var progressCallback func(done, total int, repo *models.Repository)
+	if progress != nil {
+		progressCallback = progress.ProgressCallback()
+	}
+
+	github.FetchAllPRs(repos, p.client, progressCallback)
+
+	// Clear progress display if used
+	if progress != nil {
+		progress.Clear()
+	}
+
+	// Categorize, setting aside snoozed and muted PRs
+	opts := []categorizer.Option{categorizer.WithShowSnoozed(p.showSnoozed)}
+	if p.state != nil {
+		st, err := p.state.Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring snoozed, muted, and pinned PRs: %v\n", err)
+		} else {
+			opts = append(opts, categorizer.WithState(st))
+		}
+	}
+	cat := categorizer.NewCategorizer(opts...)
+	result := cat.Categorize(repos, p.cfg, p.cfg.GitHubUsername)
+	result.ScanDuration = time.Since(startTime)
+
+	return result, nil
+}
+
+// afterScan records result in the scan history (see needsSnapshot) and
+// sends notifications for it, as enabled, then filters it by the query, if
+// any; history and notifications always see every PR. Failures are
+// returned as a single warning rather than failing the run. A nil result
+// (no repositories found) is skipped.
+func (p *pipeline) afterScan(result *models.ScanResult) error {
+	if result == nil {
+		return nil
+	}
+
+	var errs []string
+	if now := time.Now(); p.history != nil && p.needsSnapshot(result, now) {
+		retention := time.Duration(p.cfg.HistoryRetentionDays) * 24 * time.Hour
+		if err := p.history.Save(result, now); err != nil {
+			errs = append(errs, fmt.Sprintf("failed to save scan history: %v", err))
+		} else if err := p.history.Prune(now.Add(-retention)); err != nil {
+			errs = append(errs, fmt.Sprintf("failed to prune scan history: %v", err))
+		}
+	}
+	if p.notifier != nil {
+		if err := p.notifier.Process(result); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if p.query != nil {
+		p.query.Filter(result, p.cfg, time.Now())
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// needsSnapshot reports whether result is to be recorded in the history.
+// Every result is, unless snapshotEvery is set: then a result with nothing
+// for prt diff to report since the latest snapshot is skipped while that
+// snapshot is younger than snapshotEvery. If the latest snapshot can't be
+// read, result is recorded.
+func (p *pipeline) needsSnapshot(result *models.ScanResult, now time.Time) bool {
+	if p.snapshotEvery <= 0 {
+		return true
+	}
+
+	entries, err := p.history.List()
+	if err != nil || len(entries) == 0 {
+		return true
+	}
+	latest := entries[len(entries)-1]
+	if now.Sub(latest.TakenAt) >= p.snapshotEvery {
+		return true
+	}
+	snap, err := p.history.Load(latest)
+	if err != nil {
+		return true
+	}
+	return !history.Diff(snap, &history.Snapshot{TakenAt: now, Result: result}, p.cfg.MaxPRAgeDays).IsEmpty()
+}
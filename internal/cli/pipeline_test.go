@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"prt/internal/categorizer"
+	"prt/internal/config"
+	"prt/internal/history"
+	"prt/internal/models"
+)
+
+func TestPipeline_AfterScanRecordsHistory(t *testing.T) {
+	store := history.NewStore(t.TempDir())
+	old := time.Now().Add(-30 * 24 * time.Hour)
+	store.Save(models.NewScanResult(), old)
+
+	p := &pipeline{cfg: &config.Config{HistoryRetentionDays: 14}, history: store}
+	if err := p.afterScan(models.NewScanResult()); err != nil {
+		t.Fatalf("afterScan() error = %v", err)
+	}
+
+	entries, err := store.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].TakenAt.Equal(old) {
+		t.Errorf("expected only the new snapshot after pruning, got %v", entries)
+	}
+
+	// No repositories found: nothing to record
+	if err := p.afterScan(nil); err != nil {
+		t.Fatalf("afterScan(nil) error = %v", err)
+	}
+	if entries, _ := store.List(); len(entries) != 1 {
+		t.Errorf("a nil result should not be recorded, got %d snapshots", len(entries))
+	}
+}
+
+func TestPipeline_AfterScanSkipsUnchangedSnapshots(t *testing.T) {
+	scan := func(ci models.CIStatus) *models.ScanResult {
+		result := models.NewScanResult()
+		result.ReposWithPRs = []*models.Repository{{Owner: "org", Name: "api"}}
+		result.MyPRs = []*models.PR{{Number: 1, RepoOwner: "org", RepoName: "api", CIStatus: ci}}
+		return result
+	}
+
+	tests := []struct {
+		name          string
+		snapshotEvery time.Duration
+		latestAge     time.Duration
+		result        *models.ScanResult
+		want          int
+	}{
+		{"unchanged", time.Hour, time.Minute, scan(models.CIStatusPassing), 1},
+		{"changed", time.Hour, time.Minute, scan(models.CIStatusFailing), 2},
+		{"unchanged but latest is old", time.Hour, 2 * time.Hour, scan(models.CIStatusPassing), 2},
+		{"every scan recorded", 0, time.Minute, scan(models.CIStatusPassing), 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := history.NewStore(t.TempDir())
+			if err := store.Save(scan(models.CIStatusPassing), time.Now().Add(-tt.latestAge)); err != nil {
+				t.Fatal(err)
+			}
+
+			p := &pipeline{cfg: &config.Config{HistoryRetentionDays: 14}, history: store, snapshotEvery: tt.snapshotEvery}
+			if err := p.afterScan(tt.result); err != nil {
+				t.Fatalf("afterScan() error = %v", err)
+			}
+			if entries, _ := store.List(); len(entries) != tt.want {
+				t.Errorf("got %d snapshots, want %d", len(entries), tt.want)
+			}
+		})
+	}
+}
+
+func TestPipeline_AfterScanReportsFailures(t *testing.T) {
+	// A file where the history directory should be
+	dir := filepath.Join(t.TempDir(), "history")
+	if err := os.WriteFile(dir, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	p := &pipeline{cfg: &config.Config{HistoryRetentionDays: 14}, history: history.NewStore(dir)}
+	err := p.afterScan(models.NewScanResult())
+	if err == nil || !strings.Contains(err.Error(), "failed to save scan history") {
+		t.Errorf("afterScan() error = %v, want a history failure", err)
+	}
+}
+
+func TestPipeline_AfterScanFiltersAfterRecording(t *testing.T) {
+	store := history.NewStore(t.TempDir())
+	query, err := categorizer.ParseQuery("label:urgent", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := models.NewScanResult()
+	result.MyPRs = []*models.PR{
+		{Number: 1, RepoOwner: "org", RepoName: "api", Labels: []string{"urgent"}},
+		{Number: 2, RepoOwner: "org", RepoName: "api"},
+	}
+
+	p := &pipeline{cfg: &config.Config{HistoryRetentionDays: 14}, history: store, query: query}
+	if err := p.afterScan(result); err != nil {
+		t.Fatalf("afterScan() error = %v", err)
+	}
+	if len(result.MyPRs) != 1 || result.Query != "label:urgent" {
+		t.Errorf("MyPRs = %v (query %q), want only the urgent PR", result.MyPRs, result.Query)
+	}
+
+	entries, err := store.List()
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("List() = %v, %v, want one snapshot", entries, err)
+	}
+	snap, err := store.Load(entries[0])
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(snap.Result.MyPRs) != 2 {
+		t.Errorf("recorded %d PRs, want both", len(snap.Result.MyPRs))
+	}
+}
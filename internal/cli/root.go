@@ -4,15 +4,14 @@ package cli
 import (
 	"fmt"
 	"os"
-	"sync"
 	"time"
 
+	"prt/internal/cache"
 	"prt/internal/categorizer"
 	"prt/internal/config"
 	"prt/internal/display"
 	"prt/internal/github"
-	"prt/internal/models"
-	"prt/internal/scanner"
+	"prt/internal/notify"
 
 	"github.com/spf13/cobra"
 )
@@ -43,23 +42,45 @@ shows stacked PR relationships.`,
 	flagDepth   int
 	flagMaxAge  int
 	flagJSON    bool
+	flagFormat  string
 	flagNoColor bool
 	flagSetup   bool
+	flagRefresh bool
+	flagWatch   time.Duration
+
+	flagInteractive bool
+	flagNotify      bool
+	flagShowSnoozed bool
+	flagQuery       string
 )
 
 func init() {
 	rootCmd.Flags().StringVarP(&flagPath, "path", "p", "", "Search path (overrides config)")
 	rootCmd.Flags().StringVarP(&flagFilter, "filter", "f", "", "Filter repos by name pattern (glob)")
 	rootCmd.Flags().StringVarP(&flagGroup, "group", "g", "", "Group by: project, author")
-	rootCmd.Flags().StringVarP(&flagSort, "sort", "s", "", "Sort by: oldest, newest")
+	rootCmd.Flags().StringVarP(&flagSort, "sort", "s", "", "Sort by one or more keys (e.g. ci,updated): oldest, newest, updated, size, approvals, ci, repo, author, priority")
 	rootCmd.Flags().IntVarP(&flagDepth, "depth", "d", 0, "Scan depth (0 uses config default)")
 	rootCmd.Flags().IntVar(&flagMaxAge, "max-age", 0, "Hide PRs older than N days (0 uses config default)")
 	rootCmd.Flags().BoolVar(&flagJSON, "json", false, "Output as JSON")
+	rootCmd.Flags().StringVar(&flagFormat, "format", "", "Output format: text, json, markdown (default text)")
 	rootCmd.Flags().BoolVar(&flagNoColor, "no-color", false, "Disable colored output")
 	rootCmd.Flags().BoolVar(&flagSetup, "setup", false, "Re-run the setup wizard")
+	rootCmd.Flags().BoolVar(&flagRefresh, "refresh", false, "Ignore cached PRs and fetch everything")
+	rootCmd.Flags().DurationVar(&flagWatch, "watch", 0, "Re-scan on an interval (e.g. 2m) and redraw in place")
+	rootCmd.Flags().BoolVarP(&flagInteractive, "interactive", "i", false, "Browse PRs in an interactive dashboard")
+	rootCmd.Flags().BoolVar(&flagNotify, "notify", false, "Run notify_command for PRs that newly need attention")
+	rootCmd.Flags().BoolVar(&flagShowSnoozed, "show-snoozed", false, "Show snoozed and muted PRs in their sections")
+	rootCmd.Flags().StringVarP(&flagQuery, "query", "q", "", "Only show PRs matching a query (e.g. \"author:alice ci:failing -draft\") or saved query")
 
 	// Add subcommands
 	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(diffCmd)
+	rootCmd.AddCommand(nextCmd)
+	rootCmd.AddCommand(stackCmd)
+	rootCmd.AddCommand(snoozeCmd, unsnoozeCmd, muteCmd, unmuteCmd, pinCmd, unpinCmd)
+	rootCmd.AddCommand(approveCmd, commentCmd, mergeCmd, rerunCmd)
+	rootCmd.AddCommand(openCmd)
+	rootCmd.AddCommand(serveCmd)
 }
 
 // Execute runs the CLI with the given version string.
@@ -68,9 +89,37 @@ func Execute(version string) error {
 	return rootCmd.Execute()
 }
 
-func runPRT(cmd *cobra.Command, args []string) error {
-	startTime := time.Now()
+// newGitHubClient returns the GitHub client for the configured backend.
+// The first configured host is used to authenticate and detect the user.
+// Unless caching is disabled, fetched PRs are cached on disk for
+// cache_ttl_minutes; refresh bypasses cached entries for this run. In watch
+// mode the TTL is capped at the watch interval so every refresh sees
+// changes made since the previous one.
+func newGitHubClient(cfg *config.Config, refresh bool, watch time.Duration) github.Client {
+	opts := []github.Option{
+		github.WithHost(cfg.Hosts()[0]),
+		github.WithMaxPRs(cfg.MaxPRsPerRepo),
+		github.WithPRBody(cfg.LinkDependsOn),
+	}
 
+	var client github.Client
+	if cfg.Backend == config.BackendAPI {
+		client = github.NewAPIClient(opts...)
+	} else {
+		client = github.NewClient(opts...)
+	}
+
+	if cfg.CacheTTLMinutes <= 0 {
+		return client
+	}
+	ttl := time.Duration(cfg.CacheTTLMinutes) * time.Minute
+	if watch > 0 && watch < ttl {
+		ttl = watch
+	}
+	return github.NewCachingClient(client, cache.NewStore(cache.Dir()), ttl, refresh)
+}
+
+func runPRT(cmd *cobra.Command, args []string) error {
 	// Determine output settings
 	isTTY := display.IsTTY(os.Stdout)
 	noColor := flagNoColor || os.Getenv("NO_COLOR") != ""
@@ -101,135 +150,77 @@ func runPRT(cmd *cobra.Command, args []string) error {
 		return runWizard(cfg)
 	}
 
-	// 3. Validate config
+	// 3. Validate config and flags
 	if err := cfg.Validate(); err != nil {
 		return err
 	}
-
-	// 4. Create scanner early (needed for parallel scan)
-	scnr, err := scanner.NewScanner(cfg.ScanDepth, cfg.IncludeRepos)
+	format, err := outputFormat(flagFormat, flagJSON)
 	if err != nil {
-		return fmt.Errorf("scanner error: %w", err)
-	}
-
-	// 5. Show discovery spinner while scanning
-	// Only show spinner for TTY and non-JSON output
-	var spinner *display.Spinner
-	showProgress := isTTY && !flagJSON
-	if showProgress {
-		spinner = display.NewSpinner(os.Stdout)
-		spinner.SetASCII(useASCII)
-		spinner.Start("Discovering repositories...")
-	}
-
-	// 6. Run gh CLI check and repo scanning in parallel
-	// This saves time by scanning repos while waiting for gh API calls
-	ghClient := github.NewClient()
-	needsUsername := cfg.GitHubUsername == ""
-
-	var wg sync.WaitGroup
-	var ghErr error
-	var scanErr error
-	var repos []*models.Repository
-	var username string
-
-	wg.Add(2)
-
-	// Goroutine A: gh CLI check + optional username fetch
-	go func() {
-		defer wg.Done()
-		if needsUsername {
-			// Combined check + user fetch (parallel internally)
-			user, err := ghClient.CheckAndGetUser()
-			if err != nil {
-				ghErr = err
-				return
-			}
-			username = user
-		} else {
-			// Just check gh CLI
-			if err := ghClient.Check(); err != nil {
-				ghErr = err
-			}
-		}
-	}()
-
-	// Goroutine B: Scan for repositories
-	go func() {
-		defer wg.Done()
-		r, err := scnr.Scan(cfg)
-		if err != nil {
-			scanErr = fmt.Errorf("scan error: %w", err)
-			return
-		}
-		repos = r
-		// Update spinner count as repos are found
-		if spinner != nil {
-			spinner.UpdateCount(len(r))
-		}
-	}()
-
-	wg.Wait()
-
-	// Stop spinner
-	if spinner != nil {
-		spinner.Stop()
+		return err
 	}
-
-	// Check for errors (gh errors take priority)
-	if ghErr != nil {
-		return ghErr
+	if err := validateWatch(flagWatch, format, isTTY); err != nil {
+		return err
 	}
-	if scanErr != nil {
-		return scanErr
+	if err := validateInteractive(flagInteractive, flagWatch, format, isTTY); err != nil {
+		return err
 	}
-
-	// Apply username if it was fetched
-	if needsUsername {
-		cfg.GitHubUsername = username
+	if flagNotify && cfg.NotifyCommand == "" {
+		return fmt.Errorf("--notify requires notify_command to be set in %s", config.ConfigPath())
 	}
-
-	if len(repos) == 0 {
-		fmt.Println("No Git repositories found in configured paths.")
-		return nil
+	var query *categorizer.Query
+	if flagQuery != "" {
+		if query, err = categorizer.ParseQuery(flagQuery, cfg.Queries); err != nil {
+			return err
+		}
 	}
 
-	// 7. Fetch PRs with progress display
-	var progress *display.ProgressDisplay
-	if showProgress && len(repos) > 0 {
-		progress = display.NewProgressDisplay(len(repos),
-			display.WithWriter(os.Stdout),
-			display.WithTTY(isTTY),
-			display.WithASCII(useASCII),
-		)
+	// 4. Create scanner and GitHub client
+	p, err := newPipeline(cfg, flagRefresh, flagWatch, useASCII)
+	if err != nil {
+		return err
 	}
-
-	var progressCallback func(done, total int, repo *models.Repository)
-	if progress != nil {
-		progressCallback = progress.ProgressCallback()
+	if flagNotify {
+		p.notifier = notify.NewNotifier(cfg.NotifyCommand, notify.StatePath())
 	}
-
-	github.FetchAllPRs(repos, ghClient, progressCallback)
-
-	// Clear progress display if used
-	if progress != nil {
-		progress.Clear()
+	p.showSnoozed = flagShowSnoozed
+	p.query = query
+	if flagWatch > 0 {
+		p.snapshotEvery = unchangedSnapshotInterval
 	}
 
-	// 8. Categorize
-	cat := categorizer.NewCategorizer()
-	result := cat.Categorize(repos, cfg, cfg.GitHubUsername)
-	result.ScanDuration = time.Since(startTime)
-
-	// 9. Render output
-	output, err := display.Render(result, display.RenderOptions{
+	renderOpts := display.RenderOptions{
 		ShowIcons:    cfg.ShowIcons,
 		ShowBranches: cfg.ShowBranchName,
 		ShowOtherPRs: cfg.ShowOtherPRs,
 		NoColor:      noColor,
-		JSON:         flagJSON,
+		JSON:         format == display.FormatJSON,
+		Markdown:     format == display.FormatMarkdown,
 		GroupBy:      cfg.DefaultGroupBy,
-	})
+	}
+
+	if flagWatch > 0 {
+		return runWatch(p, renderOpts, flagWatch)
+	}
+	if flagInteractive {
+		return runInteractive(p, renderOpts)
+	}
+
+	// 5. Scan, fetch, and categorize
+	// Only show progress for TTY and text output
+	result, err := p.run(isTTY && format == display.FormatText)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		fmt.Println("No Git repositories found in configured paths.")
+		return nil
+	}
+	if err := p.afterScan(result); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	// 6. Render output
+	output, err := display.Render(result, renderOpts)
 	if err != nil {
 		return fmt.Errorf("render error: %w", err)
 	}
@@ -237,3 +228,30 @@ func runPRT(cmd *cobra.Command, args []string) error {
 	fmt.Print(output)
 	return nil
 }
+
+// outputFormat returns the output format given by --format, or by --json,
+// which is short for --format json.
+func outputFormat(format string, jsonOutput bool) (string, error) {
+	switch format {
+	case "":
+		if jsonOutput {
+			return display.FormatJSON, nil
+		}
+		return display.FormatText, nil
+	case display.FormatText, display.FormatJSON, display.FormatMarkdown:
+		if jsonOutput && format != display.FormatJSON {
+			return "", fmt.Errorf("--json cannot be combined with --format %s", format)
+		}
+		return format, nil
+	default:
+		return "", fmt.Errorf("invalid --format %q: must be text, json, or markdown", format)
+	}
+}
+
+// formatFlag returns the flag that selects format, for error messages.
+func formatFlag(format string) string {
+	if format == display.FormatJSON {
+		return "--json"
+	}
+	return "--format " + format
+}
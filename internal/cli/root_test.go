@@ -5,6 +5,8 @@ import (
 	"os"
 	"strings"
 	"testing"
+
+	"prt/internal/display"
 )
 
 func TestRootCmd_HasSetupFlag(t *testing.T) {
@@ -34,6 +36,12 @@ func TestRootCmd_FlagsRegistered(t *testing.T) {
 		"json",
 		"no-color",
 		"setup",
+		"refresh",
+		"watch",
+		"interactive",
+		"notify",
+		"show-snoozed",
+		"query",
 	}
 
 	for _, name := range expectedFlags {
@@ -140,8 +148,10 @@ func TestFlagDefaults(t *testing.T) {
 		{"group default", "group", ""},
 		{"sort default", "sort", ""},
 		{"json default", "json", "false"},
+		{"format default", "format", ""},
 		{"no-color default", "no-color", "false"},
 		{"setup default", "setup", "false"},
+		{"watch default", "watch", "0s"},
 	}
 
 	for _, tt := range tests {
@@ -167,6 +177,7 @@ func TestFlagShorthand(t *testing.T) {
 		{"group", "g"},
 		{"sort", "s"},
 		{"depth", "d"},
+		{"interactive", "i"},
 	}
 
 	for _, tt := range tests {
@@ -186,7 +197,7 @@ func TestFlagUsageDescriptions(t *testing.T) {
 	// Verify all flags have usage descriptions
 	flags := []string{
 		"path", "filter", "group", "sort", "depth",
-		"max-age", "json", "no-color", "setup",
+		"max-age", "json", "format", "no-color", "setup",
 	}
 
 	for _, name := range flags {
@@ -237,3 +248,39 @@ func TestExecuteFunction(t *testing.T) {
 		t.Error("Execute should set version on rootCmd")
 	}
 }
+
+func TestOutputFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		json    bool
+		want    string
+		wantErr string
+	}{
+		{"default", "", false, display.FormatText, ""},
+		{"json flag", "", true, display.FormatJSON, ""},
+		{"text", "text", false, display.FormatText, ""},
+		{"markdown", "markdown", false, display.FormatMarkdown, ""},
+		{"json format and flag", "json", true, display.FormatJSON, ""},
+		{"markdown with json flag", "markdown", true, "", "--json cannot be combined"},
+		{"unknown", "html", false, "", "invalid --format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := outputFormat(tt.format, tt.json)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("outputFormat() error = %v, want it to mention %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("outputFormat() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("outputFormat() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"prt/internal/changes"
+	"prt/internal/config"
+	"prt/internal/display"
+	"prt/internal/models"
+	"prt/internal/server"
+
+	"github.com/spf13/cobra"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the dashboard in the browser",
+	Long: `Scan for PRs every --interval and serve the dashboard over HTTP, for a
+browser tab or a shared screen. The page updates itself after every scan,
+highlighting PRs that are new or changed since the previous one.
+
+  /                  the dashboard
+  /api/result.json   the latest result, as prt --json prints it
+  /api/events        server-sent events: "update" after each scan, with the
+                     JSON result, and "refresh_failed" with the error
+
+The server listens on localhost unless --addr says otherwise; give a host
+or :8080 to share the dashboard with your network.
+
+  prt serve
+  prt serve --addr :8080 --interval 5m`,
+	Args: cobra.NoArgs,
+	RunE: runServe,
+}
+
+var (
+	flagServeAddr     string
+	flagServeInterval time.Duration
+)
+
+func init() {
+	serveCmd.Flags().StringVar(&flagServeAddr, "addr", "localhost:8080", "Address to listen on")
+	serveCmd.Flags().DurationVar(&flagServeInterval, "interval", 2*time.Minute, "Time between scans (e.g. 30s, 5m)")
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	if flagServeInterval < MinWatchInterval {
+		return fmt.Errorf("--interval must be at least %s", MinWatchInterval)
+	}
+
+	cfg, err := config.Load(nil)
+	if err != nil {
+		return fmt.Errorf("config error: %w", err)
+	}
+	if config.NeedsSetup(cfg) {
+		return fmt.Errorf("prt is not set up yet; run prt to configure it")
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	p, err := newServePipeline(cfg, flagServeInterval)
+	if err != nil {
+		return err
+	}
+	srv := server.New(display.RenderOptions{
+		ShowIcons:    cfg.ShowIcons,
+		ShowBranches: cfg.ShowBranchName,
+		ShowOtherPRs: cfg.ShowOtherPRs,
+		GroupBy:      cfg.DefaultGroupBy,
+	})
+
+	listener, err := net.Listen("tcp", flagServeAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", flagServeAddr, err)
+	}
+	httpServer := &http.Server{Handler: srv.Handler()}
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- httpServer.Serve(listener) }()
+	fmt.Printf("Serving the dashboard on http://%s (Ctrl+C to stop)\n", listener.Addr())
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	r := &refresher{
+		run:      p.run,
+		after:    p.afterScan,
+		server:   srv,
+		interval: flagServeInterval,
+		wait:     sleepContext,
+		now:      time.Now,
+	}
+	loopDone := make(chan struct{})
+	go func() {
+		r.loop(ctx)
+		close(loopDone)
+	}()
+
+	select {
+	case <-ctx.Done():
+	case err = <-serveErr:
+		stop()
+	}
+	<-loopDone
+
+	srv.Close()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if shutdownErr := httpServer.Shutdown(shutdownCtx); err == nil {
+		err = shutdownErr
+	}
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// newServePipeline creates the pipeline that scans every interval. Scans
+// that changed nothing are only recorded in the history once an hour (see
+// unchangedSnapshotInterval): at the default interval, MaxSnapshots would
+// otherwise be reached within two days, far short of the retention window.
+func newServePipeline(cfg *config.Config, interval time.Duration) (*pipeline, error) {
+	p, err := newPipeline(cfg, false, interval, true)
+	if err != nil {
+		return nil, err
+	}
+	p.snapshotEvery = unchangedSnapshotInterval
+	return p, nil
+}
+
+// refresher is the scan loop behind runServe.
+type refresher struct {
+	run      func(showProgress bool) (*models.ScanResult, error)
+	after    func(result *models.ScanResult) error
+	server   *server.Server
+	interval time.Duration
+	wait     func(ctx context.Context, d time.Duration) bool
+	now      func() time.Time
+}
+
+// loop scans right away and then every interval until ctx is done,
+// passing each result to the server with the PRs that changed since the
+// previous one. Every successful scan is passed to after (history and
+// notifications); failures of either are reported on stderr, and a failed
+// scan leaves the previous result on the page.
+func (r *refresher) loop(ctx context.Context) {
+	var prev *models.ScanResult
+	for {
+		// Run in the background so an interrupt doesn't wait for a slow scan
+		done := make(chan scanOutcome, 1)
+		go func() {
+			result, err := r.run(false)
+			done <- scanOutcome{result: result, err: err}
+		}()
+
+		var outcome scanOutcome
+		select {
+		case <-ctx.Done():
+			return
+		case outcome = <-done:
+		}
+
+		switch {
+		case outcome.err != nil:
+			fmt.Fprintf(os.Stderr, "Warning: refresh failed: %v\n", outcome.err)
+			r.server.Fail(outcome.err)
+		case outcome.result == nil:
+			r.server.Fail(errors.New("no Git repositories found in configured paths"))
+		default:
+			if err := r.after(outcome.result); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+			highlights := changes.Diff(prev, outcome.result).Labels()
+			if err := r.server.Update(outcome.result, highlights, r.now()); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+			prev = outcome.result
+		}
+
+		if !r.wait(ctx, r.interval) {
+			return
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"prt/internal/config"
+	"prt/internal/display"
+	"prt/internal/history"
+	"prt/internal/models"
+	"prt/internal/server"
+)
+
+func TestServeCmd(t *testing.T) {
+	found := false
+	for _, cmd := range rootCmd.Commands() {
+		if cmd == serveCmd {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("serve subcommand should be registered")
+	}
+
+	for _, name := range []string{"addr", "interval"} {
+		flag := serveCmd.Flags().Lookup(name)
+		if flag == nil {
+			t.Errorf("expected flag --%s on serve", name)
+			continue
+		}
+		if flag.Usage == "" {
+			t.Errorf("flag --%s should have a usage description", name)
+		}
+	}
+
+	flagServeInterval = time.Second
+	defer func() { flagServeInterval = 2 * time.Minute }()
+	if err := runServe(serveCmd, nil); err == nil || !strings.Contains(err.Error(), "at least") {
+		t.Errorf("runServe() with a short interval error = %v", err)
+	}
+}
+
+func TestRefresher_Loop(t *testing.T) {
+	display.DisableColors()
+
+	// The first scan succeeds, the second fails, and the third adds #2
+	runs := []scanOutcome{
+		{result: watchResult(models.CIStatusPassing)},
+		{err: errors.New("network down")},
+		{result: watchResult(models.CIStatusPassing, models.CIStatusFailing)},
+	}
+
+	srv := server.New(display.RenderOptions{})
+	page := func() string {
+		rec := httptest.NewRecorder()
+		srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		return rec.Body.String()
+	}
+
+	var pages []string
+	var scanned int
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r := &refresher{
+		run: func(showProgress bool) (*models.ScanResult, error) {
+			if showProgress {
+				t.Error("scans should not show progress")
+			}
+			next := runs[0]
+			runs = runs[1:]
+			return next.result, next.err
+		},
+		after: func(result *models.ScanResult) error {
+			scanned++
+			return nil
+		},
+		server:   srv,
+		interval: time.Minute,
+		wait: func(ctx context.Context, d time.Duration) bool {
+			if d != time.Minute {
+				t.Errorf("wait(%s), want the interval", d)
+			}
+			pages = append(pages, page())
+			if len(runs) == 0 {
+				cancel()
+				return false
+			}
+			return true
+		},
+		now: time.Now,
+	}
+	r.loop(ctx)
+
+	if len(pages) != 3 {
+		t.Fatalf("expected 3 pages, got %d", len(pages))
+	}
+	if !strings.Contains(pages[0], "#1 PR") || strings.Contains(pages[0], "●") {
+		t.Errorf("first page should show the first scan without highlights:\n%s", pages[0])
+	}
+	if !strings.Contains(pages[1], "Refresh failed: network down") || !strings.Contains(pages[1], "#1 PR") {
+		t.Errorf("a failed scan should keep the previous result and show the error:\n%s", pages[1])
+	}
+	if !strings.Contains(pages[2], "#2 PR</a> <span class=\"highlight\">● new</span>") || strings.Contains(pages[2], "Refresh failed") {
+		t.Errorf("third page should highlight the new PR and clear the error:\n%s", pages[2])
+	}
+	if scanned != 2 {
+		t.Errorf("expected after to run for each successful scan, got %d", scanned)
+	}
+}
+
+func TestNewServePipeline_SkipsUnchangedSnapshots(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	cfg := config.DefaultConfig
+	p, err := newServePipeline(&cfg, 2*time.Minute)
+	if err != nil {
+		t.Fatalf("newServePipeline() error = %v", err)
+	}
+
+	scan := func(ci models.CIStatus) *models.ScanResult {
+		result := models.NewScanResult()
+		result.ReposWithPRs = []*models.Repository{{Owner: "org", Name: "api"}}
+		result.MyPRs = []*models.PR{{Number: 1, RepoOwner: "org", RepoName: "api", CIStatus: ci}}
+		return result
+	}
+	// Four scans, of which only the third changed anything
+	for _, ci := range []models.CIStatus{models.CIStatusPending, models.CIStatusPending, models.CIStatusPassing, models.CIStatusPassing} {
+		if err := p.afterScan(scan(ci)); err != nil {
+			t.Fatalf("afterScan() error = %v", err)
+		}
+	}
+
+	entries, err := history.NewStore(history.Dir()).List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("got %d snapshots, want one for the first scan and one for the change", len(entries))
+	}
+}
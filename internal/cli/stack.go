@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"prt/internal/config"
+	"prt/internal/display"
+	"prt/internal/models"
+	"prt/internal/stacks"
+
+	"github.com/spf13/cobra"
+)
+
+var stackCmd = &cobra.Command{
+	Use:   "stack <pr>",
+	Short: "Show a PR's stack and the order to merge it in",
+	Long: `Scan for PRs and show the stack a PR belongs to, from its root: each PR
+with its readiness to merge (approved, CI passing, mergeable, not waiting on
+an unmerged parent), and the PRs that can be merged right now.
+
+PRs stacked on those that are otherwise ready are listed as follow-ups. Once
+their parent is merged, they still target its branch until they are
+retargeted, which GitHub only does by itself when the parent's branch is
+deleted on merge; run prt stack again then to see which are ready.
+
+The PR is given as owner/repo#123, repo#123, or a pull request URL:
+
+  prt stack api#123
+  prt stack org/api#123 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runStack,
+}
+
+var flagStackJSON bool
+
+func init() {
+	stackCmd.Flags().BoolVar(&flagStackJSON, "json", false, "Output as JSON")
+}
+
+func runStack(cmd *cobra.Command, args []string) error {
+	isTTY := display.IsTTY(os.Stdout)
+	noColor := os.Getenv("NO_COLOR") != ""
+	if noColor {
+		display.DisableColors()
+	}
+
+	cfg, err := config.Load(nil)
+	if err != nil {
+		return fmt.Errorf("config error: %w", err)
+	}
+	if config.NeedsSetup(cfg) {
+		return fmt.Errorf("prt is not set up yet; run prt to configure it")
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	p, err := newPipeline(cfg, false, 0, noColor)
+	if err != nil {
+		return err
+	}
+	result, err := p.run(isTTY && !flagStackJSON)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		fmt.Println("No Git repositories found in configured paths.")
+		return nil
+	}
+	if err := p.afterScan(result); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	ref, pr, err := findPR(args[0], resultPRs(result))
+	if err != nil {
+		return err
+	}
+	if pr == nil {
+		return fmt.Errorf("%s was not found among the open PRs", ref.Key())
+	}
+	root := stacks.GetStackForPR(result.Stacks[pr.RepoFullName()], pr.Number)
+	if root == nil {
+		root = &models.StackNode{PR: pr}
+	}
+	plan := stacks.PlanMerge(root)
+
+	if flagStackJSON {
+		output, err := display.RenderStackPlanJSON(plan)
+		if err != nil {
+			return fmt.Errorf("render error: %w", err)
+		}
+		fmt.Print(output)
+		return nil
+	}
+
+	fmt.Print(display.RenderStackPlan(plan, cfg.ShowIcons))
+	return nil
+}
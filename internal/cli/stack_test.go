@@ -0,0 +1,27 @@
+package cli
+
+import "testing"
+
+func TestStackCmd(t *testing.T) {
+	found := false
+	for _, cmd := range rootCmd.Commands() {
+		if cmd == stackCmd {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("stack subcommand should be registered")
+	}
+
+	flag := stackCmd.Flags().Lookup("json")
+	if flag == nil {
+		t.Fatal("expected flag --json on stack")
+	}
+	if flag.Usage == "" {
+		t.Error("flag --json should have a usage description")
+	}
+	if err := stackCmd.Args(stackCmd, nil); err == nil {
+		t.Error("stack should require a PR argument")
+	}
+}
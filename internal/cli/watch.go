@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"prt/internal/changes"
+	"prt/internal/display"
+	"prt/internal/models"
+)
+
+// MinWatchInterval is the shortest --watch interval accepted, to keep
+// repeated scans well within GitHub's rate limits.
+const MinWatchInterval = 10 * time.Second
+
+// validateWatch checks that --watch can be used with the other flags and
+// the output format.
+func validateWatch(interval time.Duration, format string, isTTY bool) error {
+	if interval == 0 {
+		return nil
+	}
+	if interval < MinWatchInterval {
+		return fmt.Errorf("--watch interval must be at least %s", MinWatchInterval)
+	}
+	if format != display.FormatText {
+		return fmt.Errorf("--watch cannot be combined with %s", formatFlag(format))
+	}
+	if !isTTY {
+		return fmt.Errorf("--watch requires a terminal")
+	}
+	return nil
+}
+
+// runWatch runs the pipeline every interval and redraws the dashboard in
+// place until interrupted. The first run shows the usual progress display
+// and its errors are fatal; later refreshes run quietly, and a failed
+// refresh keeps the previous dashboard on screen.
+func runWatch(p *pipeline, opts display.RenderOptions, interval time.Duration) error {
+	first, err := p.run(true)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	screen := display.NewScreen(os.Stdout)
+	screen.Start()
+	defer screen.Close()
+
+	w := &watcher{
+		run:      p.run,
+		after:    p.afterScan,
+		opts:     opts,
+		screen:   screen,
+		interval: interval,
+		wait:     sleepContext,
+		now:      time.Now,
+	}
+	return w.loop(ctx, first)
+}
+
+// watcher is the refresh loop behind runWatch.
+type watcher struct {
+	run      func(showProgress bool) (*models.ScanResult, error)
+	after    func(result *models.ScanResult) error
+	opts     display.RenderOptions
+	screen   *display.Screen
+	interval time.Duration
+	wait     func(ctx context.Context, d time.Duration) bool
+	now      func() time.Time
+}
+
+// scanOutcome is the result of one pipeline run.
+type scanOutcome struct {
+	result *models.ScanResult
+	err    error
+}
+
+// loop draws result, then refreshes every interval until ctx is done.
+// PRs that are new or changed since the previous refresh are highlighted.
+// Every successful scan is passed to after (history and notifications),
+// whose failure is shown as a warning in the status line.
+func (w *watcher) loop(ctx context.Context, result *models.ScanResult) error {
+	status := display.WatchStatus{Interval: w.interval, Updated: w.now()}
+	status.Warning = w.after(result)
+	var changed changes.Set
+
+	for {
+		status.Changed = len(changed)
+		if err := w.draw(result, changed, status); err != nil {
+			return err
+		}
+
+		if !w.wait(ctx, w.interval) {
+			return nil
+		}
+		w.screen.SetStatus(display.RenderWatchRefreshing())
+
+		// Run in the background so an interrupt doesn't wait for a slow scan
+		done := make(chan scanOutcome, 1)
+		go func() {
+			next, err := w.run(false)
+			done <- scanOutcome{result: next, err: err}
+		}()
+
+		var outcome scanOutcome
+		select {
+		case <-ctx.Done():
+			return nil
+		case outcome = <-done:
+		}
+
+		status.RefreshErr = outcome.err
+		if outcome.err != nil {
+			continue
+		}
+		changed = changes.Diff(result, outcome.result)
+		result = outcome.result
+		status.Updated = w.now()
+		status.Warning = w.after(result)
+	}
+}
+
+// draw renders one frame: the dashboard followed by the watch status line.
+func (w *watcher) draw(result *models.ScanResult, changed changes.Set, status display.WatchStatus) error {
+	body := "No Git repositories found in configured paths.\n"
+	if result != nil {
+		opts := w.opts
+		opts.Highlights = changed.Labels()
+
+		output, err := display.Render(result, opts)
+		if err != nil {
+			return fmt.Errorf("render error: %w", err)
+		}
+		body = output
+	}
+
+	w.screen.Draw(body, display.RenderWatchStatus(status))
+	return nil
+}
+
+// sleepContext waits for d, returning false if ctx is done first.
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
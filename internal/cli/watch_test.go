@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"prt/internal/display"
+	"prt/internal/models"
+)
+
+func TestValidateWatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval time.Duration
+		format   string
+		isTTY    bool
+		wantErr  string
+	}{
+		{"disabled", 0, display.FormatJSON, false, ""},
+		{"valid", time.Minute, display.FormatText, true, ""},
+		{"too short", time.Second, display.FormatText, true, "at least"},
+		{"negative", -time.Minute, display.FormatText, true, "at least"},
+		{"with json", time.Minute, display.FormatJSON, true, "--json"},
+		{"with markdown", time.Minute, display.FormatMarkdown, true, "--format markdown"},
+		{"not a terminal", time.Minute, display.FormatText, false, "terminal"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateWatch(tt.interval, tt.format, tt.isTTY)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateWatch() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateWatch() error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// watchResult builds a scan result with one PR of mine per CI status.
+func watchResult(statuses ...models.CIStatus) *models.ScanResult {
+	result := models.NewScanResult()
+	for i, status := range statuses {
+		result.MyPRs = append(result.MyPRs, &models.PR{
+			Number:    i + 1,
+			Title:     "PR",
+			RepoOwner: "org",
+			RepoName:  "api",
+			State:     models.PRStateOpen,
+			CIStatus:  status,
+			CreatedAt: time.Now(),
+		})
+	}
+	return result
+}
+
+func TestWatcher_Loop(t *testing.T) {
+	display.DisableColors()
+
+	// Refresh 1 changes CI on #1 and adds #2; refresh 2 fails; refresh 3 changes nothing
+	runs := []scanOutcome{
+		{result: watchResult(models.CIStatusFailing, models.CIStatusPassing)},
+		{err: errors.New("network down")},
+		{result: watchResult(models.CIStatusFailing, models.CIStatusPassing)},
+	}
+
+	var frames []string
+	var scanned int
+	buf := &bytes.Buffer{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w := &watcher{
+		run: func(showProgress bool) (*models.ScanResult, error) {
+			if showProgress {
+				t.Error("refreshes should not show progress")
+			}
+			next := runs[0]
+			runs = runs[1:]
+			return next.result, next.err
+		},
+		after: func(result *models.ScanResult) error {
+			scanned++
+			if scanned == 1 {
+				return errors.New("notify_command failed: exit status 1")
+			}
+			return nil
+		},
+		screen:   display.NewScreen(buf),
+		interval: time.Minute,
+		wait: func(ctx context.Context, d time.Duration) bool {
+			frames = append(frames, buf.String())
+			buf.Reset()
+			if len(runs) == 0 {
+				cancel()
+				return false
+			}
+			return true
+		},
+		now: time.Now,
+	}
+
+	if err := w.loop(ctx, watchResult(models.CIStatusPending)); err != nil {
+		t.Fatalf("loop() error = %v", err)
+	}
+
+	if len(frames) != 4 {
+		t.Fatalf("expected 4 frames, got %d", len(frames))
+	}
+	if strings.Contains(frames[0], "●") {
+		t.Error("first frame should not highlight anything")
+	}
+	if !strings.Contains(frames[0], "Warning: notify_command failed: exit status 1") {
+		t.Errorf("first frame should report the warning from after:\n%s", frames[0])
+	}
+	if strings.Contains(frames[1], "Warning") {
+		t.Errorf("a successful refresh should clear the warning:\n%s", frames[1])
+	}
+	if !strings.Contains(frames[1], "● CI changed") || !strings.Contains(frames[1], "● new") {
+		t.Errorf("second frame should highlight changed and new PRs:\n%s", frames[1])
+	}
+	if !strings.Contains(frames[1], "2 changed") {
+		t.Errorf("second frame status should count changes:\n%s", frames[1])
+	}
+	if !strings.Contains(frames[2], "Refresh failed: network down") || !strings.Contains(frames[2], "#2 PR") {
+		t.Errorf("failed refresh should keep the previous dashboard and report the error:\n%s", frames[2])
+	}
+	if strings.Contains(frames[3], "●") {
+		t.Errorf("unchanged refresh should clear highlights:\n%s", frames[3])
+	}
+	if scanned != 3 {
+		t.Errorf("expected after to run for the first scan and each successful refresh, got %d", scanned)
+	}
+}
+
+func TestSleepContext(t *testing.T) {
+	if !sleepContext(context.Background(), time.Millisecond) {
+		t.Error("expected sleepContext to complete")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if sleepContext(ctx, time.Hour) {
+		t.Error("expected sleepContext to stop when the context is done")
+	}
+}
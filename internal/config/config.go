@@ -5,6 +5,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/gobwas/glob"
 	"github.com/spf13/viper"
 )
 
@@ -27,6 +28,13 @@ func (c *Config) Validate() error {
 		errs = append(errs, "github_username is required (set in config or via gh CLI auto-detect)")
 	}
 
+	// Teams are qualified by their organization
+	for _, team := range c.MyTeams {
+		if org, slug, ok := strings.Cut(NormalizeTeam(team), "/"); !ok || org == "" || slug == "" || strings.Contains(slug, "/") {
+			errs = append(errs, fmt.Sprintf("invalid my_teams entry: %q (expected org/team, like %q)", team, "myorg/backend"))
+		}
+	}
+
 	// At least one search path required
 	if len(c.SearchPaths) == 0 {
 		errs = append(errs, "at least one search_path is required")
@@ -46,7 +54,40 @@ func (c *Config) Validate() error {
 
 	// Valid sort value
 	if !IsValidSort(c.DefaultSort) {
-		errs = append(errs, fmt.Sprintf("invalid default_sort: %q (must be %q or %q)", c.DefaultSort, SortOldest, SortNewest))
+		errs = append(errs, fmt.Sprintf("invalid default_sort: %q (must be a comma-separated list of: %s)", c.DefaultSort, strings.Join(SortKeys, ", ")))
+	}
+
+	// Valid backend value (empty means the default, gh)
+	if c.Backend != "" && !IsValidBackend(c.Backend) {
+		errs = append(errs, fmt.Sprintf("invalid backend: %q (must be %q or %q)", c.Backend, BackendGH, BackendAPI))
+	}
+
+	// Sections need unique keys and valid predicates
+	errs = append(errs, validateSections(c.Sections)...)
+
+	// Ignored checks are matched by glob pattern
+	for _, pattern := range c.CIIgnoreChecks {
+		if _, err := glob.Compile(pattern); err != nil {
+			errs = append(errs, fmt.Sprintf("invalid ci_ignore_checks pattern: %q", pattern))
+		}
+	}
+
+	// Priority weights can't be negative (0 turns a factor off)
+	errs = append(errs, validatePriority(c.Priority)...)
+
+	// Saved query names are used as bare words in --query; the queries
+	// themselves are checked when --query uses them
+	for name := range c.Queries {
+		if name == "" || strings.ContainsAny(name, " \t:\"") || strings.HasPrefix(name, "-") {
+			errs = append(errs, fmt.Sprintf("invalid queries name: %q (must be a single word without colons or quotes)", name))
+		}
+	}
+
+	// Hosts must be bare hostnames (optionally with a port), not URLs with paths
+	for _, host := range c.GitHubHosts {
+		if h := NormalizeHost(host); h == "" || strings.ContainsAny(h, "/ ") {
+			errs = append(errs, fmt.Sprintf("invalid github_hosts entry: %q (expected a hostname like %q)", host, "github.mycorp.com"))
+		}
 	}
 
 	// Scan depth must be positive
@@ -54,6 +95,21 @@ func (c *Config) Validate() error {
 		errs = append(errs, "scan_depth must be at least 1")
 	}
 
+	// PR cap can't be negative (0 means the default)
+	if c.MaxPRsPerRepo < 0 {
+		errs = append(errs, "max_prs_per_repo must not be negative")
+	}
+
+	// Cache TTL can't be negative (0 disables the cache)
+	if c.CacheTTLMinutes < 0 {
+		errs = append(errs, "cache_ttl_minutes must not be negative")
+	}
+
+	// History retention can't be negative (0 disables history)
+	if c.HistoryRetentionDays < 0 {
+		errs = append(errs, "history_retention_days must not be negative")
+	}
+
 	if len(errs) > 0 {
 		return &ValidationError{Errors: errs}
 	}
@@ -84,6 +140,7 @@ func Load(flags *Flags) (*Config, error) {
 	// 1. Set defaults from DefaultConfig
 	v.SetDefault("github_username", DefaultConfig.GitHubUsername)
 	v.SetDefault("team_members", DefaultConfig.TeamMembers)
+	v.SetDefault("my_teams", DefaultConfig.MyTeams)
 	v.SetDefault("search_paths", DefaultConfig.SearchPaths)
 	v.SetDefault("include_repos", DefaultConfig.IncludeRepos)
 	v.SetDefault("scan_depth", DefaultConfig.ScanDepth)
@@ -93,7 +150,20 @@ func Load(flags *Flags) (*Config, error) {
 	v.SetDefault("show_branch_name", DefaultConfig.ShowBranchName)
 	v.SetDefault("show_icons", DefaultConfig.ShowIcons)
 	v.SetDefault("show_other_prs", DefaultConfig.ShowOtherPRs)
+	v.SetDefault("sections", DefaultConfig.Sections)
+	v.SetDefault("link_depends_on", DefaultConfig.LinkDependsOn)
+	v.SetDefault("ci_ignore_checks", DefaultConfig.CIIgnoreChecks)
+	for _, f := range DefaultConfig.Priority.fields() {
+		v.SetDefault("priority."+f.key, f.value)
+	}
+	v.SetDefault("queries", DefaultConfig.Queries)
 	v.SetDefault("max_pr_age_days", DefaultConfig.MaxPRAgeDays)
+	v.SetDefault("max_prs_per_repo", DefaultConfig.MaxPRsPerRepo)
+	v.SetDefault("cache_ttl_minutes", DefaultConfig.CacheTTLMinutes)
+	v.SetDefault("history_retention_days", DefaultConfig.HistoryRetentionDays)
+	v.SetDefault("notify_command", DefaultConfig.NotifyCommand)
+	v.SetDefault("backend", DefaultConfig.Backend)
+	v.SetDefault("github_hosts", DefaultConfig.GitHubHosts)
 
 	// 2. Load config file
 	v.SetConfigName("config")
@@ -29,6 +29,9 @@ func TestLoad_WithDefaults(t *testing.T) {
 	if !cfg.ShowIcons {
 		t.Error("ShowIcons should be true by default")
 	}
+	if len(cfg.Sections) != len(DefaultSections()) {
+		t.Errorf("Sections = %+v, want the built-in sections", cfg.Sections)
+	}
 }
 
 func TestLoad_WithFlags(t *testing.T) {
@@ -180,6 +183,121 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "invalid backend",
+			cfg: Config{
+				GitHubUsername: "testuser",
+				SearchPaths:    []string{tmpDir},
+				DefaultGroupBy: GroupByProject,
+				DefaultSort:    SortOldest,
+				ScanDepth:      3,
+				Backend:        "rest",
+			},
+			wantErr: true,
+			errMsgs: []string{"invalid backend"},
+		},
+		{
+			name: "api backend",
+			cfg: Config{
+				GitHubUsername: "testuser",
+				SearchPaths:    []string{tmpDir},
+				DefaultGroupBy: GroupByProject,
+				DefaultSort:    SortOldest,
+				ScanDepth:      3,
+				Backend:        BackendAPI,
+			},
+			wantErr: false,
+		},
+		{
+			name: "enterprise hosts",
+			cfg: Config{
+				GitHubUsername: "testuser",
+				SearchPaths:    []string{tmpDir},
+				DefaultGroupBy: GroupByProject,
+				DefaultSort:    SortOldest,
+				ScanDepth:      3,
+				GitHubHosts:    []string{"github.com", "https://github.mycorp.com/", "ghe.io:8443"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "negative max PRs per repo",
+			cfg: Config{
+				GitHubUsername: "testuser",
+				SearchPaths:    []string{tmpDir},
+				DefaultGroupBy: GroupByProject,
+				DefaultSort:    SortOldest,
+				ScanDepth:      3,
+				MaxPRsPerRepo:  -1,
+			},
+			wantErr: true,
+			errMsgs: []string{"max_prs_per_repo"},
+		},
+		{
+			name: "negative cache TTL",
+			cfg: Config{
+				GitHubUsername:  "testuser",
+				SearchPaths:     []string{tmpDir},
+				DefaultGroupBy:  GroupByProject,
+				DefaultSort:     SortOldest,
+				ScanDepth:       3,
+				CacheTTLMinutes: -5,
+			},
+			wantErr: true,
+			errMsgs: []string{"cache_ttl_minutes"},
+		},
+		{
+			name: "negative history retention",
+			cfg: Config{
+				GitHubUsername:       "testuser",
+				SearchPaths:          []string{tmpDir},
+				DefaultGroupBy:       GroupByProject,
+				DefaultSort:          SortOldest,
+				ScanDepth:            3,
+				HistoryRetentionDays: -1,
+			},
+			wantErr: true,
+			errMsgs: []string{"history_retention_days"},
+		},
+		{
+			name: "negative priority weight",
+			cfg: Config{
+				GitHubUsername: "testuser",
+				SearchPaths:    []string{tmpDir},
+				DefaultGroupBy: GroupByProject,
+				DefaultSort:    SortOldest,
+				ScanDepth:      3,
+				Priority:       PriorityWeights{ReadyToMerge: -5},
+			},
+			wantErr: true,
+			errMsgs: []string{"priority.ready_to_merge"},
+		},
+		{
+			name: "my_teams without organization",
+			cfg: Config{
+				GitHubUsername: "testuser",
+				SearchPaths:    []string{tmpDir},
+				DefaultGroupBy: GroupByProject,
+				DefaultSort:    SortOldest,
+				ScanDepth:      3,
+				MyTeams:        []string{"org/backend", "frontend"},
+			},
+			wantErr: true,
+			errMsgs: []string{"my_teams", `"frontend"`},
+		},
+		{
+			name: "invalid github host",
+			cfg: Config{
+				GitHubUsername: "testuser",
+				SearchPaths:    []string{tmpDir},
+				DefaultGroupBy: GroupByProject,
+				DefaultSort:    SortOldest,
+				ScanDepth:      3,
+				GitHubHosts:    []string{"github.mycorp.com/org"},
+			},
+			wantErr: true,
+			errMsgs: []string{"invalid github_hosts entry"},
+		},
 		{
 			name: "missing username",
 			cfg: Config{
@@ -264,6 +382,32 @@ func TestConfig_Validate(t *testing.T) {
 			wantErr: true,
 			errMsgs: []string{"scan_depth must be at least 1"},
 		},
+		{
+			name: "invalid query names",
+			cfg: Config{
+				GitHubUsername: "testuser",
+				SearchPaths:    []string{tmpDir},
+				DefaultGroupBy: GroupByProject,
+				DefaultSort:    SortOldest,
+				ScanDepth:      3,
+				Queries:        map[string]string{"stale": "age:>7d", "my stale": "age:>7d", "ci:red": "ci:failing"},
+			},
+			wantErr: true,
+			errMsgs: []string{`invalid queries name: "my stale"`, `invalid queries name: "ci:red"`},
+		},
+		{
+			name: "invalid ci_ignore_checks pattern",
+			cfg: Config{
+				GitHubUsername: "testuser",
+				SearchPaths:    []string{tmpDir},
+				DefaultGroupBy: GroupByProject,
+				DefaultSort:    SortOldest,
+				ScanDepth:      3,
+				CIIgnoreChecks: []string{"codecov/*", "lint["},
+			},
+			wantErr: true,
+			errMsgs: []string{`invalid ci_ignore_checks pattern: "lint["`},
+		},
 		{
 			name: "multiple errors",
 			cfg: Config{
@@ -29,18 +29,30 @@ var KnownBots = []string{
 // DefaultConfig returns sensible default configuration values.
 // Note: GitHubUsername and SearchPaths must be set by user or auto-detected.
 var DefaultConfig = Config{
-	GitHubUsername: "",             // Must be set or auto-detected
-	TeamMembers:    []string{},     // No team members by default
-	SearchPaths:    []string{},     // Must be set by user
-	IncludeRepos:   []string{},     // Empty = match all repos
-	ScanDepth:      3,              // Reasonable default depth
-	Bots:           KnownBots,      // Pre-populated bot list
-	DefaultGroupBy: GroupByProject, // Group by project by default
-	DefaultSort:    SortOldest,     // Show oldest PRs first (needs attention)
-	ShowBranchName: true,           // Show branch names
-	ShowIcons:      true,           // Show status icons
-	ShowOtherPRs:   false,          // Hide "Other PRs" by default
-	MaxPRAgeDays:   0,              // No age limit by default (0 = show all)
+	GitHubUsername:       "",             // Must be set or auto-detected
+	TeamMembers:          []string{},     // No team members by default
+	MyTeams:              []string{},     // Auto-detected
+	SearchPaths:          []string{},     // Must be set by user
+	IncludeRepos:         []string{},     // Empty = match all repos
+	ScanDepth:            3,              // Reasonable default depth
+	Bots:                 KnownBots,      // Pre-populated bot list
+	DefaultGroupBy:       GroupByProject, // Group by project by default
+	DefaultSort:          SortOldest,     // Show oldest PRs first (needs attention)
+	ShowBranchName:       true,           // Show branch names
+	ShowIcons:            true,           // Show status icons
+	ShowOtherPRs:         false,          // Hide "Other PRs" by default
+	MaxPRAgeDays:         0,              // No age limit by default (0 = show all)
+	MaxPRsPerRepo:        200,            // Enough for busy monorepos without unbounded paging
+	CacheTTLMinutes:      5,              // Repeated runs within 5 minutes reuse fetched PRs
+	HistoryRetentionDays: 14,             // Two weeks of snapshots for prt diff
+	NotifyCommand:        "",             // Notifications are opt-in
+	Backend:              BackendGH,      // Use the gh CLI by default
+	GitHubHosts:          []string{DefaultGitHubHost},
+	Sections:             DefaultSections(),
+	LinkDependsOn:        false,      // PR descriptions aren't fetched for Depends-on trailers
+	CIIgnoreChecks:       []string{}, // Every check counts towards CI status
+	Priority:             DefaultPriorityWeights(),
+	Queries:              map[string]string{},
 }
 
 // ConfigDir returns the path to the PRT configuration directory.
@@ -52,6 +52,10 @@ func TestDefaultConfig(t *testing.T) {
 		t.Error("ShowIcons should be true by default")
 	}
 
+	if DefaultConfig.Priority != DefaultPriorityWeights() {
+		t.Errorf("Priority = %+v, want the default weights", DefaultConfig.Priority)
+	}
+
 	// Verify Bots is populated with KnownBots
 	if len(DefaultConfig.Bots) != len(KnownBots) {
 		t.Errorf("Bots length = %d, want %d", len(DefaultConfig.Bots), len(KnownBots))
@@ -0,0 +1,66 @@
+package config
+
+import "fmt"
+
+// PriorityWeights tune how prt next and the priority sort rank PRs. A PR's
+// score is the sum of the weights of the factors that apply to it. The first
+// six are actions; PRs with none of them are not actionable and get no score.
+type PriorityWeights struct {
+	ReviewRequested  float64 `yaml:"review_requested" mapstructure:"review_requested"`   // Someone else's PR awaits my review, or is assigned to me
+	ReReview         float64 `yaml:"re_review" mapstructure:"re_review"`                 // New commits since my review
+	CIFailing        float64 `yaml:"ci_failing" mapstructure:"ci_failing"`               // My PR's CI is failing
+	ChangesRequested float64 `yaml:"changes_requested" mapstructure:"changes_requested"` // Changes were requested on my PR
+	ReadyToMerge     float64 `yaml:"ready_to_merge" mapstructure:"ready_to_merge"`       // My PR is approved, not blocked, and CI isn't failing or pending
+	ApprovalsPending float64 `yaml:"approvals_pending" mapstructure:"approvals_pending"` // A teammate's PR has no approval, and I haven't reviewed it
+	Blocking         float64 `yaml:"blocking" mapstructure:"blocking"`                   // Per PR stacked on top of this one
+	AgePerDay        float64 `yaml:"age_per_day" mapstructure:"age_per_day"`             // Per day open, up to 30 days
+	SmallSize        float64 `yaml:"small_size" mapstructure:"small_size"`               // For small PRs, shrinking to 0 at 1000 changed lines
+}
+
+// DefaultPriorityWeights returns the built-in weights, which put review
+// requests first, then fixing and merging my own PRs.
+func DefaultPriorityWeights() PriorityWeights {
+	return PriorityWeights{
+		ReviewRequested:  40,
+		ReReview:         35,
+		CIFailing:        30,
+		ChangesRequested: 30,
+		ReadyToMerge:     25,
+		ApprovalsPending: 10,
+		Blocking:         10,
+		AgePerDay:        1,
+		SmallSize:        10,
+	}
+}
+
+// fields returns each weight with its config key, in config order.
+func (w PriorityWeights) fields() []struct {
+	key   string
+	value float64
+} {
+	return []struct {
+		key   string
+		value float64
+	}{
+		{"review_requested", w.ReviewRequested},
+		{"re_review", w.ReReview},
+		{"ci_failing", w.CIFailing},
+		{"changes_requested", w.ChangesRequested},
+		{"ready_to_merge", w.ReadyToMerge},
+		{"approvals_pending", w.ApprovalsPending},
+		{"blocking", w.Blocking},
+		{"age_per_day", w.AgePerDay},
+		{"small_size", w.SmallSize},
+	}
+}
+
+// validatePriority returns an error message for each negative weight.
+func validatePriority(w PriorityWeights) []string {
+	var errs []string
+	for _, f := range w.fields() {
+		if f.value < 0 {
+			errs = append(errs, fmt.Sprintf("priority.%s must not be negative", f.key))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestValidatePriority(t *testing.T) {
+	if errs := validatePriority(DefaultPriorityWeights()); len(errs) != 0 {
+		t.Errorf("default weights should be valid, got %v", errs)
+	}
+
+	w := DefaultPriorityWeights()
+	w.Blocking = -1
+	w.AgePerDay = 0
+	errs := validatePriority(w)
+	if len(errs) != 1 || !strings.Contains(errs[0], "priority.blocking") {
+		t.Errorf("validatePriority() = %v, want one error for priority.blocking", errs)
+	}
+}
+
+func TestLoad_PriorityMergesWithDefaults(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	os.MkdirAll(filepath.Join(home, ".prt"), 0755)
+	os.WriteFile(filepath.Join(home, ".prt", "config.yaml"), []byte(`
+priority:
+  ci_failing: 50
+  age_per_day: 0.5
+`), 0644)
+	t.Setenv("PRT_PRIORITY_SMALL_SIZE", "0")
+
+	cfg, err := Load(nil)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	want := DefaultPriorityWeights()
+	want.CIFailing = 50
+	want.AgePerDay = 0.5
+	want.SmallSize = 0
+	if cfg.Priority != want {
+		t.Errorf("Priority = %+v, want %+v", cfg.Priority, want)
+	}
+}
+
+func TestGenerateConfigFile_PriorityRoundTrip(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.Priority.ReviewRequested = 55
+	cfg.Priority.AgePerDay = 1.5
+
+	content, err := GenerateConfigFile(&cfg)
+	if err != nil {
+		t.Fatalf("GenerateConfigFile() error: %v", err)
+	}
+
+	var parsed Config
+	if err := yaml.Unmarshal([]byte(content), &parsed); err != nil {
+		t.Fatalf("Generated config is not valid YAML: %v\nContent:\n%s", err, content)
+	}
+	if parsed.Priority != cfg.Priority {
+		t.Errorf("round-tripped priority = %+v, want %+v", parsed.Priority, cfg.Priority)
+	}
+}
@@ -0,0 +1,160 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gobwas/glob"
+
+	"prt/internal/models"
+)
+
+// Special authors entries, resolved when PRs are categorized.
+const (
+	AuthorMe   = "@me"   // The current user
+	AuthorTeam = "@team" // Anyone in team_members
+	AuthorBots = "@bots" // Anyone in bots
+)
+
+// Review match values. The first three describe the current user's part in
+// the review; the others the PR's overall review state.
+const (
+	ReviewRequested        = "requested"         // Review requested from me or my team, and I haven't approved
+	ReviewAssigned         = "assigned"          // Assigned to me, and I haven't approved
+	ReviewReReview         = "re_review"         // New commits since my approval or change request
+	ReviewApproved         = "approved"          // Approved, with no changes requested
+	ReviewChangesRequested = "changes_requested" // Someone requested changes
+	ReviewUnreviewed       = "unreviewed"        // Neither approved nor changes requested
+)
+
+// ValidReviews lists the accepted review match values.
+var ValidReviews = []string{
+	ReviewRequested, ReviewAssigned, ReviewReReview,
+	ReviewApproved, ReviewChangesRequested, ReviewUnreviewed,
+}
+
+// ValidCIStatuses lists the accepted ci match values.
+var ValidCIStatuses = []string{
+	string(models.CIStatusPassing), string(models.CIStatusFailing),
+	string(models.CIStatusPending), string(models.CIStatusNone),
+}
+
+// Section is a user-defined bucket of PRs. Each PR goes into the first
+// section, in config order, whose Match holds.
+type Section struct {
+	Key   string       `yaml:"key" mapstructure:"key"`     // Identifier in JSON output (defaults to the name in snake_case)
+	Name  string       `yaml:"name" mapstructure:"name"`   // Section title
+	Icon  string       `yaml:"icon" mapstructure:"icon"`   // Shown with show_icons (built-in sections have default icons)
+	Order int          `yaml:"order" mapstructure:"order"` // Display position, lowest first
+	Match SectionMatch `yaml:"match" mapstructure:"match"`
+}
+
+// SectionMatch holds a section's predicates. A PR matches when every
+// predicate that is set holds; within a list, any entry may match. An empty
+// SectionMatch matches every PR.
+type SectionMatch struct {
+	Authors      []string `yaml:"authors" mapstructure:"authors"`             // Logins, or AuthorMe/AuthorTeam/AuthorBots
+	Labels       []string `yaml:"labels" mapstructure:"labels"`               // Any of these labels (case-insensitive)
+	Repos        []string `yaml:"repos" mapstructure:"repos"`                 // Globs against "owner/name" or "name"
+	BaseBranches []string `yaml:"base_branches" mapstructure:"base_branches"` // Globs against the base branch
+	Draft        *bool    `yaml:"draft" mapstructure:"draft"`                 // Draft state (nil = either)
+	CI           []string `yaml:"ci" mapstructure:"ci"`                       // passing | failing | pending | none
+	MinAgeDays   int      `yaml:"min_age_days" mapstructure:"min_age_days"`   // At least this old (0 = no minimum)
+	MaxAgeDays   int      `yaml:"max_age_days" mapstructure:"max_age_days"`   // At most this old (0 = no maximum)
+	Review       []string `yaml:"review" mapstructure:"review"`               // Review* values
+}
+
+// IsEmpty returns true if no predicate is set.
+func (m SectionMatch) IsEmpty() bool {
+	return len(m.Authors) == 0 && len(m.Labels) == 0 && len(m.Repos) == 0 &&
+		len(m.BaseBranches) == 0 && m.Draft == nil && len(m.CI) == 0 &&
+		m.MinAgeDays == 0 && m.MaxAgeDays == 0 && len(m.Review) == 0
+}
+
+// SectionKey returns the section's key: Key if set, otherwise the name in
+// snake_case, so "Release blockers" becomes "release_blockers".
+func (s Section) SectionKey() string {
+	if s.Key != "" {
+		return s.Key
+	}
+	var b strings.Builder
+	for _, field := range strings.FieldsFunc(strings.ToLower(s.Name), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	}) {
+		if b.Len() > 0 {
+			b.WriteByte('_')
+		}
+		b.WriteString(field)
+	}
+	return b.String()
+}
+
+// DefaultSections returns the built-in rule set: my PRs, PRs that need my
+// review, team members' PRs, and everything else.
+func DefaultSections() []Section {
+	return []Section{
+		{Key: models.SectionMyPRs, Name: "My PRs", Order: 10, Match: SectionMatch{Authors: []string{AuthorMe}}},
+		{Key: models.SectionNeedsMyAttention, Name: "Needs My Attention", Order: 20,
+			Match: SectionMatch{Review: []string{ReviewReReview, ReviewRequested, ReviewAssigned}}},
+		{Key: models.SectionTeamPRs, Name: "Team PRs", Order: 30, Match: SectionMatch{Authors: []string{AuthorTeam}}},
+		{Key: models.SectionOtherPRs, Name: "Other PRs", Order: 40},
+	}
+}
+
+// validateSections returns an error message for each invalid section.
+func validateSections(sections []Section) []string {
+	var errs []string
+	seen := make(map[string]bool)
+
+	for i, s := range sections {
+		label := fmt.Sprintf("sections[%d]", i)
+		if s.Name != "" {
+			label = fmt.Sprintf("section %q", s.Name)
+		}
+
+		key := s.SectionKey()
+		switch {
+		case s.Name == "":
+			errs = append(errs, fmt.Sprintf("%s: name is required", label))
+		case key == "":
+			errs = append(errs, fmt.Sprintf("%s: key is required when the name has no letters or digits", label))
+		case seen[key]:
+			errs = append(errs, fmt.Sprintf("%s: duplicate key %q", label, key))
+		}
+		seen[key] = true
+
+		m := s.Match
+		for _, pattern := range append(append([]string{}, m.Repos...), m.BaseBranches...) {
+			if _, err := glob.Compile(pattern); err != nil {
+				errs = append(errs, fmt.Sprintf("%s: invalid glob pattern %q", label, pattern))
+			}
+		}
+		for _, ci := range m.CI {
+			if !containsString(ValidCIStatuses, ci) {
+				errs = append(errs, fmt.Sprintf("%s: invalid ci value %q (must be one of %s)", label, ci, strings.Join(ValidCIStatuses, ", ")))
+			}
+		}
+		for _, review := range m.Review {
+			if !containsString(ValidReviews, review) {
+				errs = append(errs, fmt.Sprintf("%s: invalid review value %q (must be one of %s)", label, review, strings.Join(ValidReviews, ", ")))
+			}
+		}
+		if m.MinAgeDays < 0 || m.MaxAgeDays < 0 {
+			errs = append(errs, fmt.Sprintf("%s: min_age_days and max_age_days must not be negative", label))
+		} else if m.MaxAgeDays > 0 && m.MinAgeDays > m.MaxAgeDays {
+			errs = append(errs, fmt.Sprintf("%s: min_age_days is greater than max_age_days", label))
+		}
+	}
+
+	return errs
+}
+
+// containsString checks if a slice contains a specific string.
+func containsString(slice []string, s string) bool {
+	for _, v := range slice {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"prt/internal/models"
+)
+
+func TestSection_SectionKey(t *testing.T) {
+	tests := []struct {
+		section Section
+		want    string
+	}{
+		{Section{Name: "Security"}, "security"},
+		{Section{Name: "Release blockers"}, "release_blockers"},
+		{Section{Name: "  Dependabot / Renovate! "}, "dependabot_renovate"},
+		{Section{Name: "Team PRs", Key: models.SectionTeamPRs}, "team_prs"},
+		{Section{Name: "🔥"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.section.Name, func(t *testing.T) {
+			if got := tt.section.SectionKey(); got != tt.want {
+				t.Errorf("SectionKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultSections(t *testing.T) {
+	sections := DefaultSections()
+
+	want := []string{models.SectionMyPRs, models.SectionNeedsMyAttention, models.SectionTeamPRs, models.SectionOtherPRs}
+	if len(sections) != len(want) {
+		t.Fatalf("DefaultSections() returned %d sections, want %d", len(sections), len(want))
+	}
+	for i, key := range want {
+		if sections[i].SectionKey() != key {
+			t.Errorf("sections[%d] = %q, want %q", i, sections[i].SectionKey(), key)
+		}
+	}
+	if errs := validateSections(sections); len(errs) != 0 {
+		t.Errorf("default sections are invalid: %v", errs)
+	}
+	if !sections[3].Match.IsEmpty() {
+		t.Error("Other PRs should match every PR")
+	}
+}
+
+func TestValidateSections(t *testing.T) {
+	tests := []struct {
+		name     string
+		sections []Section
+		wantErr  string
+	}{
+		{name: "missing name", sections: []Section{{}}, wantErr: "sections[0]: name is required"},
+		{name: "no key", sections: []Section{{Name: "🔥"}}, wantErr: "key is required"},
+		{name: "duplicate key", sections: []Section{{Name: "Security"}, {Name: "security"}}, wantErr: `duplicate key "security"`},
+		{name: "invalid glob", sections: []Section{{Name: "A", Match: SectionMatch{Repos: []string{"api-["}}}}, wantErr: "invalid glob pattern"},
+		{name: "invalid ci", sections: []Section{{Name: "A", Match: SectionMatch{CI: []string{"red"}}}}, wantErr: `invalid ci value "red"`},
+		{name: "invalid review", sections: []Section{{Name: "A", Match: SectionMatch{Review: []string{"lgtm"}}}}, wantErr: `invalid review value "lgtm"`},
+		{name: "negative age", sections: []Section{{Name: "A", Match: SectionMatch{MinAgeDays: -1}}}, wantErr: "must not be negative"},
+		{name: "empty age range", sections: []Section{{Name: "A", Match: SectionMatch{MinAgeDays: 7, MaxAgeDays: 3}}}, wantErr: "greater than max_age_days"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validateSections(tt.sections)
+			if len(errs) != 1 || !strings.Contains(errs[0], tt.wantErr) {
+				t.Errorf("validateSections() = %v, want one error containing %q", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoad_Sections(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	os.MkdirAll(filepath.Join(home, ".prt"), 0755)
+	os.WriteFile(filepath.Join(home, ".prt", "config.yaml"), []byte(`
+sections:
+  - name: "Security"
+    icon: "🔒"
+    order: 5
+    match:
+      labels: ["security"]
+      draft: false
+      max_age_days: 30
+  - name: "Mine"
+    key: "my_prs"
+    match:
+      authors: ["@me"]
+`), 0644)
+
+	cfg, err := Load(nil)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if len(cfg.Sections) != 2 {
+		t.Fatalf("Sections = %+v, want the 2 configured sections", cfg.Sections)
+	}
+	security := cfg.Sections[0]
+	if security.Name != "Security" || security.Icon != "🔒" || security.Order != 5 ||
+		len(security.Match.Labels) != 1 || security.Match.Draft == nil || *security.Match.Draft || security.Match.MaxAgeDays != 30 {
+		t.Errorf("Sections[0] = %+v", security)
+	}
+	if cfg.Sections[1].SectionKey() != models.SectionMyPRs {
+		t.Errorf("Sections[1] key = %q, want %q", cfg.Sections[1].SectionKey(), models.SectionMyPRs)
+	}
+}
+
+func TestGenerateConfigFile_SectionsRoundTrip(t *testing.T) {
+	draft := true
+	cfg := DefaultConfig
+	cfg.Sections = append(DefaultSections(), Section{
+		Name:  "Release blockers",
+		Icon:  "🚧",
+		Order: 15,
+		Match: SectionMatch{
+			Labels:       []string{"release-blocker"},
+			Repos:        []string{"org/*"},
+			BaseBranches: []string{"release/*"},
+			Draft:        &draft,
+			CI:           []string{"failing"},
+			MinAgeDays:   1,
+			MaxAgeDays:   14,
+			Review:       []string{ReviewUnreviewed},
+		},
+	})
+
+	content, err := GenerateConfigFile(&cfg)
+	if err != nil {
+		t.Fatalf("GenerateConfigFile() error: %v", err)
+	}
+
+	var parsed Config
+	if err := yaml.Unmarshal([]byte(content), &parsed); err != nil {
+		t.Fatalf("Generated config is not valid YAML: %v\nContent:\n%s", err, content)
+	}
+	if len(parsed.Sections) != len(cfg.Sections) {
+		t.Fatalf("parsed %d sections, want %d", len(parsed.Sections), len(cfg.Sections))
+	}
+	got := parsed.Sections[4]
+	want := cfg.Sections[4]
+	if got.Name != want.Name || got.Icon != want.Icon || got.Order != want.Order ||
+		got.Match.Draft == nil || !*got.Match.Draft || got.Match.MinAgeDays != 1 || got.Match.MaxAgeDays != 14 ||
+		strings.Join(got.Match.BaseBranches, ",") != "release/*" || strings.Join(got.Match.Review, ",") != ReviewUnreviewed {
+		t.Errorf("round-tripped section = %+v, want %+v", got, want)
+	}
+	if parsed.Sections[0].Key != models.SectionMyPRs || strings.Join(parsed.Sections[1].Match.Review, ",") != "re_review,requested,assigned" {
+		t.Errorf("built-in sections did not round-trip: %+v", parsed.Sections[:2])
+	}
+}
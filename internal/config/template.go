@@ -26,6 +26,16 @@ team_members:
   # - "teammate2"
 {{- end}}
 
+# Teams you belong to, as "org/team" (the team's slug)
+# Review requests to these teams show up in "Needs My Attention"
+# Auto-detected if left empty (via ` + "`gh api user/teams`" + `, needs the read:org scope)
+my_teams:
+{{- range .MyTeams}}
+  - "{{.}}"
+{{- else}}
+  # - "myorg/backend"
+{{- end}}
+
 # Directories to search for Git repositories
 # Supports absolute paths and ~ for home directory
 search_paths:
@@ -60,7 +70,9 @@ bots:
 # Default grouping: "project" or "author"
 default_group_by: "{{.DefaultGroupBy}}"
 
-# Default sort order: "oldest" or "newest" (by creation date)
+# Default sort order: one or more of "oldest", "newest" (by creation date),
+# "updated", "size", "approvals", "ci", "repo", "author", or "priority",
+# separated by commas (e.g. "ci,updated"); a leading "-" reverses a key
 default_sort: "{{.DefaultSort}}"
 
 # Show branch names in PR output
@@ -73,9 +85,153 @@ show_icons: {{.ShowIcons}}
 # Default: false (hidden to reduce noise)
 show_other_prs: {{.ShowOtherPRs}}
 
+# Sections PRs are sorted into, displayed by "order" (lowest first)
+# Each PR goes into the first section in this list whose "match" holds:
+# every predicate given must match, and any entry of a list may match.
+# PRs that match no section go to "Other PRs".
+# The keys my_prs, needs_my_attention, team_prs, and other_prs are built in;
+# other sections are listed under "sections" in --json output.
+# Predicates:
+#   authors: logins, or "@me", "@team" (team_members), "@bots" (bots)
+#   labels: labels (any of them)
+#   repos: globs against "owner/name" or "name"
+#   base_branches: globs against the base branch
+#   draft: true or false
+#   ci: passing, failing, pending, none
+#   min_age_days, max_age_days: age range in days
+#   review: requested, assigned, re_review (your review);
+#           approved, changes_requested, unreviewed (the PR's review state)
+# Example:
+#   - name: "Security"
+#     icon: "🔒"
+#     order: 15
+#     match:
+#       labels: ["security"]
+sections:
+{{- range .Sections}}
+  - name: {{printf "%q" .Name}}
+{{- if .Key}}
+    key: {{printf "%q" .Key}}
+{{- end}}
+{{- if .Icon}}
+    icon: {{printf "%q" .Icon}}
+{{- end}}
+    order: {{.Order}}
+{{- if not .Match.IsEmpty}}
+    match:
+{{- with .Match}}
+{{- if .Authors}}
+      authors: [{{range $i, $v := .Authors}}{{if $i}}, {{end}}{{printf "%q" $v}}{{end}}]
+{{- end}}
+{{- if .Labels}}
+      labels: [{{range $i, $v := .Labels}}{{if $i}}, {{end}}{{printf "%q" $v}}{{end}}]
+{{- end}}
+{{- if .Repos}}
+      repos: [{{range $i, $v := .Repos}}{{if $i}}, {{end}}{{printf "%q" $v}}{{end}}]
+{{- end}}
+{{- if .BaseBranches}}
+      base_branches: [{{range $i, $v := .BaseBranches}}{{if $i}}, {{end}}{{printf "%q" $v}}{{end}}]
+{{- end}}
+{{- if .Draft}}
+      draft: {{.Draft}}
+{{- end}}
+{{- if .CI}}
+      ci: [{{range $i, $v := .CI}}{{if $i}}, {{end}}{{printf "%q" $v}}{{end}}]
+{{- end}}
+{{- if .MinAgeDays}}
+      min_age_days: {{.MinAgeDays}}
+{{- end}}
+{{- if .MaxAgeDays}}
+      max_age_days: {{.MaxAgeDays}}
+{{- end}}
+{{- if .Review}}
+      review: [{{range $i, $v := .Review}}{{if $i}}, {{end}}{{printf "%q" $v}}{{end}}]
+{{- end}}
+{{- end}}
+{{- end}}
+{{- else}}
+  # - name: "Security"
+  #   match:
+  #     labels: ["security"]
+{{- end}}
+
+# Treat the PRs named in "Depends-on: org/repo#123" lines of a PR's
+# description like the PR's parent in a stack, even in other repositories:
+# the PR is shown as blocked until they are merged. Fetches each PR's
+# description on every scan
+link_depends_on: {{.LinkDependsOn}}
+
+# CI check names to ignore when working out a PR's CI status (glob syntax),
+# such as flaky optional jobs; they are still listed with the PR's checks
+ci_ignore_checks:
+{{- range .CIIgnoreChecks}}
+  - "{{.}}"
+{{- else}}
+  # - "codecov/*"
+{{- end}}
+
+# Weights for ranking PRs in "prt next" (0 turns a factor off)
+# A PR's score is the sum of the weights that apply to it. Only PRs with one
+# of the first six factors, which each call for an action, are listed.
+priority:
+  review_requested: {{.Priority.ReviewRequested}}   # Someone else's PR awaits your review
+  re_review: {{.Priority.ReReview}}          # New commits since your review
+  ci_failing: {{.Priority.CIFailing}}         # Your PR's CI is failing
+  changes_requested: {{.Priority.ChangesRequested}}  # Changes were requested on your PR
+  ready_to_merge: {{.Priority.ReadyToMerge}}     # Your PR is approved and CI is green
+  approvals_pending: {{.Priority.ApprovalsPending}}  # A teammate's PR has no approval yet
+  blocking: {{.Priority.Blocking}}           # Per PR stacked on top of this one
+  age_per_day: {{.Priority.AgePerDay}}         # Per day open, up to 30 days
+  small_size: {{.Priority.SmallSize}}         # For small PRs, down to 0 at 1000 changed lines
+
+# Saved queries for --query, by name
+# A name used as a word in --query stands for the saved query, so
+# "prt -q 'stale repo:api-*'" finds stale PRs in the api repos.
+# Example:
+#   stale: "age:>14d updated:>7d -draft"
+queries:
+{{- range $name, $query := .Queries}}
+  {{$name}}: {{printf "%q" $query}}
+{{- else}} {}
+{{- end}}
+
 # Hide PRs older than this many days (0 = no limit)
 # Useful for filtering out stale/long-running PRs
 max_pr_age_days: {{.MaxPRAgeDays}}
+
+# Maximum number of open PRs fetched per repository
+# Repositories with more open PRs are flagged as truncated in the output
+max_prs_per_repo: {{.MaxPRsPerRepo}}
+
+# Reuse fetched PRs for this many minutes (0 = always fetch)
+# Use --refresh to bypass the cache for one run. With backend "api", stale
+# entries are revalidated with a cheap conditional request instead of refetched.
+cache_ttl_minutes: {{.CacheTTLMinutes}}
+
+# Keep a snapshot of each scan for this many days (0 = don't record)
+# "prt diff" compares the current PRs against these snapshots.
+history_retention_days: {{.HistoryRetentionDays}}
+
+# Command run for each notification when prt runs with --notify (e.g. from
+# cron or watch mode). It runs via "sh -c" with the event as JSON on stdin and
+# PRT_NOTIFY_TYPE, PRT_NOTIFY_TITLE, PRT_NOTIFY_MESSAGE, PRT_NOTIFY_URL set.
+# Example: notify-send "$PRT_NOTIFY_TITLE" "$PRT_NOTIFY_MESSAGE"
+notify_command: {{printf "%q" .NotifyCommand}}
+
+# How PRT talks to GitHub: "gh" or "api"
+# "gh" uses the GitHub CLI; "api" calls the GitHub API directly using
+# GH_TOKEN, GITHUB_TOKEN, or the token stored by ` + "`gh auth login`" + `
+backend: "{{.Backend}}"
+
+# GitHub hosts whose repositories are scanned
+# Add GitHub Enterprise Server hosts here; repos with remotes on any other
+# host are reported as skipped. The first host is used to detect your username.
+github_hosts:
+{{- range .GitHubHosts}}
+  - "{{.}}"
+{{- else}}
+  - "github.com"
+{{- end}}
 `
 
 // GenerateConfigFile generates a well-commented YAML config file from the given config.
@@ -69,6 +69,7 @@ func TestGenerateConfigFile_CorrectValues(t *testing.T) {
 	cfg := &Config{
 		GitHubUsername: "myuser",
 		TeamMembers:    []string{"alice", "bob"},
+		MyTeams:        []string{"org/backend"},
 		SearchPaths:    []string{"/my/path"},
 		IncludeRepos:   []string{"prefix-*"},
 		ScanDepth:      5,
@@ -89,6 +90,7 @@ func TestGenerateConfigFile_CorrectValues(t *testing.T) {
 		`github_username: "myuser"`,
 		`- "alice"`,
 		`- "bob"`,
+		`- "org/backend"`,
 		`- "/my/path"`,
 		`- "prefix-*"`,
 		`scan_depth: 5`,
@@ -237,3 +239,62 @@ func TestGenerateConfigFile_SpecialCharacters(t *testing.T) {
 		t.Errorf("Config with special chars is not valid YAML: %v\nContent:\n%s", err, content)
 	}
 }
+
+func TestGenerateConfigFile_NotifyCommandRoundTrip(t *testing.T) {
+	command := `notify-send "$PRT_NOTIFY_TITLE" "$PRT_NOTIFY_MESSAGE" \ --urgency=low`
+	cfg := &Config{
+		GitHubUsername: "testuser",
+		SearchPaths:    []string{"~/code"},
+		ScanDepth:      3,
+		DefaultGroupBy: GroupByProject,
+		DefaultSort:    SortOldest,
+		NotifyCommand:  command,
+	}
+
+	content, err := GenerateConfigFile(cfg)
+	if err != nil {
+		t.Fatalf("GenerateConfigFile() error: %v", err)
+	}
+
+	var parsed Config
+	if err := yaml.Unmarshal([]byte(content), &parsed); err != nil {
+		t.Fatalf("Generated config is not valid YAML: %v\nContent:\n%s", err, content)
+	}
+	if parsed.NotifyCommand != command {
+		t.Errorf("notify_command = %q, want %q", parsed.NotifyCommand, command)
+	}
+}
+
+func TestGenerateConfigFile_QueriesRoundTrip(t *testing.T) {
+	for _, queries := range []map[string]string{
+		{},
+		{"stale": "age:>14d -draft", "urgent": `label:urgent title:"hot fix"`},
+	} {
+		cfg := &Config{
+			GitHubUsername: "testuser",
+			SearchPaths:    []string{"~/code"},
+			ScanDepth:      3,
+			DefaultGroupBy: GroupByProject,
+			DefaultSort:    SortOldest,
+			Queries:        queries,
+		}
+
+		content, err := GenerateConfigFile(cfg)
+		if err != nil {
+			t.Fatalf("GenerateConfigFile() error: %v", err)
+		}
+
+		var parsed Config
+		if err := yaml.Unmarshal([]byte(content), &parsed); err != nil {
+			t.Fatalf("Generated config is not valid YAML: %v\nContent:\n%s", err, content)
+		}
+		if len(parsed.Queries) != len(queries) {
+			t.Errorf("queries = %v, want %v", parsed.Queries, queries)
+		}
+		for name, query := range queries {
+			if parsed.Queries[name] != query {
+				t.Errorf("queries[%q] = %q, want %q", name, parsed.Queries[name], query)
+			}
+		}
+	}
+}
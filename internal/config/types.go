@@ -1,16 +1,45 @@
 // Package config handles configuration loading and validation for PRT.
 package config
 
+import (
+	"slices"
+	"strings"
+)
+
 // GroupBy constants define how PRs are grouped in the display.
 const (
 	GroupByProject = "project"
 	GroupByAuthor  = "author"
 )
 
-// Sort constants define the order of PRs in the display.
+// Sort keys define the order of PRs in the display. default_sort and
+// --sort take a comma-separated list of them, such as "ci,updated": later
+// keys break ties in earlier ones, and a leading "-" reverses a key.
+const (
+	SortOldest    = "oldest"    // Creation date, oldest first
+	SortNewest    = "newest"    // Creation date, newest first
+	SortUpdated   = "updated"   // Last activity, most recent first
+	SortSize      = "size"      // Changed lines, smallest first
+	SortApprovals = "approvals" // Approvals, most first
+	SortCI        = "ci"        // CI status: failing, pending, passing, none
+	SortRepo      = "repo"      // Repository, A-Z
+	SortAuthor    = "author"    // Author, A-Z
+	SortPriority  = "priority"  // Priority score (as in prt next), highest first
+)
+
+// SortKeys lists the valid sort keys.
+var SortKeys = []string{
+	SortOldest, SortNewest, SortUpdated, SortSize, SortApprovals,
+	SortCI, SortRepo, SortAuthor, SortPriority,
+}
+
+// DefaultGitHubHost is the hostname of public GitHub.
+const DefaultGitHubHost = "github.com"
+
+// Backend constants select how PRT talks to GitHub.
 const (
-	SortOldest = "oldest"
-	SortNewest = "newest"
+	BackendGH  = "gh"  // Shell out to the gh CLI
+	BackendAPI = "api" // Call the GitHub API directly using a token
 )
 
 // Config holds all configuration options for PRT.
@@ -21,6 +50,10 @@ type Config struct {
 	// Team - list of GitHub usernames for team highlighting
 	TeamMembers []string `yaml:"team_members" mapstructure:"team_members"`
 
+	// Teams the current user belongs to, as "org/team-slug"; review requests
+	// to these teams count as requests to the user (empty = auto-detect)
+	MyTeams []string `yaml:"my_teams" mapstructure:"my_teams"`
+
 	// Repository Discovery
 	SearchPaths  []string `yaml:"search_paths" mapstructure:"search_paths"`   // Where to look for repos
 	IncludeRepos []string `yaml:"include_repos" mapstructure:"include_repos"` // Glob patterns (empty = all)
@@ -36,8 +69,69 @@ type Config struct {
 	ShowIcons      bool   `yaml:"show_icons" mapstructure:"show_icons"`
 	ShowOtherPRs   bool   `yaml:"show_other_prs" mapstructure:"show_other_prs"` // Show "Other PRs" section
 
+	// Sections PRs are categorized into; the first matching section wins
+	Sections []Section `yaml:"sections" mapstructure:"sections"`
+
+	// Stacks
+	LinkDependsOn bool `yaml:"link_depends_on" mapstructure:"link_depends_on"` // PRs named in Depends-on trailers block the PR, across repos
+
+	// CI
+	CIIgnoreChecks []string `yaml:"ci_ignore_checks" mapstructure:"ci_ignore_checks"` // Glob patterns of check names left out of a PR's CI status
+
+	// Weights for ranking PRs in prt next
+	Priority PriorityWeights `yaml:"priority" mapstructure:"priority"`
+
+	// Saved --query expressions, by name
+	Queries map[string]string `yaml:"queries" mapstructure:"queries"`
+
 	// Filtering options
-	MaxPRAgeDays int `yaml:"max_pr_age_days" mapstructure:"max_pr_age_days"` // Hide PRs older than N days (0 = no limit)
+	MaxPRAgeDays  int `yaml:"max_pr_age_days" mapstructure:"max_pr_age_days"`   // Hide PRs older than N days (0 = no limit)
+	MaxPRsPerRepo int `yaml:"max_prs_per_repo" mapstructure:"max_prs_per_repo"` // Max open PRs fetched per repo
+
+	// Caching
+	CacheTTLMinutes int `yaml:"cache_ttl_minutes" mapstructure:"cache_ttl_minutes"` // Reuse fetched PRs for N minutes (0 = no cache)
+
+	// History
+	HistoryRetentionDays int `yaml:"history_retention_days" mapstructure:"history_retention_days"` // Keep scan snapshots for N days (0 = don't record)
+
+	// Notifications
+	NotifyCommand string `yaml:"notify_command" mapstructure:"notify_command"` // Shell command run for each event with --notify
+
+	// GitHub access
+	Backend     string   `yaml:"backend" mapstructure:"backend"`           // gh | api
+	GitHubHosts []string `yaml:"github_hosts" mapstructure:"github_hosts"` // Hosts whose remotes are scanned; first is used for user detection
+}
+
+// Hosts returns the configured GitHub hosts, normalized to lowercase
+// hostnames (with an optional port). An empty list means public GitHub only.
+func (c *Config) Hosts() []string {
+	var hosts []string
+	for _, h := range c.GitHubHosts {
+		if h = NormalizeHost(h); h != "" {
+			hosts = append(hosts, h)
+		}
+	}
+	if len(hosts) == 0 {
+		return []string{DefaultGitHubHost}
+	}
+	return hosts
+}
+
+// NormalizeHost trims whitespace, any URL scheme, and trailing slashes from
+// a configured host and lowercases it, so "https://GHE.corp.com/" becomes
+// "ghe.corp.com".
+func NormalizeHost(host string) string {
+	host = strings.TrimSpace(host)
+	if i := strings.Index(host, "://"); i >= 0 {
+		host = host[i+3:]
+	}
+	return strings.ToLower(strings.TrimRight(host, "/"))
+}
+
+// NormalizeTeam trims whitespace and a leading "@" from a team name and
+// lowercases it, so "@MyOrg/Backend" becomes "myorg/backend".
+func NormalizeTeam(team string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(team), "@"))
 }
 
 // IsValidGroupBy returns true if the given value is a valid GroupBy option.
@@ -45,7 +139,34 @@ func IsValidGroupBy(v string) bool {
 	return v == GroupByProject || v == GroupByAuthor
 }
 
-// IsValidSort returns true if the given value is a valid Sort option.
+// IsValidBackend returns true if the given value is a valid Backend option.
+func IsValidBackend(v string) bool {
+	return v == BackendGH || v == BackendAPI
+}
+
+// ParseSort splits a sort spec like "ci,-updated" into its keys, trimmed of
+// whitespace, keeping any leading "-".
+func ParseSort(v string) []string {
+	var keys []string
+	for _, key := range strings.Split(v, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// IsValidSort returns true if the given value is a comma-separated list of
+// sort keys, each optionally prefixed with "-".
 func IsValidSort(v string) bool {
-	return v == SortOldest || v == SortNewest
+	keys := ParseSort(v)
+	if len(keys) == 0 {
+		return false
+	}
+	for _, key := range keys {
+		if !slices.Contains(SortKeys, strings.TrimPrefix(key, "-")) {
+			return false
+		}
+	}
+	return true
 }
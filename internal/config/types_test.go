@@ -1,6 +1,8 @@
 package config
 
 import (
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -26,6 +28,17 @@ func TestIsValidGroupBy(t *testing.T) {
 	}
 }
 
+func TestParseSort(t *testing.T) {
+	got := ParseSort(" ci , -updated,,repo ")
+	want := []string{"ci", "-updated", "repo"}
+	if !slices.Equal(got, want) {
+		t.Errorf("ParseSort() = %q, want %q", got, want)
+	}
+	if got := ParseSort(""); len(got) != 0 {
+		t.Errorf("ParseSort(\"\") = %q, want none", got)
+	}
+}
+
 func TestIsValidSort(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -37,6 +50,13 @@ func TestIsValidSort(t *testing.T) {
 		{"invalid", "invalid", false},
 		{"empty", "", false},
 		{"uppercase", "OLDEST", false},
+		{"every key", strings.Join(SortKeys, ","), true},
+		{"multiple keys", "ci,updated", true},
+		{"spaces", "ci, updated", true},
+		{"reversed", "-size,repo", true},
+		{"one invalid", "ci,invalid", false},
+		{"only commas", ",,", false},
+		{"bare dash", "-", false},
 	}
 
 	for _, tt := range tests {
@@ -48,6 +68,56 @@ func TestIsValidSort(t *testing.T) {
 	}
 }
 
+func TestIsValidBackend(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  bool
+	}{
+		{"gh", BackendGH, true},
+		{"api", BackendAPI, true},
+		{"invalid", "rest", false},
+		{"empty", "", false},
+		{"uppercase", "API", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsValidBackend(tt.value); got != tt.want {
+				t.Errorf("IsValidBackend(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_Hosts(t *testing.T) {
+	tests := []struct {
+		name  string
+		hosts []string
+		want  []string
+	}{
+		{"nil defaults to github.com", nil, []string{"github.com"}},
+		{"blank entries ignored", []string{" ", ""}, []string{"github.com"}},
+		{"normalized", []string{"GitHub.com", "https://GHE.corp.com/"}, []string{"github.com", "ghe.corp.com"}},
+		{"port kept", []string{"ghe.io:8443"}, []string{"ghe.io:8443"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{GitHubHosts: tt.hosts}
+			got := cfg.Hosts()
+			if len(got) != len(tt.want) {
+				t.Fatalf("Hosts() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Hosts()[%d] = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
 func TestConstants(t *testing.T) {
 	// Verify constant values match expected strings
 	if GroupByProject != "project" {
@@ -95,3 +165,23 @@ func TestConfigStruct(t *testing.T) {
 		t.Error("ShowIcons should be true")
 	}
 }
+
+func TestNormalizeTeam(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"org/backend", "org/backend"},
+		{"@Org/Backend", "org/backend"},
+		{"  org/backend ", "org/backend"},
+		{"backend", "backend"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := NormalizeTeam(tt.input); got != tt.want {
+				t.Errorf("NormalizeTeam(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package display
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"prt/internal/history"
+	"prt/internal/models"
+)
+
+// categoryTitles are the dashboard section names used in diff reports.
+// Custom sections are shown by key.
+var categoryTitles = map[history.Category]string{
+	history.CategoryMyPRs:            "My PRs",
+	history.CategoryNeedsMyAttention: "Needs My Attention",
+	history.CategoryTeamPRs:          "Team PRs",
+	history.CategoryOtherPRs:         "Other PRs",
+	history.CategorySnoozed:          "Snoozed",
+}
+
+// categoryTitle returns the section name of a category.
+func categoryTitle(category history.Category) string {
+	if title, ok := categoryTitles[category]; ok {
+		return title
+	}
+	return string(category)
+}
+
+// RenderDiff renders a report of what changed between two scans, grouped
+// by kind of change.
+func RenderDiff(report *history.Report, showIcons bool) string {
+	var b strings.Builder
+
+	b.WriteString(HeaderStyle.Render(fmt.Sprintf("CHANGES SINCE %s", report.From.Local().Format("Mon Jan 2 15:04"))))
+	b.WriteString("\n\n")
+
+	if report.IsEmpty() {
+		b.WriteString(EmptyStyle.Render("  Nothing changed"))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	renderDiffGroup(&b, "Opened", len(report.Opened), func(i int) (*models.PR, string) {
+		pr := report.Opened[i]
+		return pr, AuthorStyle.Render("@" + pr.Author)
+	})
+	renderDiffGroup(&b, "Merged or closed", len(report.Closed), func(i int) (*models.PR, string) {
+		pr := report.Closed[i]
+		return pr, AuthorStyle.Render("@" + pr.Author)
+	})
+	renderDiffGroup(&b, "Moved", len(report.Moved), func(i int) (*models.PR, string) {
+		m := report.Moved[i]
+		return m.PR, categoryTitle(m.From) + MetaStyle.Render(" → ") + categoryTitle(m.To)
+	})
+	renderDiffGroup(&b, "CI changed", len(report.CIChanged), func(i int) (*models.PR, string) {
+		c := report.CIChanged[i]
+		return c.PR, ciLabel(c.From, showIcons) + MetaStyle.Render(" → ") + ciLabel(c.To, showIcons)
+	})
+	renderDiffGroup(&b, "New reviews", len(report.Reviewed), func(i int) (*models.PR, string) {
+		r := report.Reviewed[i]
+		var parts []string
+		for _, review := range r.Reviews {
+			parts = append(parts, AuthorStyle.Render("@"+review.Author)+" "+reviewVerb(review.State))
+		}
+		return r.PR, strings.Join(parts, MetaStyle.Render(", "))
+	})
+
+	return b.String()
+}
+
+// renderDiffGroup renders one kind of change: a heading with the count,
+// then each PR with its detail and URL. Empty groups are omitted.
+func renderDiffGroup(b *strings.Builder, title string, count int, item func(i int) (pr *models.PR, detail string)) {
+	if count == 0 {
+		return
+	}
+
+	b.WriteString(RepoStyle.Render(fmt.Sprintf("%s (%d)", title, count)))
+	b.WriteString("\n")
+	for i := 0; i < count; i++ {
+		pr, detail := item(i)
+		b.WriteString("  ")
+		b.WriteString(NumberStyle.Render(pr.Key()))
+		b.WriteString(" ")
+		b.WriteString(pr.Title)
+		b.WriteString("\n    ")
+		b.WriteString(detail)
+		b.WriteString("\n    ")
+		b.WriteString(URLStyle.Render(pr.URL))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+}
+
+// ciLabel renders a CI status as a word, e.g. in "pending → failing".
+func ciLabel(status models.CIStatus, showIcons bool) string {
+	if status == "" || status == models.CIStatusNone {
+		return DimStyle.Render("no CI")
+	}
+	if label := formatCIStatus(status, showIcons); label != "" {
+		return label + " " + string(status)
+	}
+	return string(status)
+}
+
+// reviewVerb describes a review state, e.g. "approved".
+func reviewVerb(state models.ReviewState) string {
+	switch state {
+	case models.ReviewStateApproved:
+		return ApprovedStyle.Render("approved")
+	case models.ReviewStateChangesRequested:
+		return ChangesRequestedStyle.Render("requested changes")
+	case models.ReviewStateDismissed:
+		return MetaStyle.Render("dismissed")
+	default:
+		return MetaStyle.Render("commented")
+	}
+}
+
+// RenderDiffJSON marshals a diff report to pretty-printed JSON.
+func RenderDiffJSON(report *history.Report) (string, error) {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal report: %w", err)
+	}
+	return string(data) + "\n", nil
+}
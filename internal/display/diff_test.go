@@ -0,0 +1,89 @@
+package display
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"prt/internal/history"
+	"prt/internal/models"
+)
+
+func TestRenderDiff(t *testing.T) {
+	DisableColors()
+	pr := func(n int) *models.PR {
+		return &models.PR{Number: n, Title: "Title " + string(rune('A'+n)), Author: "alice",
+			RepoOwner: "org", RepoName: "api", URL: "https://github.com/org/api/pull/1"}
+	}
+
+	report := &history.Report{
+		From:   time.Date(2025, 1, 2, 18, 0, 0, 0, time.Local),
+		Opened: []*models.PR{pr(1)},
+		Closed: []*models.PR{pr(2)},
+		Moved:  []history.Move{{PR: pr(3), From: history.CategoryNeedsMyAttention, To: history.CategoryTeamPRs}},
+		CIChanged: []history.CIChange{
+			{PR: pr(4), From: models.CIStatusPending, To: models.CIStatusFailing},
+		},
+		Reviewed: []history.ReviewChange{{PR: pr(5), Reviews: []models.Review{
+			{Author: "bob", State: models.ReviewStateApproved},
+			{Author: "carol", State: models.ReviewStateChangesRequested},
+		}}},
+	}
+
+	got := RenderDiff(report, false)
+
+	for _, want := range []string{
+		"CHANGES SINCE Thu Jan 2 18:00",
+		"Opened (1)", "org/api#1 Title B", "@alice",
+		"Merged or closed (1)", "org/api#2",
+		"Moved (1)", "Needs My Attention → Team PRs",
+		"CI changed (1)", "pending → CI ✗ failing",
+		"New reviews (1)", "@bob approved, @carol requested changes",
+		"https://github.com/org/api/pull/1",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("RenderDiff() missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestRenderDiff_Empty(t *testing.T) {
+	DisableColors()
+	got := RenderDiff(&history.Report{From: time.Now()}, false)
+
+	if !strings.Contains(got, "Nothing changed") {
+		t.Errorf("expected an empty report to say so, got:\n%s", got)
+	}
+	if strings.Contains(got, "Opened") {
+		t.Errorf("empty groups should be omitted, got:\n%s", got)
+	}
+}
+
+func TestRenderDiffJSON(t *testing.T) {
+	report := &history.Report{
+		Opened:    []*models.PR{{Number: 1, RepoOwner: "org", RepoName: "api"}},
+		Closed:    []*models.PR{},
+		Moved:     []history.Move{{PR: &models.PR{Number: 2}, From: history.CategoryMyPRs, To: history.CategoryOtherPRs}},
+		CIChanged: []history.CIChange{},
+		Reviewed:  []history.ReviewChange{},
+	}
+
+	out, err := RenderDiffJSON(report)
+	if err != nil {
+		t.Fatalf("RenderDiffJSON() error = %v", err)
+	}
+
+	var parsed map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	for _, key := range []string{"from", "to", "opened", "closed", "moved", "ci_changed", "reviewed"} {
+		if _, ok := parsed[key]; !ok {
+			t.Errorf("JSON output missing %q", key)
+		}
+	}
+	if !strings.Contains(string(parsed["moved"]), `"to": "other_prs"`) {
+		t.Errorf("moved = %s, want categories by JSON section key", parsed["moved"])
+	}
+}
@@ -0,0 +1,176 @@
+// Package display provides terminal rendering for PRT output.
+package display
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"prt/internal/config"
+	"prt/internal/models"
+)
+
+// RenderHTML renders the dashboard as an HTML fragment, for the page
+// served by prt serve: each section with its PRs grouped by repository (or
+// author), stacked PRs nested under their parents, and the scan summary.
+// Status items carry the CSS classes of their kind (e.g. "ci-failing").
+func RenderHTML(result *models.ScanResult, opts RenderOptions) (string, error) {
+	if result == nil {
+		return "", fmt.Errorf("cannot render nil result")
+	}
+
+	var b strings.Builder
+	for _, section := range result.DisplaySections() {
+		if section.Key == models.SectionOtherPRs && !opts.ShowOtherPRs {
+			continue
+		}
+		renderHTMLSection(&b, section, result.SectionPRs(section), result.Stacks, opts)
+	}
+
+	b.WriteString(`<footer class="summary">`)
+	b.WriteString(strings.ReplaceAll(html.EscapeString(footerSummary(result)), "\n", "<br>"))
+	b.WriteString("</footer>\n")
+	return b.String(), nil
+}
+
+// renderHTMLSection renders a section with its PRs grouped by repository
+// or author.
+func renderHTMLSection(b *strings.Builder, section *models.Section, prs []*models.PR, stacks map[string]*models.Stack, opts RenderOptions) {
+	fmt.Fprintf(b, `<section class="section" id="section-%s">`+"\n", html.EscapeString(section.Key))
+
+	title := SectionTitle(section)
+	if icon := SectionIcon(section); opts.ShowIcons && icon != "" {
+		title = icon + " " + title
+	}
+	fmt.Fprintf(b, `<h2>%s <span class="count">%d</span></h2>`+"\n", html.EscapeString(title), len(prs))
+
+	if len(prs) == 0 {
+		empty := "None"
+		if section.Key == models.SectionNeedsMyAttention {
+			empty = "None - you're all caught up!"
+		}
+		fmt.Fprintf(b, `<p class="empty">%s</p>`+"\n", html.EscapeString(empty))
+		b.WriteString("</section>\n")
+		return
+	}
+
+	byAuthor := opts.GroupBy == config.GroupByAuthor
+	groups, names := groupByRepo(prs), []string(nil)
+	if byAuthor {
+		groups = groupByAuthor(prs)
+		names = sortedAuthorNames(groups)
+	} else {
+		names = sortedRepoNames(groups)
+	}
+
+	for _, name := range names {
+		heading := name
+		if byAuthor {
+			heading = "@" + name
+		}
+		fmt.Fprintf(b, `<h3 class="group">%s</h3>`+"\n", html.EscapeString(heading))
+		b.WriteString(`<ul class="prs">` + "\n")
+		for _, node := range topLevelNodes(groups[name], stacks) {
+			renderHTMLNode(b, node, opts, byAuthor)
+		}
+		b.WriteString("</ul>\n")
+	}
+	b.WriteString("</section>\n")
+}
+
+// topLevelNodes returns the PRs of prs that aren't stacked on another PR,
+// in order: stack roots as their stack nodes, so their stacks can be
+// rendered below them, and the other PRs as nodes of their own.
+func topLevelNodes(prs []*models.PR, stacks map[string]*models.Stack) []*models.StackNode {
+	var nodes []*models.StackNode
+	for _, pr := range prs {
+		node := findStackNode(stacks[pr.RepoFullName()], pr)
+		switch {
+		case node == nil:
+			nodes = append(nodes, &models.StackNode{PR: pr})
+		case node.Parent == nil:
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// findStackNode returns pr's node in stack, or nil if it isn't stacked.
+func findStackNode(stack *models.Stack, pr *models.PR) *models.StackNode {
+	if stack == nil {
+		return nil
+	}
+	for _, node := range stack.AllNodes {
+		if node.PR != nil && node.PR.Number == pr.Number {
+			return node
+		}
+	}
+	return nil
+}
+
+// renderHTMLNode renders a PR as a list item, with the PRs stacked on it
+// in a nested list. Blocked PRs get the "blocked" class.
+func renderHTMLNode(b *strings.Builder, node *models.StackNode, opts RenderOptions, byAuthor bool) {
+	pr := node.PR
+	if pr == nil {
+		return
+	}
+
+	class := "pr"
+	if node.IsBlocked() {
+		class += " blocked"
+	}
+	if _, ok := opts.Highlights[pr.Key()]; ok {
+		class += " highlighted"
+	}
+	fmt.Fprintf(b, `<li class="%s" data-key="%s">`+"\n", class, html.EscapeString(pr.Key()))
+
+	// Number and title, linked to the PR
+	fmt.Fprintf(b, `<div class="title"><a href="%s">#%d %s</a>`,
+		html.EscapeString(pr.URL), pr.Number, html.EscapeString(pr.Title))
+	for _, label := range pr.Labels {
+		fmt.Fprintf(b, ` <span class="label">%s</span>`, html.EscapeString(label))
+	}
+	if node.IsOrphan {
+		orphan := "orphan"
+		if opts.ShowIcons {
+			orphan += " " + IconBlocked
+		}
+		fmt.Fprintf(b, ` <span class="orphan">(%s)</span>`, orphan)
+	}
+	if label, ok := opts.Highlights[pr.Key()]; ok {
+		fmt.Fprintf(b, ` <span class="highlight">● %s</span>`, html.EscapeString(label))
+	}
+	b.WriteString("</div>\n")
+
+	// Status
+	parts := statusParts(pr, opts.ShowIcons)
+	rendered := make([]string, len(parts))
+	for i, part := range parts {
+		rendered[i] = html.EscapeString(part.Text)
+		if part.Class != "" {
+			rendered[i] = fmt.Sprintf(`<span class="%s">%s</span>`, part.Class, rendered[i])
+		}
+		rendered[i] += html.EscapeString(part.Detail)
+	}
+	fmt.Fprintf(b, `<div class="status">%s</div>`+"\n", strings.Join(rendered, " · "))
+
+	// Branches, with the repo or author not shown by the group heading
+	if opts.ShowBranches {
+		owner := "@" + pr.Author
+		if byAuthor {
+			owner = pr.RepoFullName()
+		}
+		fmt.Fprintf(b, `<div class="branches">%s · %s → %s</div>`+"\n",
+			html.EscapeString(owner), html.EscapeString(pr.HeadBranch), html.EscapeString(pr.BaseBranch))
+	}
+
+	if len(node.Children) > 0 {
+		b.WriteString(`<ul class="prs">` + "\n")
+		for _, child := range node.Children {
+			renderHTMLNode(b, child, opts, byAuthor)
+		}
+		b.WriteString("</ul>\n")
+	}
+	b.WriteString("</li>\n")
+}
@@ -0,0 +1,100 @@
+package display
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"prt/internal/config"
+	"prt/internal/models"
+)
+
+func TestRenderHTML(t *testing.T) {
+	root := &models.PR{
+		Number: 1, Title: "Auth <core>", URL: "https://github.com/org/api/pull/1",
+		Author: "me", RepoOwner: "org", RepoName: "api", State: models.PRStateOpen,
+		HeadBranch: "auth", BaseBranch: "main", CreatedAt: time.Now().Add(-48 * time.Hour),
+		CIStatus: models.CIStatusFailing, Labels: []string{"security"},
+		Checks: []models.Check{{Name: "lint", Conclusion: "FAILURE"}},
+	}
+	child := &models.PR{
+		Number: 2, Title: "Auth tests", URL: "https://github.com/org/api/pull/2",
+		Author: "me", RepoOwner: "org", RepoName: "api", State: models.PRStateOpen,
+		HeadBranch: "auth-tests", BaseBranch: "auth", CreatedAt: time.Now(),
+		Additions: 10, Deletions: 2, ChangedFiles: 1,
+	}
+	rootNode := &models.StackNode{PR: root}
+	childNode := &models.StackNode{PR: child, Parent: rootNode, Depth: 1}
+	rootNode.Children = []*models.StackNode{childNode}
+
+	result := models.NewScanResult()
+	result.MyPRs = []*models.PR{root, child}
+	result.OtherPRs = []*models.PR{{Number: 9, Title: "Bump deps", RepoOwner: "org", RepoName: "web", Author: "bot"}}
+	result.Stacks = map[string]*models.Stack{
+		"org/api": {Roots: []*models.StackNode{rootNode}, AllNodes: []*models.StackNode{rootNode, childNode}},
+	}
+	result.TotalReposScanned = 2
+	result.TotalPRsFound = 3
+
+	output, err := RenderHTML(result, RenderOptions{ShowBranches: true, Highlights: map[string]string{"org/api#2": "new"}})
+	if err != nil {
+		t.Fatalf("RenderHTML() error = %v", err)
+	}
+
+	for _, want := range []string{
+		`<section class="section" id="section-my_prs">`,
+		`<h2>MY PRS <span class="count">2</span></h2>`,
+		`<h3 class="group">org/api</h3>`,
+		`<a href="https://github.com/org/api/pull/1">#1 Auth &lt;core&gt;</a> <span class="label">security</span>`,
+		`<span class="waiting-review">Waiting review</span> · Created 2d ago · <span class="ci-failing">CI ✗ lint</span>`,
+		`<div class="branches">@me · auth → main</div>`,
+		`<li class="pr blocked highlighted" data-key="org/api#2">`,
+		`<span class="highlight">● new</span>`,
+		`<span class="size">S</span> +10/-2`,
+		`<p class="empty">None - you&#39;re all caught up!</p>`,
+		`<footer class="summary">Scanned 2 repos · Found 3 PRs`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output should contain %q, got:\n%s", want, output)
+		}
+	}
+
+	// The stacked PR is nested in its parent's item, not listed on its own
+	if strings.Count(output, `data-key="org/api#2"`) != 1 {
+		t.Errorf("stacked PR should be rendered once, got:\n%s", output)
+	}
+	nested := output[strings.Index(output, `data-key="org/api#1"`):]
+	if !strings.HasPrefix(nested[strings.Index(nested, "<ul"):], `<ul class="prs">`+"\n"+`<li class="pr blocked highlighted" data-key="org/api#2">`) {
+		t.Errorf("stacked PR should be nested under its parent, got:\n%s", output)
+	}
+	if strings.Contains(output, "other_prs") {
+		t.Error("Other PRs should be left out unless ShowOtherPRs is set")
+	}
+}
+
+func TestRenderHTML_GroupByAuthor(t *testing.T) {
+	result := models.NewScanResult()
+	result.TeamPRs = []*models.PR{
+		{Number: 3, Title: "Fix", RepoOwner: "org", RepoName: "web", Author: "alice", HeadBranch: "fix", BaseBranch: "main"},
+	}
+
+	output, err := RenderHTML(result, RenderOptions{ShowIcons: true, ShowBranches: true, GroupBy: config.GroupByAuthor})
+	if err != nil {
+		t.Fatalf("RenderHTML() error = %v", err)
+	}
+	for _, want := range []string{
+		`<h2>` + IconTeam + ` TEAM PRS <span class="count">1</span></h2>`,
+		`<h3 class="group">@alice</h3>`,
+		`<div class="branches">org/web · fix → main</div>`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output should contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestRenderHTML_NilResult(t *testing.T) {
+	if _, err := RenderHTML(nil, RenderOptions{}); err == nil {
+		t.Error("RenderHTML(nil) should return an error")
+	}
+}
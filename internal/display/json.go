@@ -23,10 +23,19 @@ type jsonOutput struct {
 	TeamPRs          []*models.PR `json:"team_prs,omitempty"`
 	OtherPRs         []*models.PR `json:"other_prs,omitempty"`
 
+	// Sections from the sections config other than the four above
+	Sections []*models.Section `json:"sections,omitempty"`
+
+	// Snoozed and muted PRs, not counted in total_prs
+	SnoozedPRs []*models.PR `json:"snoozed_prs,omitempty"`
+
 	// Summary counts
 	TotalPRs    int     `json:"total_prs"`
 	Username    string  `json:"username"`
 	ScanSeconds float64 `json:"scan_seconds"`
+
+	// The --query the PRs were filtered by, if any
+	Query string `json:"query,omitempty"`
 }
 
 // RenderJSON marshals the ScanResult to pretty-printed JSON.
@@ -37,6 +46,7 @@ type jsonOutput struct {
 //	prt --json | jq '.my_prs | length'
 //	prt --json | jq '.needs_my_attention[].url'
 //	prt --json | jq '.total_prs'
+//	prt --json | jq '.sections[] | select(.key == "security") | .prs'
 func RenderJSON(result *models.ScanResult, opts JSONOptions) (string, error) {
 	if result == nil {
 		return "", fmt.Errorf("cannot render nil result")
@@ -59,8 +69,11 @@ func buildJSONOutput(result *models.ScanResult, opts JSONOptions) *jsonOutput {
 		MyPRs:            result.MyPRs,
 		NeedsMyAttention: result.NeedsMyAttention,
 		TeamPRs:          result.TeamPRs,
+		Sections:         result.CustomSections(),
+		SnoozedPRs:       result.SnoozedPRs,
 		Username:         result.Username,
 		ScanSeconds:      float64(result.ScanDuration) / float64(time.Second),
+		Query:            result.Query,
 	}
 
 	if opts.ShowOtherPRs {
@@ -72,6 +85,9 @@ func buildJSONOutput(result *models.ScanResult, opts JSONOptions) *jsonOutput {
 	if opts.ShowOtherPRs {
 		output.TotalPRs += len(output.OtherPRs)
 	}
+	for _, s := range output.Sections {
+		output.TotalPRs += len(s.PRs)
+	}
 
 	return output
 }
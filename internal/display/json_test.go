@@ -249,6 +249,41 @@ func TestRenderJSON_AllFieldsPresent(t *testing.T) {
 	}
 }
 
+func TestRenderJSON_PRFields(t *testing.T) {
+	result := models.NewScanResult()
+	result.MyPRs = []*models.PR{{
+		Number:            1,
+		Labels:            []string{"bug"},
+		Milestone:         "v1.2",
+		ChangedFiles:      4,
+		Mergeable:         models.MergeableConflicting,
+		MergeStateStatus:  models.MergeStateDirty,
+		ReviewDecision:    models.ReviewDecisionReviewRequired,
+		IsCrossRepository: true,
+		UpdatedAt:         time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	}}
+
+	output, err := RenderJSON(result, JSONOptions{})
+	if err != nil {
+		t.Fatalf("RenderJSON failed: %v", err)
+	}
+
+	for _, want := range []string{
+		`"labels": [`,
+		`"milestone": "v1.2"`,
+		`"changed_files": 4`,
+		`"mergeable": "CONFLICTING"`,
+		`"merge_state_status": "DIRTY"`,
+		`"review_decision": "REVIEW_REQUIRED"`,
+		`"is_cross_repository": true`,
+		`"updated_at": "2025-01-02T03:04:05Z"`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %s in JSON output", want)
+		}
+	}
+}
+
 func TestRenderJSON_WorksWithJQ(t *testing.T) {
 	// This test verifies the output structure is jq-friendly
 	result := models.NewScanResult()
@@ -338,3 +373,99 @@ func TestRenderJSON_ShowOtherPRs_False(t *testing.T) {
 		t.Errorf("Expected 0 OtherPRs with ShowOtherPRs=false, got %d", len(parsed.OtherPRs))
 	}
 }
+
+func TestRenderJSON_CustomSections(t *testing.T) {
+	result := models.NewScanResult()
+	result.MyPRs = []*models.PR{{Number: 1}}
+	result.Sections = []*models.Section{
+		{Key: models.SectionMyPRs, Name: "My PRs"},
+		{Key: "security", Name: "Security", PRs: []*models.PR{{Number: 2}, {Number: 3}}},
+		{Key: "dependabot", Name: "Dependabot", PRs: []*models.PR{}},
+	}
+
+	output, err := RenderJSON(result, JSONOptions{})
+	if err != nil {
+		t.Fatalf("RenderJSON failed: %v", err)
+	}
+
+	var parsed struct {
+		MyPRs    []*models.PR      `json:"my_prs"`
+		Sections []*models.Section `json:"sections"`
+		TotalPRs int               `json:"total_prs"`
+	}
+	if err := json.Unmarshal([]byte(output), &parsed); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+
+	if len(parsed.Sections) != 2 || parsed.Sections[0].Key != "security" || len(parsed.Sections[0].PRs) != 2 {
+		t.Errorf("sections = %+v, want security and dependabot only", parsed.Sections)
+	}
+	if parsed.Sections[1].PRs == nil {
+		t.Error("empty custom sections should have an empty prs array")
+	}
+	if parsed.TotalPRs != 3 {
+		t.Errorf("total_prs = %d, want 3", parsed.TotalPRs)
+	}
+}
+
+func TestRenderJSON_SnoozedPRs(t *testing.T) {
+	result := models.NewScanResult()
+	result.MyPRs = []*models.PR{{Number: 1, Pinned: true}}
+	result.SnoozedPRs = []*models.PR{{Number: 2, Snoozed: true}}
+
+	output, err := RenderJSON(result, JSONOptions{})
+	if err != nil {
+		t.Fatalf("RenderJSON failed: %v", err)
+	}
+
+	var parsed struct {
+		TotalPRs int `json:"total_prs"`
+		MyPRs    []struct {
+			Pinned bool `json:"pinned"`
+		} `json:"my_prs"`
+		SnoozedPRs []struct {
+			Number  int  `json:"number"`
+			Snoozed bool `json:"snoozed"`
+		} `json:"snoozed_prs"`
+	}
+	if err := json.Unmarshal([]byte(output), &parsed); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
+	}
+
+	if parsed.TotalPRs != 1 {
+		t.Errorf("Expected total_prs=1 (snoozed PRs are not counted), got %d", parsed.TotalPRs)
+	}
+	if len(parsed.MyPRs) != 1 || !parsed.MyPRs[0].Pinned {
+		t.Errorf("Expected my PR marked pinned, got %+v", parsed.MyPRs)
+	}
+	if len(parsed.SnoozedPRs) != 1 || parsed.SnoozedPRs[0].Number != 2 || !parsed.SnoozedPRs[0].Snoozed {
+		t.Errorf("Expected snoozed_prs with PR #2, got %+v", parsed.SnoozedPRs)
+	}
+
+	output, err = RenderJSON(models.NewScanResult(), JSONOptions{})
+	if err != nil {
+		t.Fatalf("RenderJSON failed: %v", err)
+	}
+	if strings.Contains(output, "snoozed_prs") {
+		t.Error("snoozed_prs should be omitted when nothing is snoozed")
+	}
+}
+
+func TestRenderJSON_Query(t *testing.T) {
+	result := models.NewScanResult()
+	result.Query = "author:alice"
+
+	output, err := RenderJSON(result, JSONOptions{})
+	if err != nil {
+		t.Fatalf("RenderJSON failed: %v", err)
+	}
+	if !strings.Contains(output, `"query": "author:alice"`) {
+		t.Errorf("Expected the query in the output, got:\n%s", output)
+	}
+
+	result.Query = ""
+	output, _ = RenderJSON(result, JSONOptions{})
+	if strings.Contains(output, `"query"`) {
+		t.Errorf("Expected no query field without a query, got:\n%s", output)
+	}
+}
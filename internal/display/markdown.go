@@ -0,0 +1,136 @@
+// Package display provides terminal rendering for PRT output.
+package display
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"prt/internal/config"
+	"prt/internal/models"
+)
+
+// RenderMarkdown renders the dashboard as Markdown, for pasting into chat
+// or a GitHub issue: a heading per section, PRs grouped under a heading per
+// repository (or author), each a list item with its linked title and its
+// status with emojis, and stacked PRs in nested lists under their parents.
+func RenderMarkdown(result *models.ScanResult, opts RenderOptions) (string, error) {
+	if result == nil {
+		return "", fmt.Errorf("cannot render nil result")
+	}
+
+	var sections []string
+	for _, section := range result.DisplaySections() {
+		if section.Key == models.SectionOtherPRs && !opts.ShowOtherPRs {
+			continue
+		}
+		sections = append(sections, renderMarkdownSection(section, result.SectionPRs(section), result.Stacks, opts))
+	}
+	return strings.Join(sections, "\n"), nil
+}
+
+// renderMarkdownSection renders a section with its PRs grouped by
+// repository or author.
+func renderMarkdownSection(section *models.Section, prs []*models.PR, stacks map[string]*models.Stack, opts RenderOptions) string {
+	var b strings.Builder
+
+	title := section.Name
+	if icon := SectionIcon(section); icon != "" {
+		title = icon + " " + title
+	}
+	b.WriteString("## " + markdownText(title) + "\n\n")
+
+	if len(prs) == 0 {
+		empty := "None"
+		if section.Key == models.SectionNeedsMyAttention {
+			empty = "None - you're all caught up!"
+		}
+		b.WriteString("_" + empty + "_\n")
+		return b.String()
+	}
+
+	byAuthor := opts.GroupBy == config.GroupByAuthor
+	groups, names := groupByRepo(prs), []string(nil)
+	if byAuthor {
+		groups = groupByAuthor(prs)
+		names = sortedAuthorNames(groups)
+	} else {
+		names = sortedRepoNames(groups)
+	}
+
+	for i, name := range names {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("### " + markdownText(name) + "\n\n")
+		for _, node := range topLevelNodes(groups[name], stacks) {
+			renderMarkdownNode(&b, node, "", byAuthor)
+		}
+	}
+	return b.String()
+}
+
+// renderMarkdownNode renders a PR as a list item at indent, followed by
+// the PRs stacked on it, indented one level further.
+func renderMarkdownNode(b *strings.Builder, node *models.StackNode, indent string, byAuthor bool) {
+	pr := node.PR
+	if pr == nil {
+		return
+	}
+
+	b.WriteString(indent + "- ")
+	fmt.Fprintf(b, "[#%d %s](%s)", pr.Number, markdownText(pr.Title), pr.URL)
+	// The repo or author not shown by the group heading, without an @ that
+	// would notify them
+	if byAuthor {
+		b.WriteString(" in " + markdownText(pr.RepoFullName()))
+	} else if pr.Author != "" {
+		b.WriteString(" by " + markdownText(pr.Author))
+	}
+	for _, label := range pr.Labels {
+		b.WriteString(" " + markdownCode(label))
+	}
+
+	var status []string
+	if node.IsOrphan {
+		status = append(status, IconBlocked+" Orphan")
+	} else if node.IsBlocked() {
+		status = append(status, IconBlocked+" Blocked")
+	}
+	for _, part := range statusParts(pr, true) {
+		status = append(status, markdownText(part.Text+part.Detail))
+	}
+	b.WriteString(" — " + strings.Join(status, " · ") + "\n")
+
+	for _, child := range node.Children {
+		renderMarkdownNode(b, child, indent+"  ", byAuthor)
+	}
+}
+
+// markdownSpecial matches the characters with a meaning in Markdown text.
+var markdownSpecial = regexp.MustCompile("[\\\\`*_\\[\\]<>|~]")
+
+// mention matches a GitHub user or team mention, e.g. @org/team.
+var mention = regexp.MustCompile(`@[\w-]+(/[\w-]+)?`)
+
+// markdownText escapes s for Markdown text. Mentions are put in code spans
+// so that pasting the output into GitHub or Slack doesn't notify anyone.
+func markdownText(s string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range mention.FindAllStringIndex(s, -1) {
+		b.WriteString(markdownSpecial.ReplaceAllString(s[last:m[0]], `\$0`))
+		b.WriteString(markdownCode(s[m[0]:m[1]]))
+		last = m[1]
+	}
+	b.WriteString(markdownSpecial.ReplaceAllString(s[last:], `\$0`))
+	return b.String()
+}
+
+// markdownCode returns s as a code span.
+func markdownCode(s string) string {
+	if strings.Contains(s, "`") {
+		return "`` " + s + " ``"
+	}
+	return "`" + s + "`"
+}
@@ -0,0 +1,96 @@
+package display
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"prt/internal/config"
+	"prt/internal/models"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	root := &models.PR{
+		Number: 1, Title: "Auth for @org/security_team", URL: "https://github.com/org/api/pull/1",
+		Author: "me", RepoOwner: "org", RepoName: "api", State: models.PRStateOpen,
+		CreatedAt: time.Now().Add(-48 * time.Hour), CIStatus: models.CIStatusFailing,
+		Labels: []string{"security"}, Checks: []models.Check{{Name: "lint", Conclusion: "FAILURE"}},
+	}
+	child := &models.PR{
+		Number: 2, Title: "Fix *all* the [tests]", URL: "https://github.com/org/api/pull/2",
+		Author: "me", RepoOwner: "org", RepoName: "api", State: models.PRStateOpen,
+		CreatedAt: time.Now(), Additions: 10, Deletions: 2, ChangedFiles: 1,
+	}
+	rootNode := &models.StackNode{PR: root}
+	childNode := &models.StackNode{PR: child, Parent: rootNode, Depth: 1}
+	rootNode.Children = []*models.StackNode{childNode}
+
+	result := models.NewScanResult()
+	result.MyPRs = []*models.PR{root, child}
+	result.OtherPRs = []*models.PR{{Number: 9, Title: "Bump deps", RepoOwner: "org", RepoName: "web", Author: "bot"}}
+	result.Stacks = map[string]*models.Stack{
+		"org/api": {Roots: []*models.StackNode{rootNode}, AllNodes: []*models.StackNode{rootNode, childNode}},
+	}
+
+	output, err := RenderMarkdown(result, RenderOptions{})
+	if err != nil {
+		t.Fatalf("RenderMarkdown() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"## 📋 My PRs\n\n### org/api\n\n",
+		"- [#1 Auth for `@org/security_team`](https://github.com/org/api/pull/1) by me `security` — ",
+		"CI ❌ lint\n",
+		"\n  - [#2 Fix \\*all\\* the \\[tests\\]](https://github.com/org/api/pull/2) by me — 🔒 Blocked · ",
+		"+10/-2\n",
+		"_None - you're all caught up!_\n",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output should contain %q, got:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "Bump deps") {
+		t.Errorf("Other PRs should be hidden unless ShowOtherPRs is set, got:\n%s", output)
+	}
+}
+
+func TestRenderMarkdown_GroupByAuthor(t *testing.T) {
+	result := models.NewScanResult()
+	result.TeamPRs = []*models.PR{
+		{Number: 3, Title: "Add cache", URL: "https://github.com/org/web/pull/3", Author: "alice", RepoOwner: "org", RepoName: "web"},
+	}
+
+	output, err := RenderMarkdown(result, RenderOptions{GroupBy: config.GroupByAuthor})
+	if err != nil {
+		t.Fatalf("RenderMarkdown() error = %v", err)
+	}
+	if want := "### alice\n\n- [#3 Add cache](https://github.com/org/web/pull/3) in org/web — "; !strings.Contains(output, want) {
+		t.Errorf("output should contain %q, got:\n%s", want, output)
+	}
+}
+
+func TestRenderMarkdown_Nil(t *testing.T) {
+	if _, err := RenderMarkdown(nil, RenderOptions{}); err == nil {
+		t.Error("RenderMarkdown(nil) should return an error")
+	}
+}
+
+func TestMarkdownText(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"plain title", "plain title"},
+		{"fix_the <bug>", `fix\_the \<bug\>`},
+		{"ping @alice-b", "ping `@alice-b`"},
+		{"for @org/team_a and a|b", "for `@org/team_a` and a\\|b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := markdownText(tt.in); got != tt.want {
+				t.Errorf("markdownText(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package display
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"prt/internal/priority"
+)
+
+// RenderNext renders the PRs to act on next, numbered in order, each with
+// the reason it ranks where it does.
+func RenderNext(items []priority.Item) string {
+	var b strings.Builder
+
+	b.WriteString(HeaderStyle.Render("NEXT UP"))
+	b.WriteString("\n\n")
+
+	if len(items) == 0 {
+		b.WriteString(EmptyStyle.Render("  Nothing needs your attention"))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	for i, item := range items {
+		pr := item.PR
+		b.WriteString(fmt.Sprintf("%2d. ", i+1))
+		b.WriteString(NumberStyle.Render(pr.Key()))
+		b.WriteString(" ")
+		b.WriteString(pr.Title)
+		b.WriteString(" ")
+		b.WriteString(AuthorStyle.Render("@" + pr.Author))
+		b.WriteString("\n    ")
+		b.WriteString(HighlightStyle.Render(item.Action))
+		if rest := strings.TrimPrefix(item.Reason, item.Action); rest != "" {
+			b.WriteString(MetaStyle.Render(rest))
+		}
+		b.WriteString("\n    ")
+		b.WriteString(URLStyle.Render(pr.URL))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// nextJSON is the JSON shape of prt next.
+type nextJSON struct {
+	Items []priority.Item `json:"items"`
+}
+
+// RenderNextJSON marshals the PRs to act on next to pretty-printed JSON.
+func RenderNextJSON(items []priority.Item) (string, error) {
+	if items == nil {
+		items = []priority.Item{}
+	}
+	data, err := json.MarshalIndent(nextJSON{Items: items}, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal next items: %w", err)
+	}
+	return string(data) + "\n", nil
+}
@@ -0,0 +1,79 @@
+package display
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"prt/internal/models"
+	"prt/internal/priority"
+)
+
+func TestRenderNext(t *testing.T) {
+	DisableColors()
+	items := []priority.Item{
+		{
+			PR: &models.PR{Number: 12, Title: "Add rate limiting", Author: "alice", RepoOwner: "org", RepoName: "api",
+				URL: "https://github.com/org/api/pull/12"},
+			Score:  62,
+			Action: "Review requested",
+			Reason: "Review requested · blocks 2 PRs · open 5d",
+		},
+		{
+			PR:     &models.PR{Number: 3, Title: "Fix login", Author: "me", RepoOwner: "org", RepoName: "web"},
+			Score:  30,
+			Action: "CI failing",
+			Reason: "CI failing",
+		},
+	}
+
+	got := RenderNext(items)
+
+	for _, want := range []string{
+		"NEXT UP",
+		" 1. org/api#12 Add rate limiting @alice",
+		"Review requested · blocks 2 PRs · open 5d",
+		"https://github.com/org/api/pull/12",
+		" 2. org/web#3 Fix login @me",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("RenderNext() missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestRenderNext_Empty(t *testing.T) {
+	DisableColors()
+	if got := RenderNext(nil); !strings.Contains(got, "Nothing needs your attention") {
+		t.Errorf("expected an empty list to say so, got:\n%s", got)
+	}
+}
+
+func TestRenderNextJSON(t *testing.T) {
+	out, err := RenderNextJSON(nil)
+	if err != nil {
+		t.Fatalf("RenderNextJSON() error = %v", err)
+	}
+	if !strings.Contains(out, `"items": []`) {
+		t.Errorf("empty output = %s, want an empty items list", out)
+	}
+
+	out, err = RenderNextJSON([]priority.Item{{PR: &models.PR{Number: 1}, Score: 40, Action: "Review requested", Reason: "Review requested"}})
+	if err != nil {
+		t.Fatalf("RenderNextJSON() error = %v", err)
+	}
+	var parsed struct {
+		Items []map[string]json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if len(parsed.Items) != 1 {
+		t.Fatalf("items = %d, want 1", len(parsed.Items))
+	}
+	for _, key := range []string{"pr", "score", "action", "reason"} {
+		if _, ok := parsed.Items[0][key]; !ok {
+			t.Errorf("item missing %q", key)
+		}
+	}
+}
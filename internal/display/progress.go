@@ -160,6 +160,10 @@ func (p *ProgressDisplay) Update(repo *models.Repository) {
 		if prCount == 0 {
 			line = DimStyle.Render(fmt.Sprintf("%s %s (0 PRs)",
 				successIcon, repo.Name))
+		} else if repo.Truncated {
+			// More open PRs exist than were fetched
+			line = WarningStyle.Render(fmt.Sprintf("%s %s (%d+ PRs, truncated)",
+				successIcon, repo.Name, prCount))
 		} else {
 			line = SuccessStyle.Render(fmt.Sprintf("%s %s (%d %s)",
 				successIcon, repo.Name, prCount, plural))
@@ -188,7 +192,11 @@ func (p *ProgressDisplay) Update(repo *models.Repository) {
 				errorIcon, repo.Name, errMsg))
 		}
 	case models.ScanStatusSkipped:
-		line = DimStyle.Render(fmt.Sprintf("- %s (skipped)", repo.Name))
+		if repo.ScanError != nil {
+			line = DimStyle.Render(fmt.Sprintf("- %s (skipped: %s)", repo.Name, repo.ScanError))
+		} else {
+			line = DimStyle.Render(fmt.Sprintf("- %s (skipped)", repo.Name))
+		}
 	}
 
 	p.results = append(p.results, line)
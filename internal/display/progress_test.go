@@ -177,6 +177,21 @@ func TestProgressDisplay_Update(t *testing.T) {
 		}
 	})
 
+	t.Run("truncated repo", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		p := NewProgressDisplay(1, WithWriter(buf), WithTTY(false))
+		p.Update(&models.Repository{
+			Name:       "mono",
+			ScanStatus: models.ScanStatusSuccess,
+			PRs:        make([]*models.PR, 200),
+			Truncated:  true,
+		})
+
+		if !strings.Contains(buf.String(), "200+ PRs, truncated") {
+			t.Errorf("output should flag truncation, got %q", buf.String())
+		}
+	})
+
 	t.Run("skipped status", func(t *testing.T) {
 		buf := &bytes.Buffer{}
 		p := NewProgressDisplay(1, WithWriter(buf))
@@ -3,9 +3,12 @@ package display
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"prt/internal/models"
+
+	"github.com/charmbracelet/lipgloss"
 )
 
 // PRRenderOptions configures how a single PR is rendered.
@@ -13,12 +16,15 @@ type PRRenderOptions struct {
 	ShowIcons               bool
 	ShowBranches            bool
 	IsBlocked               bool
-	ShowRepoInsteadOfAuthor bool // When true, show [repo] instead of @author (for author grouping mode)
+	IsOrphan                bool              // When true, the PR's parent in its stack was merged or closed
+	ShowRepoInsteadOfAuthor bool              // When true, show [repo] instead of @author (for author grouping mode)
+	Highlights              map[string]string // PR key -> change label, for PRs that changed since the last refresh
 }
 
 // RenderPR renders a single PR as a formatted row with tree prefix.
 // The prefix should be a tree character like TreeBranch or TreeLastBranch.
-// If isBlocked is true, the entire PR is rendered with dimmed styling.
+// If isBlocked is true, the entire PR is rendered with dimmed styling; if
+// isOrphan is, its title is followed by an orphan indicator.
 func RenderPR(pr *models.PR, prefix string, opts PRRenderOptions) string {
 	return RenderPRWithContinuation(pr, prefix, "", opts)
 }
@@ -41,6 +47,18 @@ func RenderPRWithContinuation(pr *models.PR, prefix string, continuationPrefix s
 		b.WriteString(" ")
 		b.WriteString(pr.Title)
 	}
+	if labels := formatLabels(pr.Labels); labels != "" {
+		b.WriteString(" ")
+		b.WriteString(labels)
+	}
+	if opts.IsOrphan {
+		b.WriteString(" ")
+		b.WriteString(RenderOrphanIndicator(opts.ShowIcons))
+	}
+	if label, ok := opts.Highlights[pr.Key()]; ok {
+		b.WriteString(" ")
+		b.WriteString(HighlightStyle.Render("● " + label))
+	}
 	b.WriteString("\n")
 
 	// Calculate indent for detail lines
@@ -85,97 +103,243 @@ func RenderPRWithContinuation(pr *models.PR, prefix string, continuationPrefix s
 	return b.String()
 }
 
-// formatStatusLine creates the status line showing state, age, CI, and approvals.
+// StatusLine renders the status details shown below a PR's title: state,
+// age, CI status, merge conflicts, size, and approvals.
+func StatusLine(pr *models.PR, showIcons bool) string {
+	return formatStatusLine(pr, showIcons)
+}
+
+// formatStatusLine creates the status line showing state, age, CI, merge
+// conflicts, size, and approvals.
 func formatStatusLine(pr *models.PR, showIcons bool) string {
-	var parts []string
+	parts := statusParts(pr, showIcons)
+	rendered := make([]string, len(parts))
+	for i, part := range parts {
+		rendered[i] = part.Text
+		if style, ok := statusStyles[part.Class]; ok {
+			rendered[i] = style.Render(part.Text)
+		}
+		rendered[i] += part.Detail
+	}
+	return MetaStyle.Render(strings.Join(rendered, " · "))
+}
+
+// statusPart is one item of a PR's status line, e.g. "CI ✗ build". Class
+// names the kind of status it shows, which picks its style (and its CSS
+// class in HTML); Detail follows Text unstyled.
+type statusPart struct {
+	Text   string
+	Class  string
+	Detail string
+}
+
+// Status part classes.
+const (
+	classDraft            = "draft"
+	classReReview         = "re-review"
+	classApproved         = "approved"
+	classChangesRequested = "changes-requested"
+	classWaitingReview    = "waiting-review"
+	classClosed           = "closed"
+	classCIPassing        = "ci-passing"
+	classCIFailing        = "ci-failing"
+	classCIPending        = "ci-pending"
+	classConflicts        = "conflicts"
+	classSize             = "size"
+)
+
+// statusStyles are the terminal styles of status part classes.
+var statusStyles = map[string]lipgloss.Style{
+	classDraft:            DraftStyle,
+	classReReview:         ReReviewStyle,
+	classApproved:         ApprovedStyle,
+	classChangesRequested: ChangesRequestedStyle,
+	classWaitingReview:    NeedsReviewStyle,
+	classClosed:           MetaStyle,
+	classCIPassing:        CIPassingStyle,
+	classCIFailing:        CIFailingStyle,
+	classCIPending:        CIPendingStyle,
+	classConflicts:        ConflictStyle,
+	classSize:             SizeStyle,
+}
+
+// statusParts returns the items of a PR's status line, unstyled: state,
+// age, CI status, merge conflicts, size, team review request, dependencies,
+// approvals, and the user's marks.
+func statusParts(pr *models.PR, showIcons bool) []statusPart {
+	var parts []statusPart
+	withIcon := func(icon, label string) string {
+		if showIcons {
+			return icon + " " + label
+		}
+		return label
+	}
 
 	// State
-	state := formatState(pr, showIcons)
-	if state != "" {
-		parts = append(parts, state)
+	if text, class := stateLabel(pr, showIcons); text != "" {
+		parts = append(parts, statusPart{Text: text, Class: class})
 	}
 
 	// Age
-	parts = append(parts, fmt.Sprintf("Created %s", pr.AgeString()))
+	parts = append(parts, statusPart{Text: fmt.Sprintf("Created %s", pr.AgeString())})
+
+	// CI Status, naming the checks that failed
+	if text, class := ciStatusLabel(pr.CIStatus, showIcons); text != "" {
+		if failing := pr.FailingChecks(); len(failing) > 0 {
+			text += " " + formatCheckNames(failing)
+		}
+		parts = append(parts, statusPart{Text: text, Class: class})
+	}
 
-	// CI Status
-	ci := formatCIStatus(pr.CIStatus, showIcons)
-	if ci != "" {
-		parts = append(parts, ci)
+	// Merge conflicts with the base branch
+	if pr.HasConflicts() {
+		parts = append(parts, statusPart{Text: withIcon(IconConflict, "Conflicts"), Class: classConflicts})
+	}
+
+	// Size, if known
+	if pr.ChangedLines() > 0 || pr.ChangedFiles > 0 {
+		parts = append(parts, statusPart{
+			Text:   string(pr.Size()),
+			Class:  classSize,
+			Detail: fmt.Sprintf(" +%d/-%d", pr.Additions, pr.Deletions),
+		})
+	}
+
+	// Review requested from one of my teams rather than me
+	if pr.RequestedTeam != "" {
+		parts = append(parts, statusPart{Text: "via @" + pr.RequestedTeam})
+	}
+
+	// PRs named in Depends-on trailers
+	if len(pr.DependsOn) > 0 {
+		parts = append(parts, statusPart{Text: "Depends on " + strings.Join(pr.DependsOnKeys(), ", ")})
 	}
 
 	// Approvals (if any)
-	approvals := countApprovals(pr.Reviews)
-	if approvals > 0 {
-		parts = append(parts, fmt.Sprintf("%d approval%s", approvals, pluralize(approvals)))
+	if approvals := countApprovals(pr.Reviews); approvals > 0 {
+		parts = append(parts, statusPart{Text: fmt.Sprintf("%d approval%s", approvals, pluralize(approvals))})
 	}
 
-	return MetaStyle.Render(strings.Join(parts, " · "))
+	// The user's marks
+	for _, mark := range []struct {
+		set         bool
+		icon, label string
+	}{
+		{pr.Pinned, IconPinned, "Pinned"},
+		{pr.Snoozed, IconSnoozed, "Snoozed"},
+		{pr.Muted, IconMuted, "Muted"},
+	} {
+		if mark.set {
+			parts = append(parts, statusPart{Text: withIcon(mark.icon, mark.label)})
+		}
+	}
+
+	return parts
 }
 
 // formatState returns a styled string representing the PR state.
 func formatState(pr *models.PR, showIcons bool) string {
+	text, class := stateLabel(pr, showIcons)
+	if style, ok := statusStyles[class]; ok {
+		return style.Render(text)
+	}
+	return text
+}
+
+// stateLabel returns the text and status class of the PR state.
+func stateLabel(pr *models.PR, showIcons bool) (string, string) {
+	label := func(icon, text, class string) (string, string) {
+		if showIcons && icon != "" {
+			return icon + " " + text, class
+		}
+		return text, class
+	}
+
 	switch pr.EffectiveState() {
 	case models.PRStateDraft:
-		if showIcons {
-			return DraftStyle.Render(IconDraft + " Draft")
-		}
-		return DraftStyle.Render("Draft")
+		return label(IconDraft, "Draft", classDraft)
 	case models.PRStateOpen:
+		// New commits since my review take precedence over the review state
+		if pr.NeedsReReview {
+			return label(IconReReview, "Re-review needed", classReReview)
+		}
+
 		// Check review state
-		reviewState := getReviewState(pr)
-		switch reviewState {
+		switch getReviewState(pr) {
 		case models.ReviewStateApproved:
-			if showIcons {
-				return ApprovedStyle.Render(IconApproved + " Approved")
-			}
-			return ApprovedStyle.Render("Approved")
+			return label(IconApproved, "Approved", classApproved)
 		case models.ReviewStateChangesRequested:
-			if showIcons {
-				return ChangesRequestedStyle.Render(IconChanges + " Changes requested")
-			}
-			return ChangesRequestedStyle.Render("Changes requested")
+			return label(IconChanges, "Changes requested", classChangesRequested)
 		default:
-			if showIcons {
-				return NeedsReviewStyle.Render(IconReview + " Waiting review")
-			}
-			return NeedsReviewStyle.Render("Waiting review")
+			return label(IconReview, "Waiting review", classWaitingReview)
 		}
 	case models.PRStateMerged:
-		if showIcons {
-			return ApprovedStyle.Render(IconMerged + " Merged")
-		}
-		return ApprovedStyle.Render("Merged")
+		return label(IconMerged, "Merged", classApproved)
 	case models.PRStateClosed:
-		return MetaStyle.Render("Closed")
+		return label("", "Closed", classClosed)
 	default:
-		return string(pr.State)
+		return string(pr.State), ""
 	}
 }
 
 // formatCIStatus returns a styled string representing the CI status.
 func formatCIStatus(status models.CIStatus, showIcons bool) string {
+	text, class := ciStatusLabel(status, showIcons)
+	if text == "" {
+		return ""
+	}
+	return statusStyles[class].Render(text)
+}
+
+// ciStatusLabel returns the text and status class of a CI status, or an
+// empty text if there is no CI.
+func ciStatusLabel(status models.CIStatus, showIcons bool) (string, string) {
 	switch status {
 	case models.CIStatusPassing:
 		if showIcons {
-			return CIPassingStyle.Render("CI " + IconCIPassing)
+			return "CI " + IconCIPassing, classCIPassing
 		}
-		return CIPassingStyle.Render("CI ✓")
+		return "CI ✓", classCIPassing
 	case models.CIStatusFailing:
 		if showIcons {
-			return CIFailingStyle.Render("CI " + IconCIFailing)
+			return "CI " + IconCIFailing, classCIFailing
 		}
-		return CIFailingStyle.Render("CI ✗")
+		return "CI ✗", classCIFailing
 	case models.CIStatusPending:
 		if showIcons {
-			return CIPendingStyle.Render("CI " + IconCIPending)
+			return "CI " + IconCIPending, classCIPending
 		}
-		return CIPendingStyle.Render("CI ...")
-	case models.CIStatusNone:
-		return ""
+		return "CI ...", classCIPending
 	default:
-		return ""
+		return "", ""
+	}
+}
+
+// maxCheckNames is how many failing checks the status line names before
+// summarizing the rest as "+N more".
+const maxCheckNames = 3
+
+// formatCheckNames lists the names of checks, e.g. "build, lint +2 more".
+func formatCheckNames(checks []models.Check) string {
+	names := make([]string, 0, maxCheckNames)
+	for _, c := range checks[:min(len(checks), maxCheckNames)] {
+		names = append(names, c.Name)
 	}
+	text := strings.Join(names, ", ")
+	if n := len(checks) - maxCheckNames; n > 0 {
+		text += fmt.Sprintf(" +%d more", n)
+	}
+	return text
+}
+
+// formatLabels returns the label names in brackets, e.g. "[bug] [ui]",
+// or an empty string if there are none.
+func formatLabels(labels []string) string {
+	parts := make([]string, len(labels))
+	for i, l := range labels {
+		parts[i] = LabelStyle.Render("[" + l + "]")
+	}
+	return strings.Join(parts, " ")
 }
 
 // countApprovals counts the number of approved reviews.
@@ -224,6 +388,13 @@ func RenderPRSimple(pr *models.PR, showIcons bool, showBranches bool) string {
 	})
 }
 
+// Output formats, as given to --format.
+const (
+	FormatText     = "text"
+	FormatJSON     = "json"
+	FormatMarkdown = "markdown"
+)
+
 // RenderOptions configures the output rendering behavior.
 type RenderOptions struct {
 	ShowIcons    bool   // Show emoji icons for sections and status
@@ -231,7 +402,12 @@ type RenderOptions struct {
 	ShowOtherPRs bool   // Show "Other PRs" section (external contributors, bots)
 	NoColor      bool   // Disable all color output
 	JSON         bool   // Output as JSON instead of styled text
+	Markdown     bool   // Output as Markdown instead of styled text
 	GroupBy      string // Group PRs by: "project" (default) or "author"
+
+	// Highlights marks PRs that changed since the previous refresh (watch
+	// mode), mapping PR key to a short label such as "new" or "CI changed".
+	Highlights map[string]string
 }
 
 // Render orchestrates the complete terminal output from a ScanResult.
@@ -248,6 +424,11 @@ func Render(result *models.ScanResult, opts RenderOptions) (string, error) {
 		})
 	}
 
+	// Handle Markdown mode
+	if opts.Markdown {
+		return RenderMarkdown(result, opts)
+	}
+
 	// Handle no-color mode
 	if opts.NoColor {
 		DisableColors()
@@ -260,48 +441,22 @@ func Render(result *models.ScanResult, opts RenderOptions) (string, error) {
 		ShowIcons:    opts.ShowIcons,
 		ShowBranches: opts.ShowBranches,
 		GroupBy:      opts.GroupBy,
+		Highlights:   opts.Highlights,
 	}
 
 	// Header
 	b.WriteString(renderHeader())
 	b.WriteString("\n\n")
 
-	// My PRs section
-	b.WriteString(RenderSection(
-		"MY PRS",
-		IconMyPRs,
-		result.MyPRs,
-		result.Stacks,
-		sectionOpts,
-	))
-	b.WriteString("\n")
-
-	// Needs My Attention section
-	b.WriteString(RenderSection(
-		"NEEDS MY ATTENTION",
-		IconNeedsAttention,
-		result.NeedsMyAttention,
-		result.Stacks,
-		sectionOpts,
-	))
-	b.WriteString("\n")
-
-	// Team PRs section
-	b.WriteString(RenderSection(
-		"TEAM PRS",
-		IconTeam,
-		result.TeamPRs,
-		result.Stacks,
-		sectionOpts,
-	))
-	b.WriteString("\n")
-
-	// Other PRs section (only if enabled)
-	if opts.ShowOtherPRs {
+	// Sections, in configured order; Other PRs only if enabled
+	for _, section := range result.DisplaySections() {
+		if section.Key == models.SectionOtherPRs && !opts.ShowOtherPRs {
+			continue
+		}
 		b.WriteString(RenderSection(
-			"OTHER PRS",
-			IconOther,
-			result.OtherPRs,
+			SectionTitle(section),
+			SectionIcon(section),
+			result.SectionPRs(section),
 			result.Stacks,
 			sectionOpts,
 		))
@@ -324,13 +479,93 @@ func renderHeader() string {
 // renderFooter renders the scan summary footer.
 func renderFooter(result *models.ScanResult) string {
 	separator := strings.Repeat("═", 65)
+	return SummaryStyle.Render(separator+"\n"+footerSummary(result)) + "\n"
+}
+
+// footerSummary returns the scan summary shown below the sections: counts,
+// duration, and what was left out or cut off, one note per line.
+func footerSummary(result *models.ScanResult) string {
+	found := fmt.Sprintf("%d", result.TotalPRsFound)
+	if len(truncatedRepos(result)) > 0 {
+		found += "+"
+	}
 
 	summary := fmt.Sprintf(
-		"Scanned %d repos · Found %d PRs · %s",
+		"Scanned %d repos · Found %s PRs · %s",
 		result.TotalReposScanned,
-		result.TotalPRsFound,
+		found,
 		result.ScanDurationString(),
 	)
 
-	return SummaryStyle.Render(separator+"\n"+summary) + "\n"
+	if result.Query != "" {
+		summary += fmt.Sprintf(" · %d matching %q", result.TotalPRs(), result.Query)
+	}
+
+	if cached := countCached(result); cached > 0 {
+		summary += fmt.Sprintf(" · %d cached (--refresh to refetch)", cached)
+	}
+
+	if snoozed := len(result.SnoozedPRs); snoozed > 0 {
+		summary += fmt.Sprintf(" · %d snoozed or muted (--show-snoozed to show)", snoozed)
+	}
+
+	if truncated := truncatedRepos(result); len(truncated) > 0 {
+		summary += fmt.Sprintf("\nOnly the first PRs are shown for %d repo%s over max_prs_per_repo: %s",
+			len(truncated), pluralize(len(truncated)), strings.Join(truncated, ", "))
+	}
+
+	if hosts, count := unconfiguredHosts(result); count > 0 {
+		summary += fmt.Sprintf("\nSkipped %d repo%s on hosts not in github_hosts: %s",
+			count, pluralize(count), strings.Join(hosts, ", "))
+	}
+
+	return summary
+}
+
+// countCached returns the number of repositories whose PRs were served
+// from the on-disk cache rather than fetched during this run.
+func countCached(result *models.ScanResult) int {
+	count := 0
+	for _, repos := range [][]*models.Repository{result.ReposWithPRs, result.ReposWithoutPRs} {
+		for _, repo := range repos {
+			if repo.Cached {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// truncatedRepos returns the full names of repositories whose PR list was
+// cut off by the per-repo cap, so the footer can say the counts are partial.
+func truncatedRepos(result *models.ScanResult) []string {
+	var names []string
+	for _, repo := range result.ReposWithPRs {
+		if repo.Truncated {
+			names = append(names, repo.FullName())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// unconfiguredHosts returns the distinct hosts of repositories that were
+// skipped because their remote is not on a configured GitHub host, along
+// with the number of such repositories.
+func unconfiguredHosts(result *models.ScanResult) ([]string, int) {
+	var hosts []string
+	seen := make(map[string]bool)
+	count := 0
+	for _, repo := range result.ReposWithErrors {
+		if repo.ScanStatus != models.ScanStatusSkipped || repo.Host == "" {
+			continue
+		}
+		count++
+		if !seen[repo.Host] {
+			seen[repo.Host] = true
+			hosts = append(hosts, repo.Host)
+		}
+	}
+	sort.Strings(hosts)
+	return hosts, count
 }
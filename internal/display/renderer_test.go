@@ -1,6 +1,7 @@
 package display
 
 import (
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -128,6 +129,119 @@ func TestRenderPR_BlockedStyle(t *testing.T) {
 	// The important thing is the code path is exercised without error
 }
 
+func TestRenderPR_RequestedTeam(t *testing.T) {
+	pr := &models.PR{
+		Number:                  42,
+		Title:                   "Team review",
+		State:                   models.PRStateOpen,
+		CreatedAt:               time.Now(),
+		IsReviewRequestedFromMe: true,
+		RequestedTeam:           "org/backend",
+	}
+
+	output := RenderPR(pr, TreeBranch, PRRenderOptions{})
+
+	if !strings.Contains(output, "via @org/backend") {
+		t.Errorf("Output should name the requested team, got:\n%s", output)
+	}
+}
+
+func TestRenderPR_DependsOn(t *testing.T) {
+	pr := &models.PR{
+		Number:    42,
+		Title:     "Use the new client",
+		State:     models.PRStateOpen,
+		CreatedAt: time.Now(),
+		RepoOwner: "org",
+		RepoName:  "web",
+		DependsOn: []string{"#41", "org/api#7"},
+	}
+
+	output := RenderPR(pr, TreeBranch, PRRenderOptions{})
+	if !strings.Contains(output, "Depends on org/web#41, org/api#7") {
+		t.Errorf("Output should list the PR's dependencies, got:\n%s", output)
+	}
+
+	pr.DependsOn = nil
+	if output := RenderPR(pr, TreeBranch, PRRenderOptions{}); strings.Contains(output, "Depends on") {
+		t.Errorf("Output should not mention dependencies when there are none, got:\n%s", output)
+	}
+}
+
+func TestRenderPR_Orphan(t *testing.T) {
+	pr := &models.PR{
+		Number:    42,
+		Title:     "Retarget me",
+		State:     models.PRStateOpen,
+		CreatedAt: time.Now(),
+	}
+
+	output := RenderPR(pr, TreeBranch, PRRenderOptions{IsOrphan: true})
+	if firstLine, _, _ := strings.Cut(output, "\n"); !strings.Contains(firstLine, "Retarget me (orphan)") {
+		t.Errorf("Title line should end with the orphan indicator, got:\n%s", output)
+	}
+
+	if output := RenderPR(pr, TreeBranch, PRRenderOptions{}); strings.Contains(output, "orphan") {
+		t.Errorf("Output should not mention orphans for a PR that isn't orphaned, got:\n%s", output)
+	}
+}
+
+func TestRenderPR_Marks(t *testing.T) {
+	pr := &models.PR{
+		Number:    7,
+		Title:     "Marked",
+		State:     models.PRStateOpen,
+		CreatedAt: time.Now(),
+		Pinned:    true,
+		Snoozed:   true,
+	}
+
+	output := RenderPR(pr, TreeBranch, PRRenderOptions{})
+	if !strings.Contains(output, "Pinned") || !strings.Contains(output, "Snoozed") {
+		t.Errorf("Output should show the PR's marks, got:\n%s", output)
+	}
+	if strings.Contains(output, "Muted") {
+		t.Errorf("Output should not show unset marks, got:\n%s", output)
+	}
+
+	output = RenderPR(pr, TreeBranch, PRRenderOptions{ShowIcons: true})
+	if !strings.Contains(output, IconPinned+" Pinned") {
+		t.Errorf("Output should show the pinned icon, got:\n%s", output)
+	}
+}
+
+func TestRenderPR_LabelsSizeAndConflicts(t *testing.T) {
+	pr := &models.PR{
+		Number:    8,
+		Title:     "Refactor auth",
+		State:     models.PRStateOpen,
+		CreatedAt: time.Now(),
+		Labels:    []string{"bug", "needs-docs"},
+		Additions: 120,
+		Deletions: 30,
+		Mergeable: models.MergeableConflicting,
+	}
+
+	output := RenderPR(pr, TreeBranch, PRRenderOptions{})
+	lines := strings.Split(output, "\n")
+
+	if !strings.Contains(lines[0], "Refactor auth [bug] [needs-docs]") {
+		t.Errorf("Title line should list labels, got %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "Conflicts") {
+		t.Errorf("Status line should mark merge conflicts, got %q", lines[1])
+	}
+	if !strings.Contains(lines[1], "M +120/-30") {
+		t.Errorf("Status line should show the size badge, got %q", lines[1])
+	}
+
+	plain := &models.PR{Number: 9, Title: "Plain", State: models.PRStateOpen, CreatedAt: time.Now()}
+	output = RenderPR(plain, TreeBranch, PRRenderOptions{})
+	if strings.Contains(output, "[") || strings.Contains(output, "Conflicts") || strings.Contains(output, "XS") {
+		t.Errorf("Output should omit labels, conflicts, and unknown size, got:\n%s", output)
+	}
+}
+
 func TestFormatCIStatus(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -160,6 +274,53 @@ func TestFormatCIStatus_None(t *testing.T) {
 	}
 }
 
+func TestFormatCheckNames(t *testing.T) {
+	checks := func(names ...string) []models.Check {
+		var cs []models.Check
+		for _, name := range names {
+			cs = append(cs, models.Check{Name: name})
+		}
+		return cs
+	}
+
+	tests := []struct {
+		checks []models.Check
+		want   string
+	}{
+		{checks("build"), "build"},
+		{checks("build", "lint", "test"), "build, lint, test"},
+		{checks("build", "lint", "test", "e2e", "docs"), "build, lint, test +2 more"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.want, func(t *testing.T) {
+			if got := formatCheckNames(tc.checks); got != tc.want {
+				t.Errorf("formatCheckNames() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFormatStatusLine_FailingChecks(t *testing.T) {
+	pr := &models.PR{
+		CreatedAt: time.Now(),
+		CIStatus:  models.CIStatusFailing,
+		Checks: []models.Check{
+			{Name: "build", State: "COMPLETED", Conclusion: "SUCCESS"},
+			{Name: "test", State: "COMPLETED", Conclusion: "FAILURE"},
+			{Name: "codecov", State: "FAILURE", Ignored: true},
+		},
+	}
+
+	output := formatStatusLine(pr, false)
+	if !strings.Contains(output, "CI ✗ test") {
+		t.Errorf("status line should name the failing check, got %q", output)
+	}
+	if strings.Contains(output, "build") || strings.Contains(output, "codecov") {
+		t.Errorf("status line should only name failing checks that aren't ignored, got %q", output)
+	}
+}
+
 func TestCountApprovals(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -299,6 +460,15 @@ func TestFormatState_AllStates(t *testing.T) {
 			},
 			contains: "Changes requested",
 		},
+		{
+			name: "Re-review needed",
+			pr: &models.PR{
+				State:         models.PRStateOpen,
+				Reviews:       []models.Review{{State: models.ReviewStateApproved}},
+				NeedsReReview: true,
+			},
+			contains: "Re-review needed",
+		},
 		{
 			name:     "Merged",
 			pr:       &models.PR{State: models.PRStateMerged},
@@ -458,6 +628,73 @@ func TestRender_WithPRs(t *testing.T) {
 	}
 }
 
+func TestRender_CustomSections(t *testing.T) {
+	DisableColors()
+	result := models.NewScanResult()
+	result.MyPRs = []*models.PR{{Number: 1, Title: "Mine", RepoName: "repo", State: models.PRStateOpen, CreatedAt: time.Now()}}
+	result.OtherPRs = []*models.PR{{Number: 3, Title: "Other", RepoName: "repo", State: models.PRStateOpen, CreatedAt: time.Now()}}
+	result.Sections = []*models.Section{
+		{Key: "security", Name: "Security", Icon: "S!", PRs: []*models.PR{
+			{Number: 2, Title: "Patch CVE", RepoName: "repo", State: models.PRStateOpen, CreatedAt: time.Now()},
+		}},
+		{Key: models.SectionMyPRs, Name: "My PRs"},
+		{Key: models.SectionOtherPRs, Name: "Other PRs"},
+	}
+
+	output, err := Render(result, RenderOptions{ShowIcons: true})
+	if err != nil {
+		t.Fatalf("Render should not error: %v", err)
+	}
+
+	security := strings.Index(output, "S! SECURITY")
+	mine := strings.Index(output, IconMyPRs+" MY PRS")
+	if security < 0 || mine < 0 || security > mine {
+		t.Errorf("expected SECURITY with its icon before MY PRS, got:\n%s", output)
+	}
+	if !strings.Contains(output, "Patch CVE") {
+		t.Error("Output should contain the custom section's PR")
+	}
+	if strings.Contains(output, "TEAM PRS") {
+		t.Error("Sections missing from the layout should not be rendered")
+	}
+	if strings.Contains(output, "OTHER PRS") {
+		t.Error("Other PRs should stay hidden unless ShowOtherPRs is set")
+	}
+}
+
+func TestRender_Highlights(t *testing.T) {
+	DisableColors()
+	result := models.NewScanResult()
+	result.MyPRs = []*models.PR{
+		{Number: 1, Title: "Changed", RepoOwner: "org", RepoName: "repo", State: models.PRStateOpen, CreatedAt: time.Now()},
+		{Number: 2, Title: "Unchanged", RepoOwner: "org", RepoName: "repo", State: models.PRStateOpen, CreatedAt: time.Now()},
+	}
+	// Stacked PRs are rendered through the stack tree, which must carry highlights too
+	child := &models.StackNode{PR: &models.PR{Number: 4, Title: "Child", RepoOwner: "org", RepoName: "stacked", State: models.PRStateOpen, CreatedAt: time.Now()}}
+	root := &models.StackNode{PR: &models.PR{Number: 3, Title: "Root", RepoOwner: "org", RepoName: "stacked", State: models.PRStateOpen, CreatedAt: time.Now()}, Children: []*models.StackNode{child}}
+	child.Parent = root
+	result.TeamPRs = []*models.PR{root.PR, child.PR}
+	result.Stacks["org/stacked"] = &models.Stack{Roots: []*models.StackNode{root}, AllNodes: []*models.StackNode{root, child}}
+
+	output, err := Render(result, RenderOptions{Highlights: map[string]string{
+		"org/repo#1":    "CI changed",
+		"org/stacked#4": "new",
+	}})
+	if err != nil {
+		t.Fatalf("Render should not error: %v", err)
+	}
+
+	if !strings.Contains(output, "#1 Changed ● CI changed") {
+		t.Errorf("expected PR #1 to be highlighted, got:\n%s", output)
+	}
+	if strings.Contains(output, "Unchanged ●") {
+		t.Error("expected PR #2 not to be highlighted")
+	}
+	if !strings.Contains(output, "● new") {
+		t.Errorf("expected stacked PR #4 to be highlighted, got:\n%s", output)
+	}
+}
+
 func TestRender_WithIcons(t *testing.T) {
 	result := models.NewScanResult()
 	result.MyPRs = []*models.PR{
@@ -615,6 +852,83 @@ func TestRenderFooter(t *testing.T) {
 	}
 }
 
+func TestRenderFooter_TruncatedRepos(t *testing.T) {
+	result := models.NewScanResult()
+	result.TotalPRsFound = 203
+	result.ReposWithPRs = []*models.Repository{
+		{Name: "mono", Owner: "org", Truncated: true},
+		{Name: "small", Owner: "org"},
+	}
+
+	footer := renderFooter(result)
+	if !strings.Contains(footer, "Found 203+ PRs") {
+		t.Errorf("Footer should mark the PR count as partial, got:\n%s", footer)
+	}
+	if !strings.Contains(footer, "1 repo over max_prs_per_repo: org/mono") {
+		t.Errorf("Footer should list truncated repos, got:\n%s", footer)
+	}
+}
+
+func TestRenderFooter_CachedRepos(t *testing.T) {
+	result := models.NewScanResult()
+	result.ReposWithPRs = []*models.Repository{{Name: "a", Cached: true}, {Name: "b"}}
+	result.ReposWithoutPRs = []*models.Repository{{Name: "c", Cached: true}}
+
+	footer := renderFooter(result)
+	if !strings.Contains(footer, "2 cached") {
+		t.Errorf("Footer should count cached repos, got:\n%s", footer)
+	}
+}
+
+func TestRenderFooter_UnconfiguredHosts(t *testing.T) {
+	result := models.NewScanResult()
+	result.ReposWithErrors = []*models.Repository{
+		{Name: "a", Host: "gitlab.com", ScanStatus: models.ScanStatusSkipped, ScanError: errors.New("skipped")},
+		{Name: "b", Host: "bitbucket.org", ScanStatus: models.ScanStatusSkipped, ScanError: errors.New("skipped")},
+		{Name: "c", Host: "gitlab.com", ScanStatus: models.ScanStatusSkipped, ScanError: errors.New("skipped")},
+		{Name: "d", Host: "github.com", ScanStatus: models.ScanStatusError, ScanError: errors.New("boom")},
+	}
+
+	footer := renderFooter(result)
+	if !strings.Contains(footer, "Skipped 3 repos on hosts not in github_hosts: bitbucket.org, gitlab.com") {
+		t.Errorf("Footer should report unconfigured hosts, got:\n%s", footer)
+	}
+
+	if footer := renderFooter(models.NewScanResult()); strings.Contains(footer, "Skipped") {
+		t.Error("Footer should not mention skipped repos when there are none")
+	}
+}
+
+func TestRenderFooter_SnoozedPRs(t *testing.T) {
+	result := models.NewScanResult()
+	result.SnoozedPRs = []*models.PR{{Number: 1}, {Number: 2}}
+
+	footer := renderFooter(result)
+	if !strings.Contains(footer, "2 snoozed or muted (--show-snoozed to show)") {
+		t.Errorf("Footer should count snoozed PRs, got:\n%s", footer)
+	}
+
+	if footer := renderFooter(models.NewScanResult()); strings.Contains(footer, "snoozed") {
+		t.Error("Footer should not mention snoozed PRs when there are none")
+	}
+}
+
+func TestRenderFooter_Query(t *testing.T) {
+	result := models.NewScanResult()
+	result.MyPRs = []*models.PR{{Number: 1}}
+	result.TeamPRs = []*models.PR{{Number: 2}}
+	result.Query = "label:urgent -draft"
+
+	footer := renderFooter(result)
+	if !strings.Contains(footer, `2 matching "label:urgent -draft"`) {
+		t.Errorf("Footer should count PRs matching the query, got:\n%s", footer)
+	}
+
+	if footer := renderFooter(models.NewScanResult()); strings.Contains(footer, "matching") {
+		t.Error("Footer should not mention a query when there is none")
+	}
+}
+
 func TestRenderOptions_Defaults(t *testing.T) {
 	opts := RenderOptions{}
 
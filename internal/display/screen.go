@@ -0,0 +1,129 @@
+package display
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/term"
+)
+
+// Terminal control sequences used to redraw the screen in place.
+const (
+	enterAltScreen = "\033[?1049h\033[?25l" // Switch to the alternate screen, hide cursor
+	exitAltScreen  = "\033[?25h\033[?1049l" // Show cursor, restore the main screen
+	cursorHome     = "\033[H"
+	clearToEOL     = "\033[K"
+	clearToEOS     = "\033[J"
+)
+
+// Screen redraws full-screen frames in place on a terminal, for watch mode.
+// Each frame overwrites the previous one line by line instead of clearing
+// the screen first, so refreshes don't flicker.
+type Screen struct {
+	writer io.Writer
+	height func() int // Terminal rows; 0 means unknown (no clipping)
+}
+
+// NewScreen creates a screen writing to w. When w is a terminal, frames are
+// clipped to its height so the view never scrolls.
+func NewScreen(w io.Writer) *Screen {
+	return &Screen{writer: w, height: terminalHeight(w)}
+}
+
+// terminalHeight returns a function reporting the current height of w, so
+// resizing the terminal between frames is picked up.
+func terminalHeight(w io.Writer) func() int {
+	return func() int {
+		f, ok := w.(*os.File)
+		if !ok {
+			return 0
+		}
+		_, height, err := term.GetSize(f.Fd())
+		if err != nil {
+			return 0
+		}
+		return height
+	}
+}
+
+// Start switches to the alternate screen and hides the cursor.
+func (s *Screen) Start() {
+	fmt.Fprint(s.writer, enterAltScreen)
+}
+
+// Close restores the main screen and the cursor.
+func (s *Screen) Close() {
+	fmt.Fprint(s.writer, exitAltScreen)
+}
+
+// Draw replaces the screen contents with body followed by a status line at
+// the bottom. The whole frame is written at once.
+func (s *Screen) Draw(body, status string) {
+	lines := strings.Split(strings.TrimRight(body, "\n"), "\n")
+
+	// Leave room for the status line; clip the rest with a note
+	if height := s.height(); height > 1 && len(lines) > height-1 {
+		hidden := len(lines) - (height - 2)
+		lines = append(lines[:height-2], DimStyle.Render(fmt.Sprintf("… %d more lines", hidden)))
+	}
+
+	var b strings.Builder
+	b.WriteString(cursorHome)
+	for _, line := range lines {
+		b.WriteString(line)
+		b.WriteString(clearToEOL)
+		b.WriteString("\n")
+	}
+	b.WriteString(status)
+	b.WriteString(clearToEOL)
+	b.WriteString(clearToEOS)
+
+	fmt.Fprint(s.writer, b.String())
+}
+
+// SetStatus replaces the status line drawn by the last Draw call.
+func (s *Screen) SetStatus(status string) {
+	fmt.Fprint(s.writer, "\r"+status+clearToEOL)
+}
+
+// WatchStatus describes the state of watch mode for its status line.
+type WatchStatus struct {
+	Interval   time.Duration
+	Updated    time.Time // When the results on screen were fetched
+	Changed    int       // PRs highlighted as changed since the previous refresh
+	RefreshErr error     // Set if the last refresh failed
+	Warning    error     // Set if recording history or sending notifications failed
+}
+
+// RenderWatchStatus renders the status line shown below the dashboard in
+// watch mode. After a failed refresh the dashboard still shows the results
+// from status.Updated, and the line says so.
+func RenderWatchStatus(status WatchStatus) string {
+	if status.RefreshErr != nil {
+		return ErrorStyle.Render(fmt.Sprintf("Refresh failed: %v (showing results from %s)",
+			status.RefreshErr, status.Updated.Format("15:04:05")))
+	}
+
+	parts := []string{
+		MetaStyle.Render(fmt.Sprintf("Refreshing every %s", status.Interval)),
+		MetaStyle.Render(fmt.Sprintf("Updated %s", status.Updated.Format("15:04:05"))),
+	}
+	if status.Changed > 0 {
+		parts = append(parts, HighlightStyle.Render(fmt.Sprintf("%d changed", status.Changed)))
+	}
+	if status.Warning != nil {
+		parts = append(parts, WarningStyle.Render(fmt.Sprintf("Warning: %v", status.Warning)))
+	}
+	parts = append(parts, MetaStyle.Render("Ctrl+C to quit"))
+
+	return strings.Join(parts, MetaStyle.Render(" · "))
+}
+
+// RenderWatchRefreshing renders the status line shown while a refresh is
+// in progress.
+func RenderWatchRefreshing() string {
+	return MetaStyle.Render("Refreshing...")
+}
@@ -0,0 +1,122 @@
+package display
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScreen_Draw(t *testing.T) {
+	buf := &bytes.Buffer{}
+	s := NewScreen(buf)
+
+	s.Draw("line one\nline two\n", "status")
+
+	want := cursorHome + "line one" + clearToEOL + "\n" + "line two" + clearToEOL + "\n" + "status" + clearToEOL + clearToEOS
+	if buf.String() != want {
+		t.Errorf("Draw() wrote %q, want %q", buf.String(), want)
+	}
+}
+
+func TestScreen_DrawClipsToHeight(t *testing.T) {
+	DisableColors()
+	buf := &bytes.Buffer{}
+	s := NewScreen(buf)
+	s.height = func() int { return 4 }
+
+	s.Draw("1\n2\n3\n4\n5\n6", "status")
+
+	out := buf.String()
+	if !strings.Contains(out, "1"+clearToEOL) || !strings.Contains(out, "2"+clearToEOL) {
+		t.Errorf("expected the first lines to be kept, got %q", out)
+	}
+	if strings.Contains(out, "3"+clearToEOL) {
+		t.Errorf("expected lines past the terminal height to be clipped, got %q", out)
+	}
+	if !strings.Contains(out, "… 4 more lines") {
+		t.Errorf("expected a note about clipped lines, got %q", out)
+	}
+	if got := strings.Count(out, "\n"); got != 3 {
+		t.Errorf("expected 3 newlines so the status line is the last row, got %d", got)
+	}
+}
+
+func TestScreen_StartClose(t *testing.T) {
+	buf := &bytes.Buffer{}
+	s := NewScreen(buf)
+
+	s.Start()
+	s.Close()
+
+	if buf.String() != enterAltScreen+exitAltScreen {
+		t.Errorf("Start/Close wrote %q", buf.String())
+	}
+}
+
+func TestScreen_SetStatus(t *testing.T) {
+	buf := &bytes.Buffer{}
+	NewScreen(buf).SetStatus("Refreshing...")
+
+	if buf.String() != "\rRefreshing..."+clearToEOL {
+		t.Errorf("SetStatus() wrote %q", buf.String())
+	}
+}
+
+func TestRenderWatchStatus(t *testing.T) {
+	DisableColors()
+	updated := time.Date(2025, 1, 1, 9, 30, 15, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		changed int
+		err     error
+		warning error
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "no changes",
+			want:    []string{"Refreshing every 1m0s", "Updated 09:30:15", "Ctrl+C to quit"},
+			notWant: []string{"changed"},
+		},
+		{
+			name:    "with changes",
+			changed: 3,
+			want:    []string{"3 changed"},
+		},
+		{
+			name: "failed refresh",
+			err:  errors.New("network down"),
+			want: []string{"Refresh failed: network down", "results from 09:30:15"},
+		},
+		{
+			name:    "warning",
+			warning: errors.New("notify_command failed: exit status 1"),
+			want:    []string{"Updated 09:30:15", "Warning: notify_command failed: exit status 1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RenderWatchStatus(WatchStatus{
+				Interval:   time.Minute,
+				Updated:    updated,
+				Changed:    tt.changed,
+				RefreshErr: tt.err,
+				Warning:    tt.warning,
+			})
+			for _, w := range tt.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("RenderWatchStatus() = %q, want it to contain %q", got, w)
+				}
+			}
+			for _, w := range tt.notWant {
+				if strings.Contains(got, w) {
+					t.Errorf("RenderWatchStatus() = %q, should not contain %q", got, w)
+				}
+			}
+		})
+	}
+}
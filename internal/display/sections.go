@@ -18,11 +18,34 @@ func RenderSectionHeader(icon, title string, showIcons bool) string {
 	return HeaderStyle.Render(title)
 }
 
+// sectionIcons are the icons of the built-in sections.
+var sectionIcons = map[string]string{
+	models.SectionMyPRs:            IconMyPRs,
+	models.SectionNeedsMyAttention: IconNeedsAttention,
+	models.SectionTeamPRs:          IconTeam,
+	models.SectionOtherPRs:         IconOther,
+}
+
+// SectionTitle returns the header title of a section, e.g. "MY PRS".
+func SectionTitle(section *models.Section) string {
+	return strings.ToUpper(section.Name)
+}
+
+// SectionIcon returns a section's configured icon, or the built-in icon for
+// built-in sections without one.
+func SectionIcon(section *models.Section) string {
+	if section.Icon != "" {
+		return section.Icon
+	}
+	return sectionIcons[section.Key]
+}
+
 // SectionOptions configures how a section is rendered.
 type SectionOptions struct {
 	ShowIcons    bool
 	ShowBranches bool
-	GroupBy      string // "project" (default) or "author"
+	GroupBy      string            // "project" (default) or "author"
+	Highlights   map[string]string // PR key -> change label (see RenderOptions)
 }
 
 // RenderSection renders a complete section with header and PRs grouped by repository or author.
@@ -69,7 +92,11 @@ func renderByProject(b *strings.Builder, prs []*models.PR, stacks map[string]*mo
 
 		// Render PRs
 		stack := stacks[repoName]
-		renderPRsInSection(b, repoPRs, stack, opts.ShowIcons, opts.ShowBranches, false)
+		renderPRsInSection(b, repoPRs, stack, PRRenderOptions{
+			ShowIcons:    opts.ShowIcons,
+			ShowBranches: opts.ShowBranches,
+			Highlights:   opts.Highlights,
+		})
 
 		b.WriteString("\n")
 	}
@@ -140,6 +167,7 @@ func renderPRsForAuthorGroup(b *strings.Builder, prs []*models.PR, stacks map[st
 		ShowIcons:               opts.ShowIcons,
 		ShowBranches:            opts.ShowBranches,
 		ShowRepoInsteadOfAuthor: true,
+		Highlights:              opts.Highlights,
 	}
 
 	// Render PRs in input order, interleaving stacks and non-stacked PRs
@@ -219,8 +247,7 @@ func countTopLevelItems(prs []*models.PR, stack *models.Stack) int {
 // renderPRsInSection renders a list of PRs within a section.
 // It uses stack tree structure for stacked PRs and flat rendering for non-stacked PRs.
 // PRs are rendered in their input order (preserving sort), interleaving stacks and non-stacked PRs.
-// When showRepoInsteadOfAuthor is true, the repo name is shown instead of author (for author grouping mode).
-func renderPRsInSection(b *strings.Builder, prs []*models.PR, stack *models.Stack, showIcons bool, showBranches bool, showRepoInsteadOfAuthor bool) {
+func renderPRsInSection(b *strings.Builder, prs []*models.PR, stack *models.Stack, prOpts PRRenderOptions) {
 	// Build maps for stack membership and root lookup
 	stackRootNodes := make(map[int]*models.StackNode) // PR number -> stack root node
 	stackChildPRs := make(map[int]bool)               // PR numbers that are children (not roots)
@@ -251,13 +278,6 @@ func renderPRsInSection(b *strings.Builder, prs []*models.PR, stack *models.Stac
 		}
 	}
 
-	// Create base render options
-	prOpts := PRRenderOptions{
-		ShowIcons:               showIcons,
-		ShowBranches:            showBranches,
-		ShowRepoInsteadOfAuthor: showRepoInsteadOfAuthor,
-	}
-
 	// Render PRs in input order, interleaving stacks and non-stacked PRs
 	itemIdx := 0
 	for _, pr := range prs {
@@ -328,12 +348,9 @@ func renderStackNodeInSection(b *strings.Builder, node *models.StackNode, prefix
 	}
 
 	// Render the PR with tree prefix and continuation for detail lines
-	nodeOpts := PRRenderOptions{
-		ShowIcons:               opts.ShowIcons,
-		ShowBranches:            opts.ShowBranches,
-		IsBlocked:               isBlocked,
-		ShowRepoInsteadOfAuthor: opts.ShowRepoInsteadOfAuthor,
-	}
+	nodeOpts := opts
+	nodeOpts.IsBlocked = isBlocked
+	nodeOpts.IsOrphan = node.IsOrphan
 	prOutput := RenderPRWithContinuation(node.PR, prefix+branch+" ", continuationPrefix, nodeOpts)
 	b.WriteString(prOutput)
 
@@ -378,14 +395,10 @@ func groupByRepo(prs []*models.PR) map[string][]*models.PR {
 	return result
 }
 
-// sortedRepoNames returns repository names sorted alphabetically.
+// sortedRepoNames returns repository names sorted alphabetically, those
+// with pinned PRs first.
 func sortedRepoNames(byRepo map[string][]*models.PR) []string {
-	names := make([]string, 0, len(byRepo))
-	for name := range byRepo {
-		names = append(names, name)
-	}
-	sort.Strings(names)
-	return names
+	return sortedGroupNames(byRepo)
 }
 
 // groupByAuthor groups PRs by their author username.
@@ -401,13 +414,29 @@ func groupByAuthor(prs []*models.PR) map[string][]*models.PR {
 	return result
 }
 
-// sortedAuthorNames returns author names sorted alphabetically.
+// sortedAuthorNames returns author names sorted alphabetically, those
+// with pinned PRs first.
 func sortedAuthorNames(byAuthor map[string][]*models.PR) []string {
-	names := make([]string, 0, len(byAuthor))
-	for name := range byAuthor {
+	return sortedGroupNames(byAuthor)
+}
+
+// sortedGroupNames returns the names of groups sorted alphabetically,
+// groups with pinned PRs first.
+func sortedGroupNames(groups map[string][]*models.PR) []string {
+	names := make([]string, 0, len(groups))
+	pinned := make(map[string]bool)
+	for name, prs := range groups {
 		names = append(names, name)
+		for _, pr := range prs {
+			pinned[name] = pinned[name] || pr.Pinned
+		}
 	}
-	sort.Strings(names)
+	sort.Slice(names, func(i, j int) bool {
+		if pinned[names[i]] != pinned[names[j]] {
+			return pinned[names[i]]
+		}
+		return names[i] < names[j]
+	})
 	return names
 }
 
@@ -471,6 +471,21 @@ func TestSortedAuthorNames(t *testing.T) {
 	}
 }
 
+// TestSortedAuthorNames_PinnedFirst tests that authors with pinned PRs come first
+func TestSortedAuthorNames_PinnedFirst(t *testing.T) {
+	byAuthor := map[string][]*models.PR{
+		"alice":   {{Number: 1}},
+		"zebra":   {{Number: 2}, {Number: 3, Pinned: true}},
+		"charlie": {{Number: 4}},
+	}
+
+	names := sortedAuthorNames(byAuthor)
+
+	if len(names) != 3 || names[0] != "zebra" || names[1] != "alice" || names[2] != "charlie" {
+		t.Errorf("Expected [zebra alice charlie], got %v", names)
+	}
+}
+
 // TestRenderSection_GroupByAuthor tests that the section renders correctly when grouped by author
 func TestRenderSection_GroupByAuthor(t *testing.T) {
 	prs := []*models.PR{
@@ -0,0 +1,137 @@
+package display
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"prt/internal/stacks"
+)
+
+// RenderStackPlan renders a stack as a tree, each PR with its status and
+// readiness to merge, followed by the PRs that can be merged now and the
+// follow-ups stacked on them.
+func RenderStackPlan(plan *stacks.MergePlan, showIcons bool) string {
+	var b strings.Builder
+
+	b.WriteString(HeaderStyle.Render("STACK"))
+	b.WriteString("\n\n")
+
+	// more[d] is whether the ancestor at depth d has siblings below it
+	var more []bool
+	for i, node := range plan.Nodes {
+		if node.Depth < len(more) {
+			more = more[:node.Depth]
+		}
+
+		var prefix string
+		for _, m := range more {
+			if m {
+				prefix += TreeStyle.Render(TreeVertical) + "   "
+			} else {
+				prefix += TreeIndent
+			}
+		}
+
+		isLast := !hasNextSibling(plan.Nodes, i)
+		branch, continuation := TreeBranch, prefix+TreeStyle.Render(TreeVertical)+"   "
+		if isLast {
+			branch, continuation = TreeLastBranch, prefix+TreeIndent
+		}
+		if i+1 < len(plan.Nodes) && plan.Nodes[i+1].Depth > node.Depth {
+			continuation += TreeStyle.Render(TreeVertical) + "   "
+		}
+		indent := continuation + "    "
+
+		pr := node.PR
+		b.WriteString(prefix + TreeStyle.Render(branch) + " ")
+		b.WriteString(NumberStyle.Render(fmt.Sprintf("#%d", pr.Number)))
+		b.WriteString(" " + pr.Title + "\n")
+		b.WriteString(indent + StatusLine(pr, showIcons) + "\n")
+		b.WriteString(indent + formatReadiness(node, showIcons) + "\n")
+		b.WriteString(indent + URLStyle.Render(pr.URL) + "\n")
+
+		more = append(more, !isLast)
+	}
+
+	b.WriteString("\n")
+	b.WriteString(HeaderStyle.Render("MERGE ORDER"))
+	b.WriteString("\n\n")
+
+	if len(plan.MergeOrder) == 0 {
+		b.WriteString(EmptyStyle.Render("  Nothing can be merged yet"))
+		b.WriteString("\n")
+		return b.String()
+	}
+	for i, pr := range plan.MergeOrder {
+		b.WriteString(fmt.Sprintf("%2d. ", i+1))
+		b.WriteString(NumberStyle.Render(pr.Key()))
+		b.WriteString(" " + pr.Title + "\n")
+	}
+
+	if len(plan.FollowUps) > 0 {
+		b.WriteString("\n")
+		b.WriteString(HeaderStyle.Render("FOLLOW-UPS"))
+		b.WriteString("\n\n")
+		b.WriteString(MetaStyle.Render("  Ready once the PRs they wait on are merged and they are retargeted; run prt stack again then"))
+		b.WriteString("\n")
+		for _, pr := range plan.FollowUps {
+			b.WriteString("  - ")
+			b.WriteString(NumberStyle.Render(pr.Key()))
+			b.WriteString(" " + pr.Title + "\n")
+		}
+	}
+	return b.String()
+}
+
+// hasNextSibling reports whether the node at i in a depth-first list of
+// nodes is followed by another child of the same parent.
+func hasNextSibling(nodes []stacks.PlanNode, i int) bool {
+	for _, n := range nodes[i+1:] {
+		if n.Depth < nodes[i].Depth {
+			return false
+		}
+		if n.Depth == nodes[i].Depth {
+			return true
+		}
+	}
+	return false
+}
+
+// formatReadiness describes whether a PR can be merged now, after the PRs
+// it waits on, or why not.
+func formatReadiness(node stacks.PlanNode, showIcons bool) string {
+	r := node.Readiness
+	switch {
+	case r.Ready:
+		text := "Ready to merge"
+		if showIcons {
+			text = IconApproved + " " + text
+		}
+		return ApprovedStyle.Render(text)
+	case r.FollowUp:
+		text := "Ready after its dependencies merge"
+		if node.Parent != 0 {
+			text = fmt.Sprintf("Ready after #%d merges", node.Parent)
+		}
+		if showIcons {
+			text = IconPause + " " + text
+		}
+		return CIPendingStyle.Render(text)
+	}
+
+	text := "Not ready: " + strings.Join(r.Reasons, " · ")
+	if showIcons {
+		text = IconBlocked + " " + text
+	}
+	return BlockedStyle.Render(text)
+}
+
+// RenderStackPlanJSON marshals a merge plan to pretty-printed JSON.
+func RenderStackPlanJSON(plan *stacks.MergePlan) (string, error) {
+	data, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal merge plan: %w", err)
+	}
+	return string(data) + "\n", nil
+}
@@ -0,0 +1,111 @@
+package display
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"prt/internal/models"
+	"prt/internal/stacks"
+)
+
+// testPlan returns the merge plan of a stack of three PRs where the root can
+// be merged, its first child follows it, and the second child fails CI.
+func testPlan() *stacks.MergePlan {
+	newPR := func(number int, title, head, base string) *models.PR {
+		return &models.PR{
+			Number: number, Title: title, RepoOwner: "org", RepoName: "api",
+			URL:        "https://github.com/org/api/pull/" + title,
+			HeadBranch: head, BaseBranch: base, State: models.PRStateOpen,
+			ReviewDecision: models.ReviewDecisionApproved,
+			CIStatus:       models.CIStatusPassing,
+			Mergeable:      models.MergeableMergeable,
+		}
+	}
+	root := newPR(1, "Auth", "auth", "main")
+	child := newPR(2, "Auth API", "auth-api", "auth")
+	failing := newPR(3, "Auth docs", "auth-docs", "auth")
+	failing.CIStatus = models.CIStatusFailing
+
+	stack := stacks.DetectStacks([]*models.PR{root, child, failing})
+	return stacks.PlanMerge(stacks.GetStackForPR(stack, 1))
+}
+
+func TestRenderStackPlan(t *testing.T) {
+	DisableColors()
+	got := RenderStackPlan(testPlan(), false)
+
+	for _, want := range []string{
+		"STACK",
+		"└── #1 Auth\n",
+		"    ├── #2 Auth API\n",
+		"    └── #3 Auth docs\n",
+		"Ready to merge\n",
+		"Ready after #1 merges\n",
+		"Not ready: CI failing · Waits on org/api#1\n",
+		"MERGE ORDER",
+		" 1. org/api#1 Auth\n",
+		"FOLLOW-UPS",
+		"  - org/api#2 Auth API\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("RenderStackPlan() missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, " 2. ") || strings.Contains(got, "org/api#3 Auth docs") {
+		t.Errorf("RenderStackPlan() should only list #1 to merge now and #2 as a follow-up:\n%s", got)
+	}
+
+	// #2's details continue the tree line down to its sibling #3
+	if !strings.Contains(got, "    │       Ready after #1 merges") {
+		t.Errorf("RenderStackPlan() should continue the tree next to #2's details:\n%s", got)
+	}
+}
+
+func TestRenderStackPlan_NothingReady(t *testing.T) {
+	DisableColors()
+	plan := stacks.PlanMerge(&models.StackNode{PR: &models.PR{Number: 1, Title: "WIP", IsDraft: true}})
+
+	got := RenderStackPlan(plan, true)
+	if !strings.Contains(got, "Draft") || !strings.Contains(got, "Nothing can be merged yet") {
+		t.Errorf("RenderStackPlan() should explain that nothing is ready:\n%s", got)
+	}
+}
+
+func TestRenderStackPlanJSON(t *testing.T) {
+	out, err := RenderStackPlanJSON(testPlan())
+	if err != nil {
+		t.Fatalf("RenderStackPlanJSON() error = %v", err)
+	}
+
+	var decoded struct {
+		Nodes []struct {
+			PR        struct{ Number int } `json:"pr"`
+			Depth     int                  `json:"depth"`
+			Parent    int                  `json:"parent"`
+			Readiness struct {
+				Ready    bool     `json:"ready"`
+				FollowUp bool     `json:"follow_up"`
+				Blocked  bool     `json:"blocked"`
+				Reasons  []string `json:"reasons"`
+			} `json:"readiness"`
+		} `json:"nodes"`
+		MergeOrder []struct{ Number int } `json:"merge_order"`
+		FollowUps  []struct{ Number int } `json:"follow_ups"`
+	}
+	if err := json.Unmarshal([]byte(out), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+
+	if len(decoded.Nodes) != 3 || len(decoded.MergeOrder) != 1 || decoded.MergeOrder[0].Number != 1 ||
+		len(decoded.FollowUps) != 1 || decoded.FollowUps[0].Number != 2 {
+		t.Fatalf("decoded = %+v, want 3 nodes, #1 in merge order, and #2 as a follow-up", decoded)
+	}
+	child := decoded.Nodes[1]
+	if child.PR.Number != 2 || child.Depth != 1 || child.Parent != 1 || child.Readiness.Ready || !child.Readiness.FollowUp || !child.Readiness.Blocked {
+		t.Errorf("#2 = %+v, want a follow-up of its parent #1", child)
+	}
+	if r := decoded.Nodes[2].Readiness; r.Ready || len(r.Reasons) != 2 || r.Reasons[0] != "CI failing" {
+		t.Errorf("#3 readiness = %+v, want not ready because of CI", r)
+	}
+}
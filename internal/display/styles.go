@@ -39,6 +39,11 @@ var (
 	ChangesRequestedStyle = lipgloss.NewStyle().
 				Foreground(lipgloss.Color("214")) // Orange
 
+	// ReReviewStyle renders PRs updated since my review
+	ReReviewStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("205")) // Pink/magenta
+
 	// BlockedStyle renders blocked PRs (stacked PRs waiting on parent)
 	BlockedStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("244")). // Gray
@@ -56,6 +61,20 @@ var (
 	CIPendingStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("226")) // Yellow
 
+	// ConflictStyle renders the merge conflict marker
+	ConflictStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("196")) // Red
+
+	// SizeStyle renders the PR size badge (XS to XL)
+	SizeStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("244")) // Gray
+
+	// LabelStyle renders PR labels
+	LabelStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("141")) // Light purple
+
 	// URLStyle renders clickable URLs
 	URLStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("39")). // Blue
@@ -97,6 +116,12 @@ var (
 	BranchStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("141")) // Light purple
 
+	// HighlightStyle renders change markers on PRs that changed since the
+	// previous refresh in watch mode
+	HighlightStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("205")) // Pink/magenta
+
 	// SummaryStyle renders the footer summary line
 	SummaryStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("244")).
@@ -117,8 +142,13 @@ const (
 	IconMerged   = "\U0001F7E3" // Purple circle
 	IconApproved = "\u2705"     // Check mark
 	IconChanges  = "\U0001F504" // Arrows counterclockwise
+	IconReReview = "\U0001F501" // Repeat
 	IconReview   = "\U0001F440" // Eyes
 	IconBlocked  = "\U0001F512" // Lock
+	IconPinned   = "\U0001F4CC" // Pushpin
+	IconSnoozed  = "\U0001F4A4" // Zzz
+	IconMuted    = "\U0001F507" // Muted speaker
+	IconConflict = "\U0001F4A5" // Collision
 
 	// CI status icons
 	IconCIPassing = "\u2705" // Check mark
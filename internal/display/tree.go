@@ -76,6 +76,7 @@ func renderNode(b *strings.Builder, node *models.StackNode, prefix string, isLas
 		ShowIcons:    showIcons,
 		ShowBranches: showBranches,
 		IsBlocked:    isBlocked,
+		IsOrphan:     node.IsOrphan,
 	}
 	prOutput := RenderPRWithContinuation(node.PR, prefix+styledBranch+" ", continuationPrefix, opts)
 	b.WriteString(prOutput)
@@ -96,7 +97,8 @@ func renderNode(b *strings.Builder, node *models.StackNode, prefix string, isLas
 }
 
 // RenderOrphanIndicator returns a styled indicator for orphan PRs.
-// Orphan PRs are those whose parent branch was merged but the PR still targets it.
+// Orphan PRs are those whose parent was merged or closed but the PR still
+// targets its branch (see stacks.MarkOrphans).
 func RenderOrphanIndicator(showIcons bool) string {
 	if showIcons {
 		return MetaStyle.Render("(orphan " + IconBlocked + ")")
@@ -370,6 +370,9 @@ func TestRenderStackTree_OrphanPR(t *testing.T) {
 	if !strings.Contains(result, "#101") {
 		t.Error("expected orphan child in output")
 	}
+	if !strings.Contains(result, "(orphan)") || strings.Count(result, "(orphan)") != 1 {
+		t.Errorf("expected one orphan indicator, for the child, got:\n%s", result)
+	}
 }
 
 func TestRenderStackTree_ComplexTree(t *testing.T) {
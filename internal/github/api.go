@@ -0,0 +1,276 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"prt/internal/models"
+)
+
+// DefaultAPIURL is the REST API base URL of public GitHub.
+const DefaultAPIURL = "https://api.github.com"
+
+// apiTimeout bounds a single HTTP request to the GitHub API.
+const apiTimeout = 30 * time.Second
+
+// apiClient implements Client by talking to the GitHub API over HTTP.
+// It needs no gh binary, only a token (see ResolveToken). Requests are routed
+// to the API of the host recorded on each repository.
+type apiClient struct {
+	// host is used for auth checks and user lookups (empty means github.com)
+	host string
+	// maxPRs caps the number of PRs fetched per repository
+	maxPRs int
+	// fetchBody requests PR descriptions too (see WithPRBody)
+	fetchBody bool
+	// endpoints resolves the API URLs of a host
+	endpoints func(host string) apiEndpoints
+	// resolveToken finds the token for a host
+	resolveToken func(host string) (string, error)
+	httpClient   *http.Client
+	retryer      *Retryer
+
+	mu     sync.Mutex
+	tokens map[string]hostToken // resolved tokens by host
+}
+
+// apiEndpoints holds the REST and GraphQL base URLs of one GitHub host.
+type apiEndpoints struct {
+	rest    string // e.g. https://api.github.com
+	graphql string // e.g. https://api.github.com/graphql
+}
+
+// hostToken caches the outcome of resolving a host's token.
+type hostToken struct {
+	token string
+	err   error
+}
+
+// NewAPIClient creates a GitHub API client.
+// Without options it uses github.com, the default retry config, and
+// DefaultMaxPRs. Tokens are resolved once per host, on first use; a
+// missing token is reported by Check (or per repository for other hosts).
+func NewAPIClient(opts ...Option) Client {
+	o := newClientOptions(opts)
+	return &apiClient{
+		host:         o.host,
+		maxPRs:       o.maxPRs,
+		fetchBody:    o.fetchBody,
+		endpoints:    endpointsForHost,
+		resolveToken: ResolveToken,
+		httpClient:   &http.Client{Timeout: apiTimeout},
+		retryer:      NewRetryer(o.retry),
+	}
+}
+
+// NewAPIClientWithConfig creates a GitHub API client with custom retry config.
+func NewAPIClientWithConfig(retryConfig RetryConfig) Client {
+	return NewAPIClient(WithRetryConfig(retryConfig))
+}
+
+// endpointsForHost returns the API URLs of host. Public GitHub serves its
+// API from api.github.com; GitHub Enterprise Server serves it under /api.
+func endpointsForHost(host string) apiEndpoints {
+	if host == "" || host == DefaultHost {
+		return apiEndpoints{rest: DefaultAPIURL, graphql: DefaultAPIURL + "/graphql"}
+	}
+	base := "https://" + host + "/api"
+	return apiEndpoints{rest: base + "/v3", graphql: base + "/graphql"}
+}
+
+// token returns the (cached) token for host.
+func (c *apiClient) token(host string) (string, error) {
+	if host == "" {
+		host = DefaultHost
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if t, ok := c.tokens[host]; ok {
+		return t.token, t.err
+	}
+	token, err := c.resolveToken(host)
+	if c.tokens == nil {
+		c.tokens = make(map[string]hostToken)
+	}
+	c.tokens[host] = hostToken{token: token, err: err}
+	return token, err
+}
+
+// Check verifies that a token is available and accepted by the API.
+func (c *apiClient) Check() error {
+	_, err := c.GetCurrentUser()
+	return err
+}
+
+// GetCurrentUser returns the login of the user the token belongs to.
+func (c *apiClient) GetCurrentUser() (string, error) {
+	token, err := c.token(c.host)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := c.do(http.MethodGet, c.endpoints(c.host).rest+"/user", token, nil, "")
+	if err != nil {
+		return "", err
+	}
+
+	var user ghUser
+	if err := json.Unmarshal(body, &user); err != nil {
+		return "", fmt.Errorf("failed to get current user: %w", err)
+	}
+	if user.Login == "" {
+		return "", fmt.Errorf("empty username returned from GitHub API")
+	}
+
+	return user.Login, nil
+}
+
+// CheckAndGetUser verifies authentication and returns the current user.
+// A single /user request covers both, so there is nothing to parallelize.
+func (c *apiClient) CheckAndGetUser() (string, error) {
+	return c.GetCurrentUser()
+}
+
+// ListPRs fetches open pull requests for a single repository.
+func (c *apiClient) ListPRs(repo *models.Repository) ([]*models.PR, bool, error) {
+	results := c.ListPRsBatch([]*models.Repository{repo})
+	return results[0].PRs, results[0].Truncated, results[0].Err
+}
+
+// ListPRsBatch fetches open pull requests for several repositories with a
+// single GraphQL request per page. Uses retry logic for transient failures
+// of a whole request; repository-specific failures are returned per repo.
+// All repos must be on the same host (see BatchClient).
+func (c *apiClient) ListPRsBatch(repos []*models.Repository) []BatchResult {
+	if len(repos) == 0 {
+		return nil
+	}
+
+	host := batchHost(repos)
+	token, err := c.token(host)
+	if err != nil {
+		results := make([]BatchResult, len(repos))
+		for i := range results {
+			results[i].Err = err
+		}
+		return results
+	}
+
+	return fetchBatch(repos, c.maxPRs, c.fetchBody, c.retryer, func(query string, vars map[string]string) ([]byte, error) {
+		payload := map[string]interface{}{
+			"query":     query,
+			"variables": vars,
+		}
+		return c.do(http.MethodPost, c.endpoints(host).graphql, token, payload, "")
+	})
+}
+
+// RevalidatePRs sends a conditional request for repo's open PR listing,
+// newest-updated first. Any change to an open PR (new commits, reviews,
+// edits) or the set of open PRs changes the listing and thus its ETag.
+// Conditional requests answered with 304 don't count against the rate limit.
+func (c *apiClient) RevalidatePRs(repo *models.Repository, etag string) (string, bool, error) {
+	token, err := c.token(repo.Host)
+	if err != nil {
+		return "", false, err
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/pulls?state=open&sort=updated&direction=desc&per_page=%d",
+		c.endpoints(repo.Host).rest, repo.FullName(), revalidateMaxPRs)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return "", false, err
+	}
+	c.setHeaders(req, token)
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", false, &NetworkError{Cause: err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return etag, true, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", false, &NetworkError{Cause: err}
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", false, ClassifyHTTPError(resp, body, repo.Path)
+	}
+
+	return resp.Header.Get("ETag"), false, nil
+}
+
+// do performs a request authenticated with token and returns the response
+// body. Non-2xx responses are converted to typed errors via
+// ClassifyHTTPError; repoPath is used to attribute not-found errors.
+func (c *apiClient) do(method, url, token string, payload interface{}, repoPath string) ([]byte, error) {
+	var reqBody io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, url, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	c.setHeaders(req, token)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, &NetworkError{Cause: err}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &NetworkError{Cause: err}
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, ClassifyHTTPError(resp, body, repoPath)
+	}
+
+	return body, nil
+}
+
+// setHeaders sets the authentication and content negotiation headers
+// common to all API requests.
+func (c *apiClient) setHeaders(req *http.Request, token string) {
+	req.Header.Set("Authorization", "bearer "+token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", "prt")
+}
+
+// apiErrorMessage extracts the "message" field from a GitHub API error body.
+func apiErrorMessage(body []byte) string {
+	var apiErr struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Message != "" {
+		return apiErr.Message
+	}
+	return strings.TrimSpace(string(body))
+}
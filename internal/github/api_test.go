@@ -0,0 +1,202 @@
+package github
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"prt/internal/models"
+)
+
+func TestNewAPIClient(t *testing.T) {
+	isolateTokenEnv(t)
+	t.Setenv("GH_TOKEN", "abc")
+
+	c, ok := NewAPIClient().(*apiClient)
+	if !ok {
+		t.Fatal("NewAPIClient should return an *apiClient")
+	}
+	if token, err := c.token(c.host); err != nil || token != "abc" {
+		t.Errorf("token = %q, %v; want abc", token, err)
+	}
+}
+
+func TestEndpointsForHost(t *testing.T) {
+	tests := []struct {
+		host        string
+		wantREST    string
+		wantGraphQL string
+	}{
+		{"", "https://api.github.com", "https://api.github.com/graphql"},
+		{"github.com", "https://api.github.com", "https://api.github.com/graphql"},
+		{"github.mycorp.com", "https://github.mycorp.com/api/v3", "https://github.mycorp.com/api/graphql"},
+		{"ghe.io:8443", "https://ghe.io:8443/api/v3", "https://ghe.io:8443/api/graphql"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			got := endpointsForHost(tt.host)
+			if got.rest != tt.wantREST || got.graphql != tt.wantGraphQL {
+				t.Errorf("endpointsForHost(%q) = %+v, want %s, %s", tt.host, got, tt.wantREST, tt.wantGraphQL)
+			}
+		})
+	}
+}
+
+func TestAPIClient_RoutesByHost(t *testing.T) {
+	public := newFakeGitHub(t)
+	enterprise := newFakeGitHub(t)
+	enterprise.token = "ghe-token"
+	enterprise.repos = map[string]string{"corp/svc": `[]`}
+
+	c := &apiClient{
+		endpoints: func(host string) apiEndpoints {
+			srv := public
+			if host == "ghe.corp.com" {
+				srv = enterprise
+			}
+			return apiEndpoints{rest: srv.URL, graphql: srv.URL + "/graphql"}
+		},
+		resolveToken: func(host string) (string, error) {
+			if host == "ghe.corp.com" {
+				return "ghe-token", nil
+			}
+			return public.token, nil
+		},
+		httpClient: http.DefaultClient,
+		retryer:    testRetryer(),
+	}
+
+	prs, _, err := c.ListPRs(&models.Repository{Host: "ghe.corp.com", Owner: "corp", Name: "svc"})
+	if err != nil || len(prs) != 0 {
+		t.Errorf("enterprise ListPRs() = %d PRs, %v; want none", len(prs), err)
+	}
+
+	prs, _, err = c.ListPRs(&models.Repository{Host: "github.com", Owner: "org", Name: "api"})
+	if err != nil || len(prs) != 1 {
+		t.Errorf("public ListPRs() = %d PRs, %v; want 1", len(prs), err)
+	}
+
+	// corp/svc only exists on the enterprise server
+	_, _, err = c.ListPRs(&models.Repository{Owner: "corp", Name: "svc"})
+	var notFound *RepoNotFoundError
+	if !errors.As(err, &notFound) {
+		t.Errorf("public ListPRs(corp/svc) error = %v, want RepoNotFoundError", err)
+	}
+}
+
+func TestAPIClient_MissingToken(t *testing.T) {
+	isolateTokenEnv(t)
+
+	c := NewAPIClient()
+
+	var authErr *GHAuthError
+	if err := c.Check(); !errors.As(err, &authErr) {
+		t.Errorf("Check() error = %v, want GHAuthError", err)
+	}
+
+	results := c.(BatchClient).ListPRsBatch(nil)
+	if len(results) != 0 {
+		t.Errorf("expected no results for no repos, got %d", len(results))
+	}
+}
+
+func TestAPIClient_RevalidatePRs(t *testing.T) {
+	srv := newFakeGitHub(t)
+	c := &apiClient{
+		endpoints: func(string) apiEndpoints {
+			return apiEndpoints{rest: srv.URL, graphql: srv.URL + "/graphql"}
+		},
+		resolveToken: func(string) (string, error) { return srv.token, nil },
+		httpClient:   http.DefaultClient,
+		retryer:      testRetryer(),
+	}
+	repo := &models.Repository{Owner: "org", Name: "api"}
+
+	etag, notModified, err := c.RevalidatePRs(repo, "")
+	if err != nil || notModified || etag == "" {
+		t.Fatalf("RevalidatePRs(no etag) = %q, %v, %v; want a fresh ETag", etag, notModified, err)
+	}
+
+	got, notModified, err := c.RevalidatePRs(repo, etag)
+	if err != nil || !notModified || got != etag {
+		t.Errorf("RevalidatePRs(current etag) = %q, %v, %v; want not modified", got, notModified, err)
+	}
+
+	srv.repos["org/api"] = `[]`
+	got, notModified, err = c.RevalidatePRs(repo, etag)
+	if err != nil || notModified || got == etag {
+		t.Errorf("RevalidatePRs(after change) = %q, %v, %v; want a new ETag", got, notModified, err)
+	}
+
+	_, _, err = c.RevalidatePRs(&models.Repository{Owner: "org", Name: "missing"}, etag)
+	var notFound *RepoNotFoundError
+	if !errors.As(err, &notFound) {
+		t.Errorf("RevalidatePRs(missing) error = %v, want RepoNotFoundError", err)
+	}
+}
+
+func TestClassifyHTTPError(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Unix()
+
+	tests := []struct {
+		name    string
+		status  int
+		headers map[string]string
+		body    string
+		check   func(error) bool
+	}{
+		{
+			name:   "401 is auth error",
+			status: http.StatusUnauthorized,
+			body:   `{"message": "Bad credentials"}`,
+			check:  func(err error) bool { var e *GHAuthError; return errors.As(err, &e) },
+		},
+		{
+			name:    "403 with exhausted quota is rate limit",
+			status:  http.StatusForbidden,
+			headers: map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": strconv.FormatInt(reset, 10)},
+			body:    `{"message": "API rate limit exceeded"}`,
+			check: func(err error) bool {
+				var e *RateLimitError
+				return errors.As(err, &e) && e.ResetTime.Unix() == reset
+			},
+		},
+		{
+			name:   "403 without rate limit is auth error",
+			status: http.StatusForbidden,
+			body:   `{"message": "Resource not accessible by integration"}`,
+			check:  func(err error) bool { var e *GHAuthError; return errors.As(err, &e) },
+		},
+		{
+			name:   "404 is not found",
+			status: http.StatusNotFound,
+			body:   `{"message": "Not Found"}`,
+			check:  func(err error) bool { var e *RepoNotFoundError; return errors.As(err, &e) },
+		},
+		{
+			name:   "502 is retriable scan error",
+			status: http.StatusBadGateway,
+			body:   `bad gateway`,
+			check: func(err error) bool {
+				var e *RepoScanError
+				return errors.As(err, &e) && IsRetriableError(err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: tt.status, Header: http.Header{}}
+			for k, v := range tt.headers {
+				resp.Header.Set(k, v)
+			}
+			err := ClassifyHTTPError(resp, []byte(tt.body), "/repo")
+			if !tt.check(err) {
+				t.Errorf("unexpected error classification: %T (%v)", err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package github
+
+import (
+	"time"
+
+	"prt/internal/models"
+)
+
+// Cache persists fetched PRs between runs, keyed by repository.
+// See package cache for the on-disk implementation.
+type Cache interface {
+	// Get returns the cached entry for repo, or false if there is none.
+	Get(repo *models.Repository) (*CacheEntry, bool)
+	// Put stores the entry for repo, replacing any previous one.
+	Put(repo *models.Repository, entry *CacheEntry) error
+}
+
+// CacheEntry is the cached result of fetching one repository's open PRs.
+type CacheEntry struct {
+	FetchedAt time.Time    `json:"fetched_at"`
+	PRs       []*models.PR `json:"prs"`
+	Truncated bool         `json:"truncated"`
+	// ClosedPRs are the recently merged or closed PRs fetched with PRs
+	// (see BatchResult.Closed).
+	ClosedPRs []*models.PR `json:"closed_prs,omitempty"`
+	// ETag validates the repository's open PR listing on the REST API,
+	// so a stale entry can be revalidated instead of refetched.
+	ETag string `json:"etag,omitempty"`
+}
+
+// Revalidator is implemented by clients that can cheaply check whether a
+// repository's open PRs changed since an ETag was issued.
+type Revalidator interface {
+	// RevalidatePRs checks repo's open PR listing against etag. It returns
+	// the current ETag and whether the listing is unchanged; an empty etag
+	// always reports a change.
+	RevalidatePRs(repo *models.Repository, etag string) (currentETag string, notModified bool, err error)
+}
+
+// revalidateMaxPRs is the largest cached PR list that can be revalidated:
+// the ETag only covers the first page of the REST listing.
+const revalidateMaxPRs = 100
+
+// cachingClient wraps a Client with a Cache. Fresh entries are served
+// without any request; stale entries are revalidated by ETag when the
+// wrapped client supports it, and refetched otherwise.
+type cachingClient struct {
+	Client
+	cache   Cache
+	ttl     time.Duration
+	refresh bool
+	now     func() time.Time
+}
+
+// NewCachingClient wraps client so that PRs fetched within ttl are served
+// from cache. With refresh set, cached entries are ignored (but still
+// updated with the fresh results). The returned client always implements
+// BatchClient, batching misses when client does.
+func NewCachingClient(client Client, cache Cache, ttl time.Duration, refresh bool) BatchClient {
+	return &cachingClient{
+		Client:  client,
+		cache:   cache,
+		ttl:     ttl,
+		refresh: refresh,
+		now:     time.Now,
+	}
+}
+
+// ListPRs returns repo's PRs from cache when possible, fetching otherwise.
+func (c *cachingClient) ListPRs(repo *models.Repository) ([]*models.PR, bool, error) {
+	results := c.ListPRsBatch([]*models.Repository{repo})
+	return results[0].PRs, results[0].Truncated, results[0].Err
+}
+
+// ListPRsBatch serves cached repositories and fetches the rest, in a single
+// batch if the wrapped client supports it. Fetched results are cached.
+func (c *cachingClient) ListPRsBatch(repos []*models.Repository) []BatchResult {
+	results := make([]BatchResult, len(repos))
+	etags := make([]string, len(repos))
+
+	var missIdx []int
+	var misses []*models.Repository
+	for i, repo := range repos {
+		if res, ok := c.lookup(repo, &etags[i]); ok {
+			results[i] = res
+			continue
+		}
+		missIdx = append(missIdx, i)
+		misses = append(misses, repo)
+	}
+
+	if len(misses) == 0 {
+		return results
+	}
+
+	var fetched []BatchResult
+	if batcher, ok := c.Client.(BatchClient); ok {
+		fetched = batcher.ListPRsBatch(misses)
+	} else {
+		fetched = make([]BatchResult, len(misses))
+		for j, repo := range misses {
+			prs, truncated, err := c.Client.ListPRs(repo)
+			fetched[j] = BatchResult{PRs: prs, Truncated: truncated, Err: err}
+		}
+	}
+
+	for j, i := range missIdx {
+		if j >= len(fetched) {
+			break
+		}
+		results[i] = fetched[j]
+		if fetched[j].Err != nil {
+			continue
+		}
+		// A failed write only costs a refetch next run
+		_ = c.cache.Put(repos[i], &CacheEntry{
+			FetchedAt: c.now(),
+			PRs:       fetched[j].PRs,
+			Truncated: fetched[j].Truncated,
+			ClosedPRs: fetched[j].Closed,
+			ETag:      etags[i],
+		})
+	}
+
+	return results
+}
+
+// lookup returns the cached result for repo if it is fresh, or if it is
+// stale but revalidated as unchanged. When revalidation finds a change, the
+// current ETag is stored in etag so the refetched entry can carry it.
+func (c *cachingClient) lookup(repo *models.Repository, etag *string) (BatchResult, bool) {
+	if c.refresh || c.ttl <= 0 {
+		return BatchResult{}, false
+	}
+
+	entry, ok := c.cache.Get(repo)
+	if !ok {
+		return BatchResult{}, false
+	}
+
+	if c.now().Sub(entry.FetchedAt) < c.ttl {
+		return cachedResult(entry), true
+	}
+
+	revalidator, ok := c.Client.(Revalidator)
+	if !ok {
+		return BatchResult{}, false
+	}
+
+	// Reusing an ETag-validated entry is only safe when the listing covers
+	// everything we show: CI status changes don't touch the listing, and
+	// the ETag only covers its first page.
+	if entry.ETag != "" && !canRevalidate(entry) {
+		return BatchResult{}, false
+	}
+
+	current, notModified, err := revalidator.RevalidatePRs(repo, entry.ETag)
+	if err != nil {
+		return BatchResult{}, false
+	}
+	if notModified {
+		entry.FetchedAt = c.now()
+		_ = c.cache.Put(repo, entry)
+		return cachedResult(entry), true
+	}
+
+	*etag = current
+	return BatchResult{}, false
+}
+
+// canRevalidate reports whether an ETag match proves entry is up to date.
+func canRevalidate(entry *CacheEntry) bool {
+	if entry.Truncated || len(entry.PRs) > revalidateMaxPRs {
+		return false
+	}
+	for _, pr := range entry.PRs {
+		if pr.CIStatus == models.CIStatusPending {
+			return false
+		}
+	}
+	return true
+}
+
+// cachedResult converts a cache entry into a batch result.
+func cachedResult(entry *CacheEntry) BatchResult {
+	prs := entry.PRs
+	if prs == nil {
+		prs = []*models.PR{}
+	}
+	return BatchResult{PRs: prs, Truncated: entry.Truncated, Closed: entry.ClosedPRs, Cached: true}
+}
@@ -0,0 +1,223 @@
+package github
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"prt/internal/models"
+)
+
+// memCache is an in-memory Cache for testing.
+type memCache struct {
+	entries map[string]*CacheEntry
+	puts    int
+}
+
+func newMemCache() *memCache {
+	return &memCache{entries: make(map[string]*CacheEntry)}
+}
+
+func (m *memCache) Get(repo *models.Repository) (*CacheEntry, bool) {
+	e, ok := m.entries[repo.FullName()]
+	return e, ok
+}
+
+func (m *memCache) Put(repo *models.Repository, entry *CacheEntry) error {
+	m.puts++
+	m.entries[repo.FullName()] = entry
+	return nil
+}
+
+// revalidatingClient is a mockBatchClient that also implements Revalidator.
+type revalidatingClient struct {
+	mockBatchClient
+	etag          string
+	revalidations int
+}
+
+func (r *revalidatingClient) RevalidatePRs(repo *models.Repository, etag string) (string, bool, error) {
+	r.revalidations++
+	return r.etag, etag != "" && etag == r.etag, nil
+}
+
+// countingBatch returns a batchFn serving one open and one merged PR per
+// repo and counting the repos it is asked for.
+func countingBatch(fetched *int) func(repos []*models.Repository) []BatchResult {
+	return func(repos []*models.Repository) []BatchResult {
+		*fetched += len(repos)
+		results := make([]BatchResult, len(repos))
+		for i := range repos {
+			results[i].PRs = []*models.PR{{Number: 1, CIStatus: models.CIStatusPassing}}
+			results[i].Closed = []*models.PR{{Number: 2, State: models.PRStateMerged}}
+		}
+		return results
+	}
+}
+
+func newTestCachingClient(inner Client, cache Cache, ttl time.Duration, refresh bool, now time.Time) *cachingClient {
+	c := NewCachingClient(inner, cache, ttl, refresh).(*cachingClient)
+	c.now = func() time.Time { return now }
+	return c
+}
+
+func TestCachingClient_ServesFreshEntries(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache := newMemCache()
+	cache.entries["org/api"] = &CacheEntry{
+		FetchedAt: now.Add(-time.Minute),
+		PRs:       []*models.PR{{Number: 7}},
+		Truncated: true,
+		ClosedPRs: []*models.PR{{Number: 6}},
+	}
+
+	var fetched int
+	inner := &mockBatchClient{batchFn: countingBatch(&fetched)}
+	c := newTestCachingClient(inner, cache, 5*time.Minute, false, now)
+
+	results := c.ListPRsBatch([]*models.Repository{
+		{Owner: "org", Name: "api"},
+		{Owner: "org", Name: "web"},
+	})
+
+	if fetched != 1 || len(inner.batches) != 1 || inner.batches[0][0].Name != "web" {
+		t.Errorf("expected only org/web to be fetched, got batches %v", inner.batches)
+	}
+	if !results[0].Cached || !results[0].Truncated || len(results[0].PRs) != 1 || results[0].PRs[0].Number != 7 || len(results[0].Closed) != 1 {
+		t.Errorf("org/api result = %+v, want cached entry", results[0])
+	}
+	if results[1].Cached || len(results[1].PRs) != 1 {
+		t.Errorf("org/web result = %+v, want fetched", results[1])
+	}
+	if e, ok := cache.entries["org/web"]; !ok || !e.FetchedAt.Equal(now) || len(e.ClosedPRs) != 1 {
+		t.Errorf("expected org/web to be cached at %v with its closed PRs, got %+v", now, e)
+	}
+}
+
+func TestCachingClient_RefetchesStaleEntries(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache := newMemCache()
+	cache.entries["org/api"] = &CacheEntry{FetchedAt: now.Add(-time.Hour), PRs: []*models.PR{{Number: 7}}}
+
+	var fetched int
+	c := newTestCachingClient(&mockBatchClient{batchFn: countingBatch(&fetched)}, cache, 5*time.Minute, false, now)
+
+	results := c.ListPRsBatch([]*models.Repository{{Owner: "org", Name: "api"}})
+	if fetched != 1 || results[0].Cached {
+		t.Errorf("expected stale entry to be refetched, got %+v", results[0])
+	}
+}
+
+func TestCachingClient_Refresh(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache := newMemCache()
+	cache.entries["org/api"] = &CacheEntry{FetchedAt: now, PRs: []*models.PR{{Number: 7}}}
+
+	var fetched int
+	c := newTestCachingClient(&mockBatchClient{batchFn: countingBatch(&fetched)}, cache, 5*time.Minute, true, now)
+
+	results := c.ListPRsBatch([]*models.Repository{{Owner: "org", Name: "api"}})
+	if fetched != 1 || results[0].Cached {
+		t.Errorf("expected refresh to bypass the cache, got %+v", results[0])
+	}
+	if cache.entries["org/api"].PRs[0].Number != 1 {
+		t.Error("expected refresh to update the cached entry")
+	}
+}
+
+func TestCachingClient_DoesNotCacheErrors(t *testing.T) {
+	cache := newMemCache()
+	inner := &mockClient{listPRsFunc: func(string) ([]*models.PR, error) {
+		return nil, errors.New("boom")
+	}}
+	c := newTestCachingClient(inner, cache, 5*time.Minute, false, time.Now())
+
+	_, _, err := c.ListPRs(&models.Repository{Owner: "org", Name: "api"})
+	if err == nil {
+		t.Error("expected the error to be returned")
+	}
+	if cache.puts != 0 {
+		t.Errorf("expected no cache writes, got %d", cache.puts)
+	}
+}
+
+func TestCachingClient_Revalidates(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	stale := now.Add(-time.Hour)
+
+	tests := []struct {
+		name              string
+		entry             *CacheEntry
+		serverETag        string
+		wantRevalidations int
+		wantCached        bool
+		wantETag          string
+	}{
+		{
+			name:              "unchanged listing reuses entry",
+			entry:             &CacheEntry{FetchedAt: stale, ETag: `"v1"`, PRs: []*models.PR{{Number: 7}}},
+			serverETag:        `"v1"`,
+			wantRevalidations: 1,
+			wantCached:        true,
+			wantETag:          `"v1"`,
+		},
+		{
+			name:              "changed listing refetches with new etag",
+			entry:             &CacheEntry{FetchedAt: stale, ETag: `"v1"`, PRs: []*models.PR{{Number: 7}}},
+			serverETag:        `"v2"`,
+			wantRevalidations: 1,
+			wantETag:          `"v2"`,
+		},
+		{
+			name:              "entry without etag picks one up on refetch",
+			entry:             &CacheEntry{FetchedAt: stale, PRs: []*models.PR{{Number: 7}}},
+			serverETag:        `"v1"`,
+			wantRevalidations: 1,
+			wantETag:          `"v1"`,
+		},
+		{
+			name: "pending CI is refetched without revalidating",
+			entry: &CacheEntry{FetchedAt: stale, ETag: `"v1"`, PRs: []*models.PR{
+				{Number: 7, CIStatus: models.CIStatusPending},
+			}},
+			serverETag: `"v1"`,
+		},
+		{
+			name:       "truncated entry is refetched without revalidating",
+			entry:      &CacheEntry{FetchedAt: stale, ETag: `"v1"`, Truncated: true},
+			serverETag: `"v1"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := newMemCache()
+			cache.entries["org/api"] = tt.entry
+
+			var fetched int
+			inner := &revalidatingClient{etag: tt.serverETag}
+			inner.batchFn = countingBatch(&fetched)
+			c := newTestCachingClient(inner, cache, 5*time.Minute, false, now)
+
+			results := c.ListPRsBatch([]*models.Repository{{Owner: "org", Name: "api"}})
+
+			if inner.revalidations != tt.wantRevalidations {
+				t.Errorf("revalidations = %d, want %d", inner.revalidations, tt.wantRevalidations)
+			}
+			if results[0].Cached != tt.wantCached {
+				t.Errorf("Cached = %v, want %v", results[0].Cached, tt.wantCached)
+			}
+			if wantFetched := map[bool]int{true: 0, false: 1}[tt.wantCached]; fetched != wantFetched {
+				t.Errorf("fetched %d repos, want %d", fetched, wantFetched)
+			}
+
+			entry := cache.entries["org/api"]
+			if !entry.FetchedAt.Equal(now) {
+				t.Errorf("FetchedAt = %v, want bumped to %v", entry.FetchedAt, now)
+			}
+			if entry.ETag != tt.wantETag {
+				t.Errorf("ETag = %q, want %q", entry.ETag, tt.wantETag)
+			}
+		})
+	}
+}
@@ -1,32 +1,39 @@
 package github
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os/exec"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 
 	"prt/internal/models"
 )
 
-// prListJSONFields are the fields we request from gh pr list.
-const prListJSONFields = "number,title,url,author,state,isDraft,createdAt,baseRefName,headRefName,statusCheckRollup,reviewRequests,assignees,reviews"
+// prListJSONFields are the fields we request from gh pr list, plus body
+// when the client fetches PR descriptions.
+const prListJSONFields = "number,title,url,author,state,isDraft,isCrossRepository,headRepositoryOwner,createdAt,updatedAt,baseRefName,headRefName,headRefOid,labels,milestone,additions,deletions,changedFiles,mergeable,mergeStateStatus,statusCheckRollup,reviewRequests,assignees,reviews,reviewDecision"
 
-// Client provides methods for interacting with GitHub via the gh CLI.
+// Client provides methods for interacting with GitHub.
+// The default implementation shells out to the gh CLI; NewAPIClient
+// returns one that talks to the GitHub API directly.
 type Client interface {
-	// Check verifies gh CLI is installed and authenticated.
+	// Check verifies the backend is available and authenticated.
 	Check() error
 	// GetCurrentUser returns the authenticated GitHub username.
 	GetCurrentUser() (string, error)
 	// CheckAndGetUser verifies gh CLI and returns the current user in parallel.
 	// This is faster than calling Check() then GetCurrentUser() sequentially.
 	CheckAndGetUser() (string, error)
-	// ListPRs fetches open PRs for a repository.
-	ListPRs(repoPath string) ([]*models.PR, error)
+	// ListPRs fetches open PRs for a repository, up to the client's per-repo
+	// cap. truncated reports whether the repository has more open PRs.
+	ListPRs(repo *models.Repository) (prs []*models.PR, truncated bool, err error)
 }
 
-// client is the default implementation of Client.
+// client is the default implementation of Client, backed by the gh CLI.
 type client struct {
 	// execLookPath allows mocking exec.LookPath for testing
 	execLookPath func(file string) (string, error)
@@ -34,20 +41,51 @@ type client struct {
 	execCommand func(name string, arg ...string) *exec.Cmd
 	// retryer handles retry logic for transient failures
 	retryer *Retryer
+	// host is the GitHub host used for auth checks and user lookups
+	// (empty means github.com)
+	host string
+	// maxPRs caps the number of PRs fetched per repository (0 = gh's default)
+	maxPRs int
+	// fetchBody requests PR descriptions too (see WithPRBody)
+	fetchBody bool
 }
 
-// NewClient creates a new GitHub client with default retry config.
-func NewClient() Client {
-	return NewClientWithConfig(DefaultRetryConfig)
+// NewClient creates a new GitHub client backed by the gh CLI.
+// Without options it uses github.com, the default retry config, and
+// DefaultMaxPRs.
+func NewClient(opts ...Option) Client {
+	o := newClientOptions(opts)
+	return &client{
+		execLookPath: exec.LookPath,
+		execCommand:  exec.Command,
+		retryer:      NewRetryer(o.retry),
+		host:         o.host,
+		maxPRs:       o.maxPRs,
+		fetchBody:    o.fetchBody,
+	}
 }
 
 // NewClientWithConfig creates a new GitHub client with custom retry config.
 func NewClientWithConfig(retryConfig RetryConfig) Client {
-	return &client{
-		execLookPath: exec.LookPath,
-		execCommand:  exec.Command,
-		retryer:      NewRetryer(retryConfig),
+	return NewClient(WithRetryConfig(retryConfig))
+}
+
+// hostnameArgs returns the --hostname flag for gh commands that target host,
+// or nothing for github.com, which gh uses by default.
+func hostnameArgs(host string) []string {
+	if host == "" || host == DefaultHost {
+		return nil
+	}
+	return []string{"--hostname", host}
+}
+
+// repoArg returns the --repo value for repo: OWNER/REPO on github.com and
+// HOST/OWNER/REPO on any other host.
+func repoArg(repo *models.Repository) string {
+	if repo.Host == "" || repo.Host == DefaultHost {
+		return repo.FullName()
 	}
+	return repo.Host + "/" + repo.FullName()
 }
 
 // Check verifies that the gh CLI is installed and authenticated.
@@ -71,7 +109,7 @@ Then authenticate:
 	}
 
 	// 2. Check authentication
-	cmd := c.execCommand("gh", "auth", "status")
+	cmd := c.execCommand("gh", append([]string{"auth", "status"}, hostnameArgs(c.host)...)...)
 	cmd.Stdout = io.Discard
 	cmd.Stderr = io.Discard
 
@@ -89,7 +127,7 @@ Please run:
 
 // GetCurrentUser returns the authenticated GitHub username by querying the API.
 func (c *client) GetCurrentUser() (string, error) {
-	cmd := c.execCommand("gh", "api", "user", "--jq", ".login")
+	cmd := c.execCommand("gh", append([]string{"api", "user", "--jq", ".login"}, hostnameArgs(c.host)...)...)
 
 	out, err := cmd.Output()
 	if err != nil {
@@ -138,7 +176,7 @@ Then authenticate:
 	// Auth check goroutine
 	go func() {
 		defer wg.Done()
-		cmd := c.execCommand("gh", "auth", "status")
+		cmd := c.execCommand("gh", append([]string{"auth", "status"}, hostnameArgs(c.host)...)...)
 		cmd.Stdout = io.Discard
 		cmd.Stderr = io.Discard
 		if err := cmd.Run(); err != nil {
@@ -154,7 +192,7 @@ Please run:
 	// User fetch goroutine
 	go func() {
 		defer wg.Done()
-		cmd := c.execCommand("gh", "api", "user", "--jq", ".login")
+		cmd := c.execCommand("gh", append([]string{"api", "user", "--jq", ".login"}, hostnameArgs(c.host)...)...)
 		out, err := cmd.Output()
 		if err != nil {
 			if exitErr, ok := err.(*exec.ExitError); ok {
@@ -183,23 +221,37 @@ Please run:
 	return username, nil
 }
 
-// ListPRs fetches open pull requests for the repository at repoPath.
+// ListPRs fetches open pull requests for the repository, newest first.
 // Uses retry logic for transient network failures.
-// Returns empty slice if no PRs exist.
-func (c *client) ListPRs(repoPath string) ([]*models.PR, error) {
+// Returns empty slice if no PRs exist. If the repository has more than the
+// client's cap, only the first PRs are returned and truncated is true.
+func (c *client) ListPRs(repo *models.Repository) ([]*models.PR, bool, error) {
 	var result []*models.PR
 
+	fields := prListJSONFields
+	if c.fetchBody {
+		fields += ",body"
+	}
+	args := []string{"pr", "list",
+		"--json", fields,
+		"--state", "open",
+	}
+	if c.maxPRs > 0 {
+		// Ask for one extra PR to find out whether the cap truncates the list
+		args = append(args, "--limit", strconv.Itoa(c.maxPRs+1))
+	}
+	if repo.Owner != "" {
+		args = append(args, "--repo", repoArg(repo))
+	}
+
 	err := c.retryer.Do(func() error {
-		cmd := c.execCommand("gh", "pr", "list",
-			"--json", prListJSONFields,
-			"--state", "open",
-		)
-		cmd.Dir = repoPath
+		cmd := c.execCommand("gh", args...)
+		cmd.Dir = repo.Path
 
 		out, err := cmd.Output()
 		if err != nil {
 			// Classify the error for proper retry handling
-			return ClassifyError(err, repoPath)
+			return ClassifyError(err, repo.Path)
 		}
 
 		// Empty output or empty array means no PRs
@@ -213,7 +265,7 @@ func (c *client) ListPRs(repoPath string) ([]*models.PR, error) {
 		if err != nil {
 			// Parse errors are not retriable
 			return &RepoScanError{
-				RepoPath: repoPath,
+				RepoPath: repo.Path,
 				Cause:    err,
 			}
 		}
@@ -223,8 +275,52 @@ func (c *client) ListPRs(repoPath string) ([]*models.PR, error) {
 	})
 
 	if err != nil {
-		return nil, err
+		return nil, false, err
+	}
+
+	if c.maxPRs > 0 && len(result) > c.maxPRs {
+		return result[:c.maxPRs], true, nil
 	}
 
-	return result, nil
+	return result, false, nil
+}
+
+// ListPRsBatch fetches open pull requests for several repositories with a
+// single `gh api graphql` call per page. Uses retry logic for transient
+// failures of a whole request; repository-specific failures are returned
+// per repo. All repos must be on the same host (see BatchClient).
+func (c *client) ListPRsBatch(repos []*models.Repository) []BatchResult {
+	hostArgs := hostnameArgs(batchHost(repos))
+
+	return fetchBatch(repos, c.maxPRs, c.fetchBody, c.retryer, func(query string, vars map[string]string) ([]byte, error) {
+		args := []string{"api", "graphql", "-f", "query=" + query}
+		args = append(args, hostArgs...)
+		for _, name := range sortedKeys(vars) {
+			args = append(args, "-f", name+"="+vars[name])
+		}
+
+		cmd := c.execCommand("gh", args...)
+		out, err := cmd.Output()
+
+		// gh exits non-zero when the response contains GraphQL errors but
+		// still prints the partial data, so a well-formed response is
+		// handed to the parser, whose errors are more specific.
+		if len(strings.TrimSpace(string(out))) > 0 && (err == nil || json.Valid(out)) {
+			return out, nil
+		}
+		if err != nil {
+			return nil, ClassifyError(err, "")
+		}
+		return nil, fmt.Errorf("empty response from GitHub GraphQL API")
+	})
+}
+
+// sortedKeys returns the keys of m in sorted order for deterministic arguments.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
 }
@@ -0,0 +1,339 @@
+package github
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"prt/internal/models"
+)
+
+// Contract tests run against every Client implementation. Each backend is
+// pointed at the same fake GitHub server, so they must agree on behavior.
+
+// contractBackend constructs a Client of one backend type for a fake server.
+type contractBackend struct {
+	name      string
+	newClient func(srv *fakeGitHub, token string, opts ...Option) Client
+}
+
+func contractBackends() []contractBackend {
+	return []contractBackend{
+		{
+			name: "gh",
+			newClient: func(srv *fakeGitHub, token string, opts ...Option) Client {
+				return &client{
+					execLookPath: func(file string) (string, error) { return "/usr/bin/gh", nil },
+					execCommand:  fakeGHCommand(srv, token),
+					retryer:      testRetryer(),
+					maxPRs:       newClientOptions(opts).maxPRs,
+				}
+			},
+		},
+		{
+			name: "api",
+			newClient: func(srv *fakeGitHub, token string, opts ...Option) Client {
+				return &apiClient{
+					maxPRs: newClientOptions(opts).maxPRs,
+					endpoints: func(string) apiEndpoints {
+						return apiEndpoints{rest: srv.URL, graphql: srv.URL + "/graphql"}
+					},
+					resolveToken: func(string) (string, error) { return token, nil },
+					httpClient:   http.DefaultClient,
+					retryer:      testRetryer(),
+				}
+			},
+		},
+	}
+}
+
+func TestClientContract_CheckAndGetUser(t *testing.T) {
+	for _, backend := range contractBackends() {
+		t.Run(backend.name, func(t *testing.T) {
+			srv := newFakeGitHub(t)
+			c := backend.newClient(srv, srv.token)
+
+			if err := c.Check(); err != nil {
+				t.Errorf("Check() error = %v", err)
+			}
+
+			user, err := c.GetCurrentUser()
+			if err != nil || user != "octocat" {
+				t.Errorf("GetCurrentUser() = %q, %v; want octocat", user, err)
+			}
+
+			user, err = c.CheckAndGetUser()
+			if err != nil || user != "octocat" {
+				t.Errorf("CheckAndGetUser() = %q, %v; want octocat", user, err)
+			}
+		})
+	}
+}
+
+func TestClientContract_BadCredentials(t *testing.T) {
+	for _, backend := range contractBackends() {
+		t.Run(backend.name, func(t *testing.T) {
+			srv := newFakeGitHub(t)
+			c := backend.newClient(srv, "wrong-token")
+
+			var authErr *GHAuthError
+			if err := c.Check(); !errors.As(err, &authErr) {
+				t.Errorf("Check() error = %v (%T), want GHAuthError", err, err)
+			}
+			if _, err := c.CheckAndGetUser(); !errors.As(err, &authErr) {
+				t.Errorf("CheckAndGetUser() error = %v (%T), want GHAuthError", err, err)
+			}
+		})
+	}
+}
+
+func TestClientContract_ListPRs(t *testing.T) {
+	for _, backend := range contractBackends() {
+		t.Run(backend.name, func(t *testing.T) {
+			srv := newFakeGitHub(t)
+			c := backend.newClient(srv, srv.token)
+
+			prs, _, err := c.ListPRs(&models.Repository{Owner: "org", Name: "api", Path: t.TempDir()})
+			if err != nil {
+				t.Fatalf("ListPRs() error = %v", err)
+			}
+			if len(prs) != 1 {
+				t.Fatalf("expected 1 PR, got %d", len(prs))
+			}
+
+			pr := prs[0]
+			if pr.Number != 7 || pr.Title != "Add rate limiting" || pr.Author != "alice" {
+				t.Errorf("unexpected PR identity: #%d %q by %q", pr.Number, pr.Title, pr.Author)
+			}
+			if !pr.IsDraft || pr.HeadBranch != "rate-limit" || pr.BaseBranch != "main" {
+				t.Errorf("unexpected state/branches: draft=%v %s -> %s", pr.IsDraft, pr.HeadBranch, pr.BaseBranch)
+			}
+			if pr.CIStatus != models.CIStatusFailing {
+				t.Errorf("CIStatus = %v, want failing", pr.CIStatus)
+			}
+			if len(pr.ReviewRequests) != 1 || pr.ReviewRequests[0] != "octocat" {
+				t.Errorf("ReviewRequests = %v, want [octocat]", pr.ReviewRequests)
+			}
+			if len(pr.Reviews) != 1 || pr.Reviews[0].State != models.ReviewStateChangesRequested {
+				t.Errorf("Reviews = %v, want one CHANGES_REQUESTED", pr.Reviews)
+			}
+		})
+	}
+}
+
+func TestClientContract_ListPRs_Empty(t *testing.T) {
+	for _, backend := range contractBackends() {
+		t.Run(backend.name, func(t *testing.T) {
+			srv := newFakeGitHub(t)
+			c := backend.newClient(srv, srv.token)
+
+			prs, _, err := c.ListPRs(&models.Repository{Owner: "org", Name: "empty", Path: t.TempDir()})
+			if err != nil {
+				t.Fatalf("ListPRs() error = %v", err)
+			}
+			if len(prs) != 0 {
+				t.Errorf("expected 0 PRs, got %d", len(prs))
+			}
+		})
+	}
+}
+
+func TestClientContract_ListPRs_NotFound(t *testing.T) {
+	for _, backend := range contractBackends() {
+		t.Run(backend.name, func(t *testing.T) {
+			srv := newFakeGitHub(t)
+			c := backend.newClient(srv, srv.token)
+
+			_, _, err := c.ListPRs(&models.Repository{Owner: "org", Name: "missing", Path: t.TempDir()})
+			var notFound *RepoNotFoundError
+			if !errors.As(err, &notFound) {
+				t.Errorf("ListPRs() error = %v (%T), want RepoNotFoundError", err, err)
+			}
+		})
+	}
+}
+
+func TestClientContract_ListPRsBatch(t *testing.T) {
+	for _, backend := range contractBackends() {
+		t.Run(backend.name, func(t *testing.T) {
+			srv := newFakeGitHub(t)
+			c, ok := backend.newClient(srv, srv.token).(BatchClient)
+			if !ok {
+				t.Fatal("expected backend to implement BatchClient")
+			}
+
+			results := c.ListPRsBatch([]*models.Repository{
+				{Owner: "org", Name: "api"},
+				{Owner: "org", Name: "missing"},
+				{Owner: "org", Name: "empty"},
+			})
+			if len(results) != 3 {
+				t.Fatalf("expected 3 results, got %d", len(results))
+			}
+			if results[0].Err != nil || len(results[0].PRs) != 1 {
+				t.Errorf("org/api: got %d PRs, err %v; want 1 PR", len(results[0].PRs), results[0].Err)
+			}
+			var notFound *RepoNotFoundError
+			if !errors.As(results[1].Err, &notFound) {
+				t.Errorf("org/missing: error = %v, want RepoNotFoundError", results[1].Err)
+			}
+			if results[2].Err != nil || len(results[2].PRs) != 0 {
+				t.Errorf("org/empty: got %d PRs, err %v; want none", len(results[2].PRs), results[2].Err)
+			}
+		})
+	}
+}
+
+func TestClientContract_ListPRs_Paginates(t *testing.T) {
+	for _, backend := range contractBackends() {
+		t.Run(backend.name, func(t *testing.T) {
+			srv := newFakeGitHub(t)
+			c := backend.newClient(srv, srv.token)
+
+			prs, truncated, err := c.ListPRs(&models.Repository{Owner: "org", Name: "busy", Path: t.TempDir()})
+			if err != nil {
+				t.Fatalf("ListPRs() error = %v", err)
+			}
+			if len(prs) != fakeBusyPRCount || truncated {
+				t.Errorf("got %d PRs (truncated=%v), want all %d", len(prs), truncated, fakeBusyPRCount)
+			}
+			if prs[0].Number != fakeBusyPRCount {
+				t.Errorf("first PR = #%d, want newest #%d", prs[0].Number, fakeBusyPRCount)
+			}
+		})
+	}
+}
+
+func TestClientContract_ListPRs_Truncated(t *testing.T) {
+	for _, backend := range contractBackends() {
+		t.Run(backend.name, func(t *testing.T) {
+			srv := newFakeGitHub(t)
+			c := backend.newClient(srv, srv.token, WithMaxPRs(75))
+
+			prs, truncated, err := c.ListPRs(&models.Repository{Owner: "org", Name: "busy", Path: t.TempDir()})
+			if err != nil {
+				t.Fatalf("ListPRs() error = %v", err)
+			}
+			if len(prs) != 75 || !truncated {
+				t.Errorf("got %d PRs (truncated=%v), want 75 and truncated", len(prs), truncated)
+			}
+
+			// Exactly at the cap is not truncated
+			c = backend.newClient(srv, srv.token, WithMaxPRs(fakeBusyPRCount))
+			prs, truncated, err = c.ListPRs(&models.Repository{Owner: "org", Name: "busy", Path: t.TempDir()})
+			if err != nil || len(prs) != fakeBusyPRCount || truncated {
+				t.Errorf("at cap: got %d PRs (truncated=%v, err=%v), want %d, not truncated", len(prs), truncated, err, fakeBusyPRCount)
+			}
+		})
+	}
+}
+
+func TestClientContract_HeadSHA(t *testing.T) {
+	for _, backend := range contractBackends() {
+		t.Run(backend.name, func(t *testing.T) {
+			srv := newFakeGitHub(t)
+			c, ok := backend.newClient(srv, srv.token).(HeadClient)
+			if !ok {
+				t.Fatal("expected backend to implement HeadClient")
+			}
+			repo := &models.Repository{Owner: "org", Name: "api", Path: t.TempDir()}
+
+			sha, err := c.HeadSHA(repo, 7)
+			if err != nil || sha != "c0ffee" {
+				t.Errorf("HeadSHA() = %q, %v, want c0ffee", sha, err)
+			}
+			if sha, err := c.HeadSHA(repo, 99); err == nil {
+				t.Errorf("HeadSHA() of a missing PR = %q, want an error", sha)
+			}
+		})
+	}
+}
+
+func TestClientContract_Write(t *testing.T) {
+	for _, backend := range contractBackends() {
+		t.Run(backend.name, func(t *testing.T) {
+			srv := newFakeGitHub(t)
+			c, ok := backend.newClient(srv, srv.token).(WriteClient)
+			if !ok {
+				t.Fatal("expected backend to implement WriteClient")
+			}
+			pr := &models.PR{Number: 7, RepoOwner: "org", RepoName: "api", URL: "https://github.com/org/api/pull/7", HeadSHA: "c0ffee"}
+
+			if err := c.ApprovePR(pr, "LGTM"); err != nil {
+				t.Errorf("ApprovePR() error = %v", err)
+			}
+			if err := c.ApprovePR(pr, ""); err != nil {
+				t.Errorf("ApprovePR() without a body error = %v", err)
+			}
+			if err := c.CommentPR(pr, "Rebased"); err != nil {
+				t.Errorf("CommentPR() error = %v", err)
+			}
+			if err := c.MergePR(pr, MergeMethodSquash); err != nil {
+				t.Errorf("MergePR() error = %v", err)
+			}
+			if err := c.RerunFailedJobs(pr, 123); err != nil {
+				t.Errorf("RerunFailedJobs() error = %v", err)
+			}
+
+			want := []string{
+				`POST /repos/org/api/pulls/7/reviews {"body":"LGTM","commit_id":"c0ffee","event":"APPROVE"}`,
+				`POST /repos/org/api/pulls/7/reviews {"commit_id":"c0ffee","event":"APPROVE"}`,
+				`POST /repos/org/api/issues/7/comments {"body":"Rebased"}`,
+				`PUT /repos/org/api/pulls/7/merge {"merge_method":"squash","sha":"c0ffee"}`,
+				`POST /repos/org/api/actions/runs/123/rerun-failed-jobs {}`,
+			}
+			if strings.Join(srv.writes, "\n") != strings.Join(want, "\n") {
+				t.Errorf("writes =\n%s\nwant\n%s", strings.Join(srv.writes, "\n"), strings.Join(want, "\n"))
+			}
+		})
+	}
+}
+
+func TestClientContract_Write_OutdatedHead(t *testing.T) {
+	for _, backend := range contractBackends() {
+		t.Run(backend.name, func(t *testing.T) {
+			srv := newFakeGitHub(t)
+			c := backend.newClient(srv, srv.token).(WriteClient)
+			pr := &models.PR{Number: 7, RepoOwner: "org", RepoName: "api", HeadSHA: fakeStaleSHA}
+
+			for action, err := range map[string]error{
+				"approve": c.ApprovePR(pr, ""),
+				"merge":   c.MergePR(pr, MergeMethodMerge),
+			} {
+				var actionErr *ActionError
+				if !errors.As(err, &actionErr) {
+					t.Fatalf("%s: error = %v (%T), want ActionError", action, err, err)
+				}
+				if actionErr.Action != action || !strings.Contains(actionErr.Message, "Head branch was modified") {
+					t.Errorf("%s: error = %+v", action, actionErr)
+				}
+			}
+			if len(srv.writes) != 0 {
+				t.Errorf("writes = %v, want none", srv.writes)
+			}
+		})
+	}
+}
+
+func TestClientContract_Write_Refused(t *testing.T) {
+	for _, backend := range contractBackends() {
+		t.Run(backend.name, func(t *testing.T) {
+			srv := newFakeGitHub(t)
+			c := backend.newClient(srv, srv.token).(WriteClient)
+			pr := &models.PR{Number: fakeUnmergeablePR, RepoOwner: "org", RepoName: "api"}
+
+			err := c.MergePR(pr, MergeMethodMerge)
+			var actionErr *ActionError
+			if !errors.As(err, &actionErr) {
+				t.Fatalf("MergePR() error = %v (%T), want ActionError", err, err)
+			}
+			if actionErr.Action != "merge" || actionErr.PR != "org/api#8" || !strings.Contains(actionErr.Message, "not mergeable") {
+				t.Errorf("MergePR() error = %+v", actionErr)
+			}
+			if len(srv.writes) != 0 {
+				t.Errorf("writes = %v, want none", srv.writes)
+			}
+		})
+	}
+}
@@ -3,8 +3,11 @@ package github
 import (
 	"errors"
 	"os/exec"
+	"strings"
 	"testing"
 	"time"
+
+	"prt/internal/models"
 )
 
 // testRetryer creates a Retryer with no delays for testing.
@@ -295,7 +298,7 @@ func TestListPRs_Success(t *testing.T) {
 	}
 
 	// Use current directory (exists) for testing
-	prs, err := c.ListPRs(".")
+	prs, _, err := c.ListPRs(&models.Repository{Path: "."})
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -324,7 +327,7 @@ func TestListPRs_EmptyArray(t *testing.T) {
 		retryer: testRetryer(),
 	}
 
-	prs, err := c.ListPRs(".")
+	prs, _, err := c.ListPRs(&models.Repository{Path: "."})
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -343,7 +346,7 @@ func TestListPRs_EmptyOutput(t *testing.T) {
 		retryer: testRetryer(),
 	}
 
-	prs, err := c.ListPRs(".")
+	prs, _, err := c.ListPRs(&models.Repository{Path: "."})
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -367,7 +370,7 @@ func TestListPRs_CommandArgs(t *testing.T) {
 		retryer: testRetryer(),
 	}
 
-	c.ListPRs(".")
+	c.ListPRs(&models.Repository{Path: "."})
 
 	if capturedName != "gh" {
 		t.Errorf("expected command 'gh', got %q", capturedName)
@@ -408,6 +411,96 @@ func TestListPRs_CommandArgs(t *testing.T) {
 	}
 }
 
+func TestListPRs_BodyField(t *testing.T) {
+	for _, fetchBody := range []bool{false, true} {
+		var fields string
+		c := &client{
+			execLookPath: exec.LookPath,
+			execCommand: func(name string, arg ...string) *exec.Cmd {
+				for i, a := range arg {
+					if a == "--json" && i+1 < len(arg) {
+						fields = arg[i+1]
+					}
+				}
+				return exec.Command("echo", "[]")
+			},
+			retryer:   testRetryer(),
+			fetchBody: fetchBody,
+		}
+
+		c.ListPRs(&models.Repository{Path: "."})
+
+		if got := strings.HasSuffix(fields, ",body"); got != fetchBody {
+			t.Errorf("fetchBody %v: --json %q, want body requested = %v", fetchBody, fields, fetchBody)
+		}
+	}
+}
+
+func TestListPRs_LimitAndTruncation(t *testing.T) {
+	var capturedArgs []string
+
+	c := &client{
+		execLookPath: exec.LookPath,
+		execCommand: func(name string, arg ...string) *exec.Cmd {
+			capturedArgs = arg
+			return exec.Command("echo", `[
+				{"number": 3, "title": "c", "state": "OPEN", "createdAt": "2024-12-15T10:30:00Z"},
+				{"number": 2, "title": "b", "state": "OPEN", "createdAt": "2024-12-14T10:30:00Z"},
+				{"number": 1, "title": "a", "state": "OPEN", "createdAt": "2024-12-13T10:30:00Z"}
+			]`)
+		},
+		retryer: testRetryer(),
+		maxPRs:  2,
+	}
+
+	prs, truncated, err := c.ListPRs(&models.Repository{Path: "."})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(strings.Join(capturedArgs, " "), "--limit 3") {
+		t.Errorf("args %v: expected --limit 3 (cap + 1)", capturedArgs)
+	}
+	if len(prs) != 2 || !truncated {
+		t.Errorf("got %d PRs (truncated=%v), want 2 and truncated", len(prs), truncated)
+	}
+	if prs[0].Number != 3 {
+		t.Errorf("expected newest PRs to be kept, got #%d first", prs[0].Number)
+	}
+}
+
+func TestClient_EnterpriseHostArgs(t *testing.T) {
+	var calls [][]string
+
+	c := &client{
+		execLookPath: func(file string) (string, error) { return "/usr/bin/gh", nil },
+		execCommand: func(name string, arg ...string) *exec.Cmd {
+			calls = append(calls, arg)
+			return exec.Command("echo", "[]")
+		},
+		retryer: testRetryer(),
+		host:    "ghe.corp.com",
+	}
+
+	c.Check()
+	c.GetCurrentUser()
+	c.ListPRs(&models.Repository{Host: "ghe.corp.com", Owner: "corp", Name: "svc", Path: "."})
+	c.ListPRsBatch([]*models.Repository{{Host: "ghe.corp.com", Owner: "corp", Name: "svc"}})
+
+	for _, args := range calls {
+		joined := strings.Join(args, " ")
+		switch {
+		case strings.HasPrefix(joined, "pr list"):
+			if !strings.Contains(joined, "--repo ghe.corp.com/corp/svc") {
+				t.Errorf("pr list args %q: expected --repo ghe.corp.com/corp/svc", joined)
+			}
+		default:
+			if !strings.Contains(joined, "--hostname ghe.corp.com") {
+				t.Errorf("args %q: expected --hostname ghe.corp.com", joined)
+			}
+		}
+	}
+}
+
 func TestListPRs_MultiplePRs(t *testing.T) {
 	validJSON := `[
 		{
@@ -450,7 +543,7 @@ func TestListPRs_MultiplePRs(t *testing.T) {
 		retryer: testRetryer(),
 	}
 
-	prs, err := c.ListPRs(".")
+	prs, _, err := c.ListPRs(&models.Repository{Path: "."})
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -486,7 +579,7 @@ func TestListPRs_RetriesOnTransientError(t *testing.T) {
 		retryer: testRetryer(),
 	}
 
-	prs, err := c.ListPRs(".")
+	prs, _, err := c.ListPRs(&models.Repository{Path: "."})
 	if err != nil {
 		t.Fatalf("expected success after retry, got %v", err)
 	}
@@ -638,3 +731,90 @@ func TestCheckAndGetUser_EmptyUsername(t *testing.T) {
 		t.Fatal("expected error for empty username")
 	}
 }
+
+// ListPRsBatch tests
+
+func TestListPRsBatch_Success(t *testing.T) {
+	var capturedArgs []string
+
+	c := &client{
+		execLookPath: exec.LookPath,
+		execCommand: func(name string, arg ...string) *exec.Cmd {
+			capturedArgs = arg
+			return exec.Command("echo", batchResponseJSON)
+		},
+		retryer: testRetryer(),
+	}
+
+	repos := []*models.Repository{
+		{Owner: "org", Name: "api"},
+		{Owner: "org", Name: "web"},
+		{Owner: "org", Name: "gone"},
+	}
+
+	results := c.ListPRsBatch(repos)
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	if results[0].Err != nil || len(results[0].PRs) != 1 {
+		t.Errorf("expected 1 PR for first repo, got %d (err %v)", len(results[0].PRs), results[0].Err)
+	}
+	if results[2].Err == nil {
+		t.Error("expected error for missing repo")
+	}
+
+	if len(capturedArgs) < 2 || capturedArgs[0] != "api" || capturedArgs[1] != "graphql" {
+		t.Errorf("expected 'api graphql' args, got %v", capturedArgs)
+	}
+	joined := strings.Join(capturedArgs, " ")
+	for _, want := range []string{"o0=org", "n0=api", "n1=web", "n2=gone"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("expected args to contain %q", want)
+		}
+	}
+}
+
+func TestListPRsBatch_PartialDataOnNonZeroExit(t *testing.T) {
+	// gh exits 1 when the response contains GraphQL errors, but still
+	// prints the partial data we need.
+	c := &client{
+		execLookPath: exec.LookPath,
+		execCommand: func(name string, arg ...string) *exec.Cmd {
+			return exec.Command("sh", "-c", `printf '%s' "$0"; exit 1`, batchResponseJSON)
+		},
+		retryer: testRetryer(),
+	}
+
+	repos := []*models.Repository{
+		{Owner: "org", Name: "api"},
+		{Owner: "org", Name: "web"},
+		{Owner: "org", Name: "gone"},
+	}
+
+	results := c.ListPRsBatch(repos)
+	if results[0].Err != nil || len(results[0].PRs) != 1 {
+		t.Errorf("expected partial data to be used, got %d PRs (err %v)", len(results[0].PRs), results[0].Err)
+	}
+}
+
+func TestListPRsBatch_CommandFails(t *testing.T) {
+	c := &client{
+		execLookPath: exec.LookPath,
+		execCommand: func(name string, arg ...string) *exec.Cmd {
+			return exec.Command("sh", "-c", "echo 'HTTP 404: Not Found' >&2; exit 1")
+		},
+		retryer: testRetryer(),
+	}
+
+	repos := []*models.Repository{{Owner: "org", Name: "api"}, {Owner: "org", Name: "web"}}
+
+	results := c.ListPRsBatch(repos)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	for i, r := range results {
+		if r.Err == nil {
+			t.Errorf("result %d: expected error when the whole request fails", i)
+		}
+	}
+}
@@ -1,9 +1,12 @@
-// Package github provides integration with the gh CLI for PR operations.
+// Package github provides integration with GitHub for PR operations,
+// either through the gh CLI or directly through the GitHub API.
 package github
 
 import (
 	"fmt"
+	"net/http"
 	"os/exec"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -86,6 +89,18 @@ func (e *RepoNotFoundError) Error() string {
 	return fmt.Sprintf("repository not found or no access: %s", e.RepoPath)
 }
 
+// ActionError indicates GitHub refused an action on a PR, e.g. merging
+// one that has conflicts.
+type ActionError struct {
+	Action  string // e.g. "merge"
+	PR      string // The PR's key
+	Message string
+}
+
+func (e *ActionError) Error() string {
+	return fmt.Sprintf("failed to %s %s: %s", e.Action, e.PR, e.Message)
+}
+
 // ClassifyError examines an error from gh CLI execution and returns
 // a more specific error type based on the error message/stderr.
 func ClassifyError(err error, repoPath string) error {
@@ -131,6 +146,46 @@ func ClassifyError(err error, repoPath string) error {
 	}
 }
 
+// ClassifyHTTPError converts a non-2xx GitHub API response into the same
+// error types ClassifyError produces for gh CLI failures.
+func ClassifyHTTPError(resp *http.Response, body []byte, repoPath string) error {
+	msg := apiErrorMessage(body)
+
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		return &GHAuthError{
+			Message: fmt.Sprintf(`GitHub API rejected the token (401: %s).
+
+Check that GH_TOKEN or GITHUB_TOKEN is set to a valid token.`, msg),
+		}
+
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		if resp.Header.Get("X-RateLimit-Remaining") == "0" || containsAny(msg, "", "rate limit") {
+			return &RateLimitError{ResetTime: rateLimitReset(resp)}
+		}
+		return &GHAuthError{Message: fmt.Sprintf("GitHub API access denied (%d): %s", resp.StatusCode, msg)}
+
+	case http.StatusNotFound:
+		return &RepoNotFoundError{RepoPath: repoPath}
+	}
+
+	// Default to generic repo scan error (retriable, e.g. 502s)
+	return &RepoScanError{
+		RepoPath: repoPath,
+		Cause:    fmt.Errorf("HTTP %d: %s", resp.StatusCode, msg),
+	}
+}
+
+// rateLimitReset parses the X-RateLimit-Reset header (Unix seconds).
+// Returns the zero time if the header is missing or malformed.
+func rateLimitReset(resp *http.Response) time.Time {
+	secs, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(secs, 0)
+}
+
 // containsAny checks if any of the needles are found in s1 or s2 (case-insensitive).
 func containsAny(s1, s2 string, needles ...string) bool {
 	s1Lower := strings.ToLower(s1)
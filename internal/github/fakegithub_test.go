@@ -0,0 +1,502 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"prt/internal/models"
+)
+
+// fakeGitHub is an httptest server implementing the small slice of the
+// GitHub API that PRT uses: GET /user, GET /user/teams, POST /graphql, the
+// conditional GET /repos/{owner}/{name}/pulls used to revalidate cached
+// PRs, and the endpoints that act on PRs (see WriteClient).
+type fakeGitHub struct {
+	*httptest.Server
+	token string
+	login string
+	// teams are the user's teams as "org/team-slug"
+	teams []string
+	// repos maps "owner/name" to a JSON array of GraphQL pull request nodes
+	repos map[string]string
+
+	mu sync.Mutex
+	// writes records the requests that act on PRs, as "METHOD path body"
+	writes []string
+}
+
+// fakeUnmergeablePR is the number of the PR that can't be merged.
+const fakeUnmergeablePR = 8
+
+// fakeStaleSHA is a head commit that commits were pushed on top of, so
+// approvals and merges naming it are refused.
+const fakeStaleSHA = "5ca1ab1e"
+
+// fakePRNodes is the fixture served for org/api.
+const fakePRNodes = `[{
+  "number": 7,
+  "title": "Add rate limiting",
+  "url": "https://github.com/org/api/pull/7",
+  "author": {"login": "alice"},
+  "state": "OPEN",
+  "isDraft": true,
+  "createdAt": "2024-12-15T10:30:00Z",
+  "baseRefName": "main",
+  "headRefName": "rate-limit",
+  "headRefOid": "c0ffee",
+  "reviewRequests": {"nodes": [{"requestedReviewer": {"login": "octocat"}}]},
+  "assignees": {"nodes": []},
+  "reviews": {"nodes": [{"author": {"login": "bob"}, "state": "CHANGES_REQUESTED", "submittedAt": "2024-12-16T10:30:00Z"}]},
+  "commits": {"nodes": [{"commit": {"statusCheckRollup": {"contexts": {"nodes": [
+    {"__typename": "CheckRun", "name": "test", "status": "COMPLETED", "conclusion": "FAILURE"}
+  ]}}}}]}
+}]`
+
+// fakeBusyPRCount is the number of open PRs in org/busy, enough to need
+// several pages.
+const fakeBusyPRCount = 120
+
+// fakeBusyPRNodes generates the open PRs of org/busy, newest first.
+func fakeBusyPRNodes() string {
+	nodes := make([]string, fakeBusyPRCount)
+	for i := range nodes {
+		n := fakeBusyPRCount - i
+		nodes[i] = fmt.Sprintf(`{"number": %d, "title": "Change %d", "url": "https://github.com/org/busy/pull/%d",
+  "author": {"login": "alice"}, "state": "OPEN", "createdAt": "2024-12-15T10:30:00Z",
+  "baseRefName": "main", "headRefName": "change-%d"}`, n, n, n, n)
+	}
+	return "[" + strings.Join(nodes, ",") + "]"
+}
+
+// newFakeGitHub starts a fake GitHub API server. It is closed on test cleanup.
+func newFakeGitHub(t *testing.T) *fakeGitHub {
+	f := &fakeGitHub{
+		token: "test-token",
+		login: "octocat",
+		repos: map[string]string{
+			"org/api":   fakePRNodes,
+			"org/empty": `[]`,
+			"org/busy":  fakeBusyPRNodes(),
+		},
+	}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.handle))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeGitHub) handle(w http.ResponseWriter, r *http.Request) {
+	auth := r.Header.Get("Authorization")
+	if auth != "bearer "+f.token && auth != "token "+f.token {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"message": "Bad credentials"}`)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/user":
+		fmt.Fprintf(w, `{"login": %q}`, f.login)
+
+	case r.Method == http.MethodGet && r.URL.Path == "/user/teams":
+		f.writeTeams(w, r)
+
+	case r.Method == http.MethodPost && r.URL.Path == "/graphql":
+		var req struct {
+			Query     string            `json:"query"`
+			Variables map[string]string `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.writeGraphQL(w, req.Variables)
+
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/repos/") && strings.HasSuffix(r.URL.Path, "/pulls"):
+		f.writePulls(w, r)
+
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/repos/") && strings.Contains(r.URL.Path, "/pulls/"):
+		f.writePull(w, r)
+
+	case (r.Method == http.MethodPost || r.Method == http.MethodPut) && strings.HasPrefix(r.URL.Path, "/repos/"):
+		f.act(w, r)
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message": "Not Found"}`)
+	}
+}
+
+// writePulls answers the REST open PR listing with an ETag derived from the
+// repository's fixture, honoring If-None-Match like GitHub does.
+func (f *fakeGitHub) writePulls(w http.ResponseWriter, r *http.Request) {
+	fullName := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/repos/"), "/pulls")
+	nodes, ok := f.repos[fullName]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message": "Not Found"}`)
+		return
+	}
+
+	h := fnv.New32a()
+	io.WriteString(h, nodes)
+	etag := fmt.Sprintf(`W/"%x"`, h.Sum32())
+
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	fmt.Fprint(w, `[]`)
+}
+
+// writePull answers the REST lookup of a single open PR with its number
+// and head commit.
+func (f *fakeGitHub) writePull(w http.ResponseWriter, r *http.Request) {
+	fullName, num, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/repos/"), "/pulls/")
+	var nodes []gqlPR
+	json.Unmarshal([]byte(f.repos[fullName]), &nodes)
+	for _, node := range nodes {
+		if strconv.Itoa(node.Number) == num {
+			fmt.Fprintf(w, `{"number": %d, "head": {"sha": %q}}`, node.Number, node.HeadRefOid)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNotFound)
+	fmt.Fprint(w, `{"message": "Not Found"}`)
+}
+
+// act answers a request that acts on a PR of a known repository, recording
+// it. Merging fakeUnmergeablePR fails like merging a PR with conflicts, and
+// approving or merging fakeStaleSHA like acting on an outdated head commit.
+func (f *fakeGitHub) act(w http.ResponseWriter, r *http.Request) {
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/repos/"), "/", 3)
+	if len(parts) < 3 || f.repos[parts[0]+"/"+parts[1]] == "" {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message": "Not Found"}`)
+		return
+	}
+	if parts[2] == fmt.Sprintf("pulls/%d/merge", fakeUnmergeablePR) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		fmt.Fprint(w, `{"message": "Pull Request is not mergeable"}`)
+		return
+	}
+
+	body, _ := io.ReadAll(r.Body)
+	var payload map[string]string
+	json.Unmarshal(body, &payload)
+	if payload["sha"] == fakeStaleSHA || payload["commit_id"] == fakeStaleSHA {
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprint(w, `{"message": "Head branch was modified. Review and try the merge again."}`)
+		return
+	}
+
+	f.mu.Lock()
+	f.writes = append(f.writes, strings.TrimSpace(r.Method+" "+r.URL.Path+" "+string(body)))
+	f.mu.Unlock()
+	fmt.Fprint(w, `{}`)
+}
+
+// writeTeams answers one page of the user's teams, paginated by the
+// per_page and page query parameters.
+func (f *fakeGitHub) writeTeams(w http.ResponseWriter, r *http.Request) {
+	perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if perPage <= 0 {
+		perPage = 30
+	}
+	start := max(page-1, 0) * perPage
+	end := min(start+perPage, len(f.teams))
+
+	var teams []map[string]interface{}
+	for _, name := range f.teams[min(start, end):end] {
+		org, slug, _ := strings.Cut(name, "/")
+		teams = append(teams, map[string]interface{}{"slug": slug, "organization": map[string]string{"login": org}})
+	}
+	if teams == nil {
+		teams = []map[string]interface{}{}
+	}
+	json.NewEncoder(w).Encode(teams)
+}
+
+// writeGraphQL answers a batch query by resolving each r<i> alias from the
+// o<i>/n<i> variables, the same way buildBatchQuery parameterizes them.
+// Pages hold prPageSize PRs; the a<i> cursor is the offset of the page.
+func (f *fakeGitHub) writeGraphQL(w http.ResponseWriter, vars map[string]string) {
+	data := make(map[string]json.RawMessage)
+	var errs []gqlError
+
+	for i := 0; ; i++ {
+		idx := strconv.Itoa(i)
+		owner, ok := vars["o"+idx]
+		if !ok {
+			break
+		}
+		fullName := owner + "/" + vars["n"+idx]
+		alias := "r" + idx
+
+		nodes, ok := f.repos[fullName]
+		if !ok {
+			data[alias] = json.RawMessage("null")
+			errs = append(errs, gqlError{
+				Type:    "NOT_FOUND",
+				Path:    []interface{}{alias},
+				Message: fmt.Sprintf("Could not resolve to a Repository with the name '%s'.", fullName),
+			})
+			continue
+		}
+		var all []json.RawMessage
+		json.Unmarshal([]byte(nodes), &all)
+		start, _ := strconv.Atoi(vars["a"+idx])
+		end := start + prPageSize
+		if end > len(all) {
+			end = len(all)
+		}
+		page, _ := json.Marshal(all[start:end])
+		data[alias] = json.RawMessage(fmt.Sprintf(`{"pullRequests": {"pageInfo": {"hasNextPage": %t, "endCursor": "%d"}, "nodes": %s}}`,
+			end < len(all), end, page))
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{"data": data, "errors": errs})
+}
+
+// fakeGHCommand returns an execCommand replacement that runs this test
+// binary as a fake gh CLI (see TestFakeGHProcess) talking to srv.
+func fakeGHCommand(srv *fakeGitHub, token string) func(name string, arg ...string) *exec.Cmd {
+	return func(name string, arg ...string) *exec.Cmd {
+		args := append([]string{"-test.run=^TestFakeGHProcess$", "--"}, arg...)
+		cmd := exec.Command(os.Args[0], args...)
+		cmd.Env = append(os.Environ(),
+			"PRT_FAKE_GH=1",
+			"PRT_FAKE_GH_SERVER="+srv.URL,
+			"PRT_FAKE_GH_TOKEN="+token,
+		)
+		return cmd
+	}
+}
+
+// TestFakeGHProcess is not a real test. It acts as a minimal gh CLI when
+// the test binary is re-executed by fakeGHCommand, translating the gh
+// invocations PRT makes into requests against the fake GitHub server.
+func TestFakeGHProcess(t *testing.T) {
+	if os.Getenv("PRT_FAKE_GH") != "1" {
+		return
+	}
+
+	args := os.Args
+	for i, a := range args {
+		if a == "--" {
+			args = args[i+1:]
+			break
+		}
+	}
+
+	os.Exit(runFakeGH(args, os.Getenv("PRT_FAKE_GH_SERVER"), os.Getenv("PRT_FAKE_GH_TOKEN")))
+}
+
+// runFakeGH executes one fake gh command and returns its exit code.
+func runFakeGH(args []string, server, token string) int {
+	call := func(method, path string, payload interface{}) ([]byte, int) {
+		var body io.Reader
+		if payload != nil {
+			data, _ := json.Marshal(payload)
+			body = bytes.NewReader(data)
+		}
+		req, _ := http.NewRequest(method, server+path, body)
+		req.Header.Set("Authorization", "token "+token)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return nil, 0
+		}
+		defer resp.Body.Close()
+		out, _ := io.ReadAll(resp.Body)
+		return out, resp.StatusCode
+	}
+
+	joined := strings.Join(args, " ")
+
+	switch {
+	case joined == "auth status":
+		if _, status := call(http.MethodGet, "/user", nil); status != http.StatusOK {
+			fmt.Fprintln(os.Stderr, "You are not logged into any GitHub hosts.")
+			return 1
+		}
+		return 0
+
+	case strings.HasPrefix(joined, "api user"):
+		out, status := call(http.MethodGet, "/user", nil)
+		if status != http.StatusOK {
+			fmt.Fprintf(os.Stderr, "HTTP %d: %s\n", status, apiErrorMessage(out))
+			return 1
+		}
+		var user ghUser
+		json.Unmarshal(out, &user)
+		fmt.Println(user.Login)
+		return 0
+
+	case strings.HasPrefix(joined, "api graphql"):
+		vars := make(map[string]string)
+		var query string
+		for i := 2; i+1 < len(args); i += 2 {
+			if args[i] != "-f" {
+				continue
+			}
+			key, value, _ := strings.Cut(args[i+1], "=")
+			if key == "query" {
+				query = value
+			} else {
+				vars[key] = value
+			}
+		}
+		out, status := call(http.MethodPost, "/graphql", map[string]interface{}{"query": query, "variables": vars})
+		if status != http.StatusOK {
+			fmt.Fprintf(os.Stderr, "HTTP %d: %s\n", status, apiErrorMessage(out))
+			return 1
+		}
+		os.Stdout.Write(out)
+		var resp gqlResponse
+		if json.Unmarshal(out, &resp) == nil && len(resp.Errors) > 0 {
+			return 1 // gh exits non-zero on GraphQL errors but keeps stdout
+		}
+		return 0
+
+	case strings.HasPrefix(joined, "pr list"):
+		var repo models.Repository
+		limit := 30 // gh's default
+		withBody := false
+		for i, a := range args {
+			if a == "--json" && i+1 < len(args) {
+				withBody = slices.Contains(strings.Split(args[i+1], ","), "body")
+			}
+			if a == "--repo" && i+1 < len(args) {
+				repo.Owner, repo.Name, _ = strings.Cut(args[i+1], "/")
+			}
+			if a == "--limit" && i+1 < len(args) {
+				limit, _ = strconv.Atoi(args[i+1])
+			}
+		}
+		list := make([]ghPR, 0)
+		cursor := ""
+		for len(list) < limit {
+			query, vars := buildBatchQuery([]*models.Repository{&repo}, []string{cursor}, withBody)
+			out, status := call(http.MethodPost, "/graphql", map[string]interface{}{"query": query, "variables": vars})
+			if status != http.StatusOK {
+				fmt.Fprintf(os.Stderr, "HTTP %d: %s\n", status, apiErrorMessage(out))
+				return 1
+			}
+			var resp gqlResponse
+			json.Unmarshal(out, &resp)
+			if len(resp.Errors) > 0 {
+				fmt.Fprintf(os.Stderr, "GraphQL: %s\n", resp.Errors[0].Message)
+				return 1
+			}
+			var gr gqlRepository
+			json.Unmarshal(resp.Data["r0"], &gr)
+			for _, node := range gr.PullRequests.Nodes {
+				list = append(list, node.toGHPR())
+			}
+			if !gr.PullRequests.PageInfo.HasNextPage {
+				break
+			}
+			cursor = gr.PullRequests.PageInfo.EndCursor
+		}
+		if len(list) > limit {
+			list = list[:limit]
+		}
+		json.NewEncoder(os.Stdout).Encode(list)
+		return 0
+
+	case strings.HasPrefix(joined, "api repos/"):
+		method, jq := http.MethodGet, ""
+		var payload map[string]string
+		for i := 2; i+1 < len(args); i += 2 {
+			switch args[i] {
+			case "--method":
+				method = args[i+1]
+			case "--jq":
+				jq = args[i+1]
+			case "-f":
+				if payload == nil {
+					payload = map[string]string{}
+				}
+				key, value, _ := strings.Cut(args[i+1], "=")
+				payload[key] = value
+			}
+		}
+		var body interface{}
+		if payload != nil {
+			body = payload
+		}
+		out, status := call(method, "/"+args[1], body)
+		if status < 200 || status > 299 {
+			fmt.Fprintf(os.Stderr, "gh: %s (HTTP %d)\n", apiErrorMessage(out), status)
+			return 1
+		}
+		if jq == "" {
+			os.Stdout.Write(out)
+			return 0
+		}
+		// Only simple paths like .head.sha
+		var value interface{}
+		json.Unmarshal(out, &value)
+		for _, key := range strings.Split(strings.TrimPrefix(jq, "."), ".") {
+			obj, _ := value.(map[string]interface{})
+			value = obj[key]
+		}
+		fmt.Println(value)
+		return 0
+
+	case strings.HasPrefix(joined, "pr comment"),
+		strings.HasPrefix(joined, "pr merge"), strings.HasPrefix(joined, "run rerun"):
+		flags := make(map[string]string)
+		for i := 3; i < len(args); i++ {
+			name := strings.TrimPrefix(args[i], "--")
+			if (name == "repo" || name == "body" || name == "match-head-commit") && i+1 < len(args) {
+				flags[name] = args[i+1]
+				i++
+			} else {
+				flags[name] = ""
+			}
+		}
+		repo := "/repos/" + flags["repo"]
+		var method, path string
+		payload := map[string]string{}
+		switch args[1] {
+		case "comment":
+			method, path = http.MethodPost, fmt.Sprintf("%s/issues/%s/comments", repo, args[2])
+			payload["body"] = flags["body"]
+		case "merge":
+			method, path = http.MethodPut, fmt.Sprintf("%s/pulls/%s/merge", repo, args[2])
+			for _, m := range MergeMethods {
+				if _, ok := flags[string(m)]; ok {
+					payload["merge_method"] = string(m)
+				}
+			}
+			if sha, ok := flags["match-head-commit"]; ok {
+				payload["sha"] = sha
+			}
+		case "rerun":
+			method, path = http.MethodPost, fmt.Sprintf("%s/actions/runs/%s/rerun-failed-jobs", repo, args[2])
+		}
+		out, status := call(method, path, payload)
+		if status < 200 || status > 299 {
+			fmt.Fprintf(os.Stderr, "HTTP %d: %s\n", status, apiErrorMessage(out))
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Fprintf(os.Stderr, "fake gh: unsupported command %q\n", joined)
+	return 2
+}
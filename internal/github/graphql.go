@@ -0,0 +1,604 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"prt/internal/models"
+)
+
+// DefaultBatchSize is the default number of repositories per GraphQL query.
+// GitHub limits the number of nodes a single query may touch, so very large
+// batches are rejected; 20 repos keeps us comfortably under that limit.
+const DefaultBatchSize = 20
+
+// BatchClient is implemented by clients that can fetch PRs for several
+// repositories in a single request.
+type BatchClient interface {
+	Client
+	// ListPRsBatch fetches open PRs for all given repositories, which must
+	// all be on the same host (see groupByHost).
+	// The returned slice has one entry per repo, in the same order.
+	ListPRsBatch(repos []*models.Repository) []BatchResult
+}
+
+// BatchResult holds the outcome of fetching a single repository in a batch.
+type BatchResult struct {
+	PRs []*models.PR
+	// Truncated is set when the repository has more open PRs than the
+	// per-repo cap, so PRs holds only the first ones.
+	Truncated bool
+	// Cached is set when the PRs were served from the cache (see NewCachingClient)
+	Cached bool
+	// Closed holds the repository's most recently merged or closed PRs,
+	// except those from its default branch, for orphan detection (see
+	// stacks.MarkOrphans). They carry only their identity and branches.
+	Closed []*models.PR
+	Err    error
+
+	// nextCursor is the cursor of the next page of PRs, if there is one
+	nextCursor string
+}
+
+// prPageSize is the number of PRs requested per repository and page.
+const prPageSize = 50
+
+// closedPageSize is the number of recently merged or closed PRs requested
+// per repository. A stack parent closed longer ago, in a repository where
+// more PRs were updated since, isn't seen, so its children aren't marked as
+// orphans.
+const closedPageSize = 30
+
+// batchHost returns the host shared by a batch of repositories.
+func batchHost(repos []*models.Repository) string {
+	if len(repos) == 0 {
+		return ""
+	}
+	return repos[0].Host
+}
+
+// groupByHost splits repos by host, preserving their relative order, so each
+// group can be sent to its own GraphQL endpoint.
+func groupByHost(repos []*models.Repository) [][]*models.Repository {
+	var groups [][]*models.Repository
+	index := make(map[string]int)
+	for _, r := range repos {
+		host := r.Host
+		if host == "" {
+			host = DefaultHost
+		}
+		i, ok := index[host]
+		if !ok {
+			i = len(groups)
+			index[host] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], r)
+	}
+	return groups
+}
+
+// prFieldsFragment selects the PR fields we need.
+// It mirrors prListJSONFields so both fetch paths fill the same models.PR
+// fields; the %s verb holds bodyField when PR descriptions are fetched.
+const prFieldsFragment = `fragment prFields on PullRequest {
+  number
+  title
+  url
+  author { login }
+  state
+  isDraft
+  isCrossRepository
+  headRepositoryOwner { login }
+%s  createdAt
+  updatedAt
+  baseRefName
+  baseRef { refUpdateRule { requiredStatusCheckContexts } }
+  headRefName
+  headRefOid
+  labels(first: 20) { nodes { name } }
+  milestone { title }
+  additions
+  deletions
+  changedFiles
+  mergeable
+  mergeStateStatus
+  reviewDecision
+  reviewRequests(first: 20) {
+    nodes {
+      requestedReviewer {
+        __typename
+        ... on User { login }
+        ... on Team { slug organization { login } }
+      }
+    }
+  }
+  assignees(first: 20) { nodes { login } }
+  reviews(last: 30) { nodes { author { login } state submittedAt commit { oid } } }
+  commits(last: 1) {
+    nodes {
+      commit {
+        statusCheckRollup {
+          contexts(first: 50) {
+            nodes {
+              __typename
+              ... on CheckRun { name status conclusion detailsUrl }
+              ... on StatusContext { context state targetUrl }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// bodyField selects a PR's description in prFieldsFragment.
+const bodyField = "  body\n"
+
+// repoPRsSelection selects one page of a repository's open PRs, newest
+// first (like gh pr list), so a capped list keeps the most recent PRs.
+// The %d verbs are the alias index used for the owner, name, and cursor
+// variables and the page size; %s holds extra repository fields.
+const repoPRsSelection = `  r%[1]d: repository(owner: $o%[1]d, name: $n%[1]d) {
+    pullRequests(states: OPEN, first: %[2]d, after: $a%[1]d, orderBy: {field: CREATED_AT, direction: DESC}) {
+      pageInfo { hasNextPage endCursor }
+      nodes { ...prFields }
+    }%[3]s
+  }`
+
+// closedPRsSelection selects a repository's default branch and its most
+// recently merged or closed PRs, with just the fields needed to match them
+// against open PRs' base branches. Only first pages include it.
+const closedPRsSelection = `
+    defaultBranchRef { name }
+    closed: pullRequests(states: [MERGED, CLOSED], first: %d, orderBy: {field: UPDATED_AT, direction: DESC}) {
+      nodes { number title url state isCrossRepository headRepositoryOwner { login } baseRefName headRefName }
+    }`
+
+// buildBatchQuery builds an aliased GraphQL query covering all repos.
+// Each repository is aliased as r0, r1, ... and parameterized through
+// variables so owner/name values never need escaping. cursors optionally
+// holds, per repo, the cursor to continue paginating from; repos without
+// one start at their first page, which also selects recently merged and
+// closed PRs. withBody selects the PRs' descriptions too.
+func buildBatchQuery(repos []*models.Repository, cursors []string, withBody bool) (string, map[string]string) {
+	var params, fields []string
+	vars := make(map[string]string, len(repos)*2)
+
+	for i, repo := range repos {
+		idx := strconv.Itoa(i)
+		params = append(params, "$o"+idx+": String!", "$n"+idx+": String!", "$a"+idx+": String")
+		vars["o"+idx] = repo.Owner
+		vars["n"+idx] = repo.Name
+		closed := fmt.Sprintf(closedPRsSelection, closedPageSize)
+		if i < len(cursors) && cursors[i] != "" {
+			vars["a"+idx] = cursors[i]
+			closed = ""
+		}
+		fields = append(fields, fmt.Sprintf(repoPRsSelection, i, prPageSize, closed))
+	}
+
+	var b strings.Builder
+	b.WriteString("query(")
+	b.WriteString(strings.Join(params, ", "))
+	b.WriteString(") {\n")
+	b.WriteString(strings.Join(fields, "\n"))
+	b.WriteString("\n}\n")
+	body := ""
+	if withBody {
+		body = bodyField
+	}
+	fmt.Fprintf(&b, prFieldsFragment, body)
+
+	return b.String(), vars
+}
+
+// fetchBatch fetches all pages of open PRs for repos, up to maxPRs per
+// repository. send performs a single GraphQL request and returns the raw
+// response; each page is retried for transient failures. Only repositories
+// with more pages are included in follow-up requests. withBody fetches the
+// PRs' descriptions too.
+func fetchBatch(repos []*models.Repository, maxPRs int, withBody bool, retryer *Retryer, send func(query string, vars map[string]string) ([]byte, error)) []BatchResult {
+	results := make([]BatchResult, len(repos))
+	cursors := make([]string, len(repos))
+
+	pending := make([]int, len(repos))
+	for i := range pending {
+		pending[i] = i
+	}
+
+	for len(pending) > 0 {
+		pageRepos := make([]*models.Repository, len(pending))
+		pageCursors := make([]string, len(pending))
+		for j, idx := range pending {
+			pageRepos[j] = repos[idx]
+			pageCursors[j] = cursors[idx]
+		}
+
+		query, vars := buildBatchQuery(pageRepos, pageCursors, withBody)
+
+		var page []BatchResult
+		err := retryer.Do(func() error {
+			body, err := send(query, vars)
+			if err != nil {
+				return err
+			}
+			parsed, err := ParseBatchResponse(body, pageRepos)
+			if err != nil {
+				return err
+			}
+			page = parsed
+			return nil
+		})
+
+		if err != nil {
+			for _, idx := range pending {
+				results[idx] = BatchResult{Err: err}
+			}
+			break
+		}
+
+		var next []int
+		for j, idx := range pending {
+			res := &results[idx]
+			if page[j].Err != nil {
+				*res = BatchResult{Err: page[j].Err}
+				continue
+			}
+
+			res.PRs = append(res.PRs, page[j].PRs...)
+			res.Closed = append(res.Closed, page[j].Closed...)
+			more := page[j].nextCursor != ""
+
+			if maxPRs > 0 && len(res.PRs) >= maxPRs {
+				res.Truncated = more || len(res.PRs) > maxPRs
+				res.PRs = res.PRs[:maxPRs]
+				continue
+			}
+			if more {
+				cursors[idx] = page[j].nextCursor
+				next = append(next, idx)
+			}
+		}
+		pending = next
+	}
+
+	for i := range results {
+		if results[i].Err == nil && results[i].PRs == nil {
+			results[i].PRs = []*models.PR{}
+		}
+	}
+
+	return results
+}
+
+// gqlResponse is the envelope of a GraphQL response.
+type gqlResponse struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []gqlError                 `json:"errors"`
+}
+
+// gqlError is a single GraphQL error. Path identifies the aliased field
+// (e.g. "r3") the error belongs to, when the error is repository-specific.
+type gqlError struct {
+	Type    string        `json:"type"`
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path"`
+}
+
+// gqlRepository is the shape of one aliased repository in the response.
+type gqlRepository struct {
+	PullRequests struct {
+		PageInfo struct {
+			HasNextPage bool   `json:"hasNextPage"`
+			EndCursor   string `json:"endCursor"`
+		} `json:"pageInfo"`
+		Nodes []gqlPR `json:"nodes"`
+	} `json:"pullRequests"`
+	// Set on first pages only (see closedPRsSelection)
+	DefaultBranchRef *struct {
+		Name string `json:"name"`
+	} `json:"defaultBranchRef"`
+	Closed struct {
+		Nodes []gqlPR `json:"nodes"`
+	} `json:"closed"`
+}
+
+// gqlPR is the GraphQL shape of a pull request. Connections are nested
+// under "nodes", unlike the flattened lists gh pr list returns.
+type gqlPR struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+	Author struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	State             string  `json:"state"`
+	IsDraft           bool    `json:"isDraft"`
+	IsCrossRepository bool    `json:"isCrossRepository"`
+	HeadOwner         *ghUser `json:"headRepositoryOwner"`
+	Body              string  `json:"body"`
+	CreatedAt         string  `json:"createdAt"`
+	UpdatedAt         string  `json:"updatedAt"`
+	BaseRefName       string  `json:"baseRefName"`
+	BaseRef           *struct {
+		// RefUpdateRule is the base branch's protection, when the viewer
+		// can see it
+		RefUpdateRule *struct {
+			RequiredStatusCheckContexts []string `json:"requiredStatusCheckContexts"`
+		} `json:"refUpdateRule"`
+	} `json:"baseRef"`
+	HeadRefName string `json:"headRefName"`
+	HeadRefOid  string `json:"headRefOid"`
+	Labels      struct {
+		Nodes []ghLabel `json:"nodes"`
+	} `json:"labels"`
+	Milestone        *ghMilestone `json:"milestone"`
+	Additions        int          `json:"additions"`
+	Deletions        int          `json:"deletions"`
+	ChangedFiles     int          `json:"changedFiles"`
+	Mergeable        string       `json:"mergeable"`
+	MergeStateStatus string       `json:"mergeStateStatus"`
+	ReviewDecision   string       `json:"reviewDecision"`
+	ReviewRequests   struct {
+		Nodes []struct {
+			RequestedReviewer gqlReviewer `json:"requestedReviewer"`
+		} `json:"nodes"`
+	} `json:"reviewRequests"`
+	Assignees struct {
+		Nodes []ghUser `json:"nodes"`
+	} `json:"assignees"`
+	Reviews struct {
+		Nodes []ghReview `json:"nodes"`
+	} `json:"reviews"`
+	Commits struct {
+		Nodes []struct {
+			Commit struct {
+				StatusCheckRollup *struct {
+					Contexts struct {
+						Nodes []gqlCheckContext `json:"nodes"`
+					} `json:"contexts"`
+				} `json:"statusCheckRollup"`
+			} `json:"commit"`
+		} `json:"nodes"`
+	} `json:"commits"`
+}
+
+// gqlReviewer is a requested reviewer: a User or a Team.
+type gqlReviewer struct {
+	TypeName     string `json:"__typename"`
+	Login        string `json:"login"`
+	Slug         string `json:"slug"`
+	Organization struct {
+		Login string `json:"login"`
+	} `json:"organization"`
+}
+
+// toGHReviewer converts a reviewer to the gh pr list shape, where teams
+// are named "org/team-slug".
+func (r gqlReviewer) toGHReviewer() ghReviewer {
+	if r.TypeName == "Team" {
+		return ghReviewer{TypeName: r.TypeName, Slug: r.Organization.Login + "/" + r.Slug}
+	}
+	return ghReviewer{TypeName: r.TypeName, Login: r.Login}
+}
+
+// gqlCheckContext is either a CheckRun or a StatusContext.
+type gqlCheckContext struct {
+	TypeName   string `json:"__typename"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+	DetailsURL string `json:"detailsUrl"`
+	Context    string `json:"context"`
+	State      string `json:"state"`
+	TargetURL  string `json:"targetUrl"`
+}
+
+// toGHPR flattens a GraphQL PR into the gh pr list shape so that both
+// fetch paths share convertPR.
+func (p gqlPR) toGHPR() ghPR {
+	gpr := ghPR{
+		Number:            p.Number,
+		Title:             p.Title,
+		URL:               p.URL,
+		State:             p.State,
+		IsDraft:           p.IsDraft,
+		IsCrossRepository: p.IsCrossRepository,
+		HeadOwner:         p.HeadOwner,
+		Body:              p.Body,
+		CreatedAt:         p.CreatedAt,
+		UpdatedAt:         p.UpdatedAt,
+		BaseRefName:       p.BaseRefName,
+		HeadRefName:       p.HeadRefName,
+		HeadRefOid:        p.HeadRefOid,
+		Labels:            p.Labels.Nodes,
+		Milestone:         p.Milestone,
+		Additions:         p.Additions,
+		Deletions:         p.Deletions,
+		ChangedFiles:      p.ChangedFiles,
+		Mergeable:         p.Mergeable,
+		MergeStateStatus:  p.MergeStateStatus,
+		Assignees:         p.Assignees.Nodes,
+		Reviews:           p.Reviews.Nodes,
+		ReviewDecision:    p.ReviewDecision,
+	}
+	gpr.Author.Login = p.Author.Login
+
+	for _, rr := range p.ReviewRequests.Nodes {
+		gpr.ReviewRequests = append(gpr.ReviewRequests, rr.RequestedReviewer.toGHReviewer())
+	}
+
+	// Checks are required or optional only if the base branch's
+	// protection is known
+	var required map[string]bool
+	if p.BaseRef != nil && p.BaseRef.RefUpdateRule != nil {
+		required = make(map[string]bool)
+		for _, name := range p.BaseRef.RefUpdateRule.RequiredStatusCheckContexts {
+			required[name] = true
+		}
+	}
+
+	for _, c := range p.Commits.Nodes {
+		if c.Commit.StatusCheckRollup == nil {
+			continue
+		}
+		for _, ctx := range c.Commit.StatusCheckRollup.Contexts.Nodes {
+			check := ctx.toStatusCheck()
+			if required != nil {
+				isRequired := required[check.Name] || required[check.Context]
+				check.Required = &isRequired
+			}
+			gpr.StatusCheckRollup = append(gpr.StatusCheckRollup, check)
+		}
+	}
+
+	return gpr
+}
+
+// toClosedPR converts a merged or closed PR selected by closedPRsSelection.
+func (p gqlPR) toClosedPR() *models.PR {
+	pr := &models.PR{
+		Number:            p.Number,
+		Title:             p.Title,
+		URL:               p.URL,
+		State:             models.PRState(p.State),
+		IsCrossRepository: p.IsCrossRepository,
+		BaseBranch:        p.BaseRefName,
+		HeadBranch:        p.HeadRefName,
+	}
+	if p.HeadOwner != nil {
+		pr.HeadOwner = p.HeadOwner.Login
+	}
+	return pr
+}
+
+// toStatusCheck converts a check context to the gh pr list shape.
+func (c gqlCheckContext) toStatusCheck() ghStatusCheck {
+	return ghStatusCheck{
+		TypeName:   c.TypeName,
+		Name:       c.Name,
+		Status:     c.Status,
+		Conclusion: c.Conclusion,
+		DetailsURL: c.DetailsURL,
+		Context:    c.Context,
+		State:      c.State,
+		TargetURL:  c.TargetURL,
+	}
+}
+
+// ParseBatchResponse parses a GraphQL batch response for the given repos.
+// Repository-specific errors (e.g. a repo that no longer exists) are
+// reported only for that repo; all other repos still get their PRs.
+func ParseBatchResponse(data []byte, repos []*models.Repository) ([]BatchResult, error) {
+	var resp gqlResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse GraphQL response: %w", err)
+	}
+
+	// Errors without a path (or with no data at all) affect the whole batch
+	repoErrs := make(map[string]gqlError)
+	for _, e := range resp.Errors {
+		alias := errorAlias(e)
+		if alias == "" || resp.Data == nil {
+			return nil, batchError(e)
+		}
+		repoErrs[alias] = e
+	}
+
+	results := make([]BatchResult, len(repos))
+	for i, repo := range repos {
+		alias := "r" + strconv.Itoa(i)
+
+		if e, ok := repoErrs[alias]; ok {
+			results[i].Err = repoError(e, repo)
+			continue
+		}
+
+		raw, ok := resp.Data[alias]
+		if !ok || string(raw) == "null" {
+			results[i].Err = &RepoNotFoundError{RepoPath: repo.Path}
+			continue
+		}
+
+		var gr gqlRepository
+		if err := json.Unmarshal(raw, &gr); err != nil {
+			results[i].Err = &RepoScanError{RepoPath: repo.Path, RepoName: repo.FullName(), Cause: err}
+			continue
+		}
+
+		prs := make([]*models.PR, 0, len(gr.PullRequests.Nodes))
+		for _, node := range gr.PullRequests.Nodes {
+			pr, err := convertPR(node.toGHPR())
+			if err != nil {
+				results[i].Err = &RepoScanError{RepoPath: repo.Path, RepoName: repo.FullName(), Cause: err}
+				break
+			}
+			prs = append(prs, pr)
+		}
+		if results[i].Err == nil {
+			results[i].PRs = prs
+			if gr.PullRequests.PageInfo.HasNextPage {
+				results[i].nextCursor = gr.PullRequests.PageInfo.EndCursor
+			}
+			results[i].Closed = closedPRs(gr)
+		}
+	}
+
+	return results, nil
+}
+
+// closedPRs converts a repository's recently merged and closed PRs. PRs
+// from the default branch (e.g. release merges) are left out: open PRs
+// that target it aren't orphaned by them. A parent's branch usually
+// outlives its merge, as GitHub only retargets the PRs stacked on it when
+// the branch is deleted, so PRs whose branch still exists are kept.
+func closedPRs(gr gqlRepository) []*models.PR {
+	var defaultBranch string
+	if gr.DefaultBranchRef != nil {
+		defaultBranch = gr.DefaultBranchRef.Name
+	}
+
+	var prs []*models.PR
+	for _, node := range gr.Closed.Nodes {
+		if !node.IsCrossRepository && node.HeadRefName == defaultBranch {
+			continue
+		}
+		prs = append(prs, node.toClosedPR())
+	}
+	return prs
+}
+
+// errorAlias returns the top-level alias (e.g. "r3") a GraphQL error refers to.
+func errorAlias(e gqlError) string {
+	if len(e.Path) == 0 {
+		return ""
+	}
+	alias, _ := e.Path[0].(string)
+	return alias
+}
+
+// batchError converts a batch-wide GraphQL error into a typed error.
+func batchError(e gqlError) error {
+	if e.Type == "RATE_LIMITED" || containsAny(e.Message, "", "rate limit") {
+		return &RateLimitError{}
+	}
+	return fmt.Errorf("GraphQL error: %s", e.Message)
+}
+
+// repoError converts a repository-scoped GraphQL error into a typed error.
+func repoError(e gqlError, repo *models.Repository) error {
+	switch e.Type {
+	case "NOT_FOUND":
+		return &RepoNotFoundError{RepoPath: repo.Path}
+	case "RATE_LIMITED":
+		return &RateLimitError{}
+	}
+	return &RepoScanError{
+		RepoPath: repo.Path,
+		RepoName: repo.FullName(),
+		Cause:    fmt.Errorf("%s", e.Message),
+	}
+}
@@ -0,0 +1,373 @@
+package github
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"prt/internal/models"
+)
+
+func TestBuildBatchQuery(t *testing.T) {
+	repos := []*models.Repository{
+		{Owner: "org", Name: "api"},
+		{Owner: "org", Name: "web"},
+	}
+
+	query, vars := buildBatchQuery(repos, []string{"", "Y3Vyc29y"}, false)
+
+	for _, want := range []string{
+		"$o0: String!", "$n0: String!", "$a0: String", "$o1: String!", "$n1: String!", "$a1: String",
+		"r0: repository(owner: $o0, name: $n0)",
+		"r1: repository(owner: $o1, name: $n1)",
+		"after: $a1",
+		"pageInfo { hasNextPage endCursor }",
+		"nodes { ...prFields }",
+		"fragment prFields on PullRequest",
+		"defaultBranchRef { name }",
+		"closed: pullRequests(states: [MERGED, CLOSED]",
+	} {
+		if !strings.Contains(query, want) {
+			t.Errorf("query missing %q", want)
+		}
+	}
+
+	// Only r0 is on its first page, so only it selects closed PRs
+	if n := strings.Count(query, "closed: pullRequests"); n != 1 {
+		t.Errorf("query selects closed PRs %d times, want once", n)
+	}
+
+	// Repos on their first page get no cursor variable (null)
+	wantVars := map[string]string{"o0": "org", "n0": "api", "o1": "org", "n1": "web", "a1": "Y3Vyc29y"}
+	for k, v := range wantVars {
+		if vars[k] != v {
+			t.Errorf("vars[%q] = %q, want %q", k, vars[k], v)
+		}
+	}
+	if len(vars) != len(wantVars) {
+		t.Errorf("expected %d vars, got %d", len(wantVars), len(vars))
+	}
+}
+
+func TestBuildBatchQuery_Body(t *testing.T) {
+	repos := []*models.Repository{{Owner: "org", Name: "api"}}
+
+	query, _ := buildBatchQuery(repos, nil, false)
+	if strings.Contains(query, bodyField) {
+		t.Errorf("query should not select PR descriptions by default:\n%s", query)
+	}
+
+	query, _ = buildBatchQuery(repos, nil, true)
+	if !strings.Contains(query, "headRepositoryOwner { login }\n  body\n  createdAt") {
+		t.Errorf("query should select PR descriptions in the prFields fragment:\n%s", query)
+	}
+}
+
+const batchResponseJSON = `{
+  "data": {
+    "r0": {
+      "pullRequests": {
+        "nodes": [{
+          "number": 42,
+          "title": "Add login",
+          "url": "https://github.com/org/api/pull/42",
+          "author": {"login": "alice"},
+          "state": "OPEN",
+          "isDraft": false,
+          "isCrossRepository": false,
+          "headRepositoryOwner": {"login": "org"},
+          "body": "Depends-on: #41",
+          "createdAt": "2024-12-15T10:30:00Z",
+          "updatedAt": "2024-12-16T10:30:00Z",
+          "baseRefName": "main",
+          "baseRef": {"refUpdateRule": {"requiredStatusCheckContexts": ["build"]}},
+          "headRefName": "login",
+          "headRefOid": "c0ffee",
+          "labels": {"nodes": [{"name": "security"}]},
+          "milestone": {"title": "Q1"},
+          "additions": 42,
+          "deletions": 7,
+          "changedFiles": 3,
+          "mergeable": "MERGEABLE",
+          "mergeStateStatus": "BLOCKED",
+          "reviewDecision": "REVIEW_REQUIRED",
+          "reviewRequests": {"nodes": [
+            {"requestedReviewer": {"__typename": "User", "login": "bob"}},
+            {"requestedReviewer": {"__typename": "Team", "slug": "backend", "organization": {"login": "org"}}},
+            {"requestedReviewer": {"__typename": "Mannequin"}}
+          ]},
+          "assignees": {"nodes": [{"login": "carol"}]},
+          "reviews": {"nodes": [{"author": {"login": "dave"}, "state": "APPROVED", "submittedAt": "2024-12-16T10:30:00Z", "commit": {"oid": "beef"}}]},
+          "commits": {"nodes": [{"commit": {"statusCheckRollup": {"contexts": {"nodes": [
+            {"__typename": "CheckRun", "name": "build", "status": "COMPLETED", "conclusion": "SUCCESS", "detailsUrl": "https://ci/build"},
+            {"__typename": "StatusContext", "context": "ci/lint", "state": "PENDING", "targetUrl": "https://ci/lint"}
+          ]}}}}]}
+        }]
+      }
+    },
+    "r1": {
+      "pullRequests": {"nodes": []},
+      "defaultBranchRef": {"name": "main"},
+      "closed": {"nodes": [
+        {"number": 9, "title": "Refactor", "state": "MERGED", "isCrossRepository": false, "headRepositoryOwner": {"login": "org"}, "baseRefName": "main", "headRefName": "refactor"},
+        {"number": 8, "title": "Release", "state": "MERGED", "isCrossRepository": false, "headRepositoryOwner": {"login": "org"}, "baseRefName": "release", "headRefName": "main"},
+        {"number": 7, "title": "Fork", "state": "CLOSED", "isCrossRepository": true, "headRepositoryOwner": {"login": "bob"}, "baseRefName": "main", "headRefName": "main"}
+      ]}
+    },
+    "r2": null
+  },
+  "errors": [
+    {"type": "NOT_FOUND", "path": ["r2"], "message": "Could not resolve to a Repository with the name 'org/gone'."}
+  ]
+}`
+
+func TestParseBatchResponse(t *testing.T) {
+	repos := []*models.Repository{
+		{Owner: "org", Name: "api", Path: "/code/api"},
+		{Owner: "org", Name: "web", Path: "/code/web"},
+		{Owner: "org", Name: "gone", Path: "/code/gone"},
+	}
+
+	results, err := ParseBatchResponse([]byte(batchResponseJSON), repos)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+
+	// r0: one fully populated PR
+	if results[0].Err != nil {
+		t.Fatalf("r0: unexpected error: %v", results[0].Err)
+	}
+	if len(results[0].PRs) != 1 {
+		t.Fatalf("r0: expected 1 PR, got %d", len(results[0].PRs))
+	}
+	pr := results[0].PRs[0]
+	if pr.Number != 42 || pr.Title != "Add login" || pr.Author != "alice" {
+		t.Errorf("r0: unexpected PR identity: %+v", pr)
+	}
+	if pr.BaseBranch != "main" || pr.HeadBranch != "login" || pr.HeadOwner != "org" {
+		t.Errorf("r0: unexpected branches %s:%s -> %s", pr.HeadOwner, pr.HeadBranch, pr.BaseBranch)
+	}
+	if len(pr.DependsOn) != 1 || pr.DependsOn[0] != "#41" {
+		t.Errorf("r0: DependsOn = %v, want [#41]", pr.DependsOn)
+	}
+	if len(pr.ReviewRequests) != 1 || pr.ReviewRequests[0] != "bob" {
+		t.Errorf("r0: ReviewRequests = %v, want [bob]", pr.ReviewRequests)
+	}
+	if len(pr.TeamReviewRequests) != 1 || pr.TeamReviewRequests[0] != "org/backend" {
+		t.Errorf("r0: TeamReviewRequests = %v, want [org/backend]", pr.TeamReviewRequests)
+	}
+	if len(pr.Assignees) != 1 || pr.Assignees[0] != "carol" {
+		t.Errorf("r0: Assignees = %v, want [carol]", pr.Assignees)
+	}
+	if len(pr.Reviews) != 1 || pr.Reviews[0].State != models.ReviewStateApproved || pr.Reviews[0].CommitSHA != "beef" {
+		t.Errorf("r0: Reviews = %v, want one APPROVED review of commit beef", pr.Reviews)
+	}
+	if len(pr.Labels) != 1 || pr.Labels[0] != "security" {
+		t.Errorf("r0: Labels = %v, want [security]", pr.Labels)
+	}
+	if pr.Additions != 42 || pr.Deletions != 7 || pr.ChangedFiles != 3 {
+		t.Errorf("r0: size = +%d/-%d in %d files, want +42/-7 in 3 files", pr.Additions, pr.Deletions, pr.ChangedFiles)
+	}
+	if pr.Milestone != "Q1" {
+		t.Errorf("r0: Milestone = %q, want Q1", pr.Milestone)
+	}
+	if pr.Mergeable != models.MergeableMergeable || pr.MergeStateStatus != models.MergeStateBlocked {
+		t.Errorf("r0: merge state = %s/%s, want MERGEABLE/BLOCKED", pr.Mergeable, pr.MergeStateStatus)
+	}
+	if pr.ReviewDecision != models.ReviewDecisionReviewRequired {
+		t.Errorf("r0: ReviewDecision = %q, want REVIEW_REQUIRED", pr.ReviewDecision)
+	}
+	if pr.UpdatedAt.IsZero() {
+		t.Error("r0: UpdatedAt should be set")
+	}
+	if pr.HeadSHA != "c0ffee" {
+		t.Errorf("r0: HeadSHA = %q, want c0ffee", pr.HeadSHA)
+	}
+	if pr.CIStatus != models.CIStatusPending {
+		t.Errorf("r0: CIStatus = %v, want pending", pr.CIStatus)
+	}
+	if len(pr.Checks) != 2 {
+		t.Fatalf("r0: Checks = %+v, want 2", pr.Checks)
+	}
+	build, lint := pr.Checks[0], pr.Checks[1]
+	if build.Name != "build" || build.State != "COMPLETED" || build.Conclusion != "SUCCESS" || build.URL != "https://ci/build" {
+		t.Errorf("r0: build check = %+v", build)
+	}
+	if build.Required == nil || !*build.Required {
+		t.Errorf("r0: build check should be required")
+	}
+	if lint.Name != "ci/lint" || lint.State != "PENDING" || lint.URL != "https://ci/lint" {
+		t.Errorf("r0: lint check = %+v", lint)
+	}
+	if lint.Required == nil || *lint.Required {
+		t.Errorf("r0: lint check should be optional")
+	}
+
+	// r1: repo exists but has no open PRs
+	if results[1].Err != nil || len(results[1].PRs) != 0 {
+		t.Errorf("r1: expected no PRs and no error, got %d PRs, err %v", len(results[1].PRs), results[1].Err)
+	}
+
+	// r1: #9 was merged with its branch kept, so PRs stacked on it are
+	// orphaned; #8 from the default branch doesn't orphan PRs targeting
+	// main, but #7 from a fork's main does count
+	closed := results[1].Closed
+	if len(closed) != 2 || closed[0].Number != 9 || closed[1].Number != 7 {
+		t.Fatalf("r1: Closed = %v, want #9 and #7", closed)
+	}
+	if closed[0].State != models.PRStateMerged || closed[0].HeadBranch != "refactor" || closed[0].HeadOwner != "org" {
+		t.Errorf("r1: closed #9 = %+v, want merged from org:refactor", closed[0])
+	}
+	if closed[1].State != models.PRStateClosed || !closed[1].IsCrossRepository || closed[1].HeadOwner != "bob" {
+		t.Errorf("r1: closed #7 = %+v, want closed from bob:main", closed[1])
+	}
+	if len(results[0].Closed) != 0 {
+		t.Errorf("r0: Closed = %v, want none", results[0].Closed)
+	}
+
+	// r2: repository-specific error does not affect the others
+	var notFound *RepoNotFoundError
+	if !errors.As(results[2].Err, &notFound) {
+		t.Errorf("r2: expected RepoNotFoundError, got %T", results[2].Err)
+	}
+}
+
+func TestParseBatchResponse_BatchErrors(t *testing.T) {
+	repos := []*models.Repository{{Owner: "org", Name: "api"}}
+
+	tests := []struct {
+		name      string
+		data      string
+		rateLimit bool
+	}{
+		{
+			name:      "rate limited",
+			data:      `{"errors": [{"type": "RATE_LIMITED", "message": "API rate limit exceeded"}]}`,
+			rateLimit: true,
+		},
+		{
+			name: "query error without path",
+			data: `{"errors": [{"message": "Field 'foo' doesn't exist"}]}`,
+		},
+		{
+			name: "invalid JSON",
+			data: `not json`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseBatchResponse([]byte(tt.data), repos)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			var rl *RateLimitError
+			if errors.As(err, &rl) != tt.rateLimit {
+				t.Errorf("RateLimitError = %v, want %v (err: %v)", !tt.rateLimit, tt.rateLimit, err)
+			}
+		})
+	}
+}
+
+func TestGQLCheckContext_ToStatusCheck(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  gqlCheckContext
+		want ghStatusCheck
+	}{
+		{
+			name: "check run",
+			ctx:  gqlCheckContext{TypeName: "CheckRun", Name: "build", Status: "COMPLETED", Conclusion: "FAILURE", DetailsURL: "https://ci/1"},
+			want: ghStatusCheck{TypeName: "CheckRun", Name: "build", Status: "COMPLETED", Conclusion: "FAILURE", DetailsURL: "https://ci/1"},
+		},
+		{
+			name: "status context",
+			ctx:  gqlCheckContext{TypeName: "StatusContext", Context: "ci/lint", State: "SUCCESS", TargetURL: "https://ci/2"},
+			want: ghStatusCheck{TypeName: "StatusContext", Context: "ci/lint", State: "SUCCESS", TargetURL: "https://ci/2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ctx.toStatusCheck(); got != tt.want {
+				t.Errorf("toStatusCheck() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseBatchResponse_NextCursor(t *testing.T) {
+	data := `{"data": {
+	  "r0": {"pullRequests": {"pageInfo": {"hasNextPage": true, "endCursor": "c1"}, "nodes": []}},
+	  "r1": {"pullRequests": {"pageInfo": {"hasNextPage": false, "endCursor": "c2"}, "nodes": []}}
+	}}`
+	repos := []*models.Repository{{Owner: "org", Name: "a"}, {Owner: "org", Name: "b"}}
+
+	results, err := ParseBatchResponse([]byte(data), repos)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results[0].nextCursor != "c1" {
+		t.Errorf("r0 nextCursor = %q, want c1", results[0].nextCursor)
+	}
+	if results[1].nextCursor != "" {
+		t.Errorf("r1 nextCursor = %q, want none on the last page", results[1].nextCursor)
+	}
+}
+
+func TestFetchBatch_PaginatesOnlyReposWithMorePages(t *testing.T) {
+	srv := newFakeGitHub(t)
+	repos := []*models.Repository{
+		{Owner: "org", Name: "busy"},
+		{Owner: "org", Name: "api"},
+		{Owner: "org", Name: "missing"},
+	}
+
+	var requests []map[string]string
+	send := func(query string, vars map[string]string) ([]byte, error) {
+		requests = append(requests, vars)
+		rec := httptest.NewRecorder()
+		srv.writeGraphQL(rec, vars)
+		return rec.Body.Bytes(), nil
+	}
+
+	results := fetchBatch(repos, 100, false, testRetryer(), send)
+
+	// busy needs a second page; api and missing are done after the first
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+	if requests[1]["n0"] != "busy" || requests[1]["a0"] == "" || len(requests[1]) != 3 {
+		t.Errorf("follow-up request vars = %v, want only org/busy with a cursor", requests[1])
+	}
+
+	if len(results[0].PRs) != 100 || !results[0].Truncated {
+		t.Errorf("busy: got %d PRs (truncated=%v), want 100 and truncated", len(results[0].PRs), results[0].Truncated)
+	}
+	if len(results[1].PRs) != 1 || results[1].Truncated {
+		t.Errorf("api: got %d PRs (truncated=%v), want 1", len(results[1].PRs), results[1].Truncated)
+	}
+	var notFound *RepoNotFoundError
+	if !errors.As(results[2].Err, &notFound) {
+		t.Errorf("missing: error = %v, want RepoNotFoundError", results[2].Err)
+	}
+}
+
+func TestFetchBatch_RequestFailure(t *testing.T) {
+	repos := []*models.Repository{{Owner: "org", Name: "a"}, {Owner: "org", Name: "b"}}
+	send := func(query string, vars map[string]string) ([]byte, error) {
+		return nil, &GHAuthError{Message: "not authenticated"}
+	}
+
+	results := fetchBatch(repos, 10, false, testRetryer(), send)
+	for i, r := range results {
+		var authErr *GHAuthError
+		if !errors.As(r.Err, &authErr) {
+			t.Errorf("results[%d].Err = %v, want GHAuthError", i, r.Err)
+		}
+	}
+}
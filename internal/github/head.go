@@ -0,0 +1,77 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strings"
+
+	"prt/internal/models"
+)
+
+// HeadClient is a Client that can also look up the head commit of a
+// single PR, without listing its repository's open PRs.
+type HeadClient interface {
+	Client
+	// HeadSHA returns the latest commit on the head branch of PR number
+	// in repo.
+	HeadSHA(repo *models.Repository, number int) (string, error)
+}
+
+// prHead is the part of a REST pull request read by HeadSHA.
+type prHead struct {
+	Head struct {
+		SHA string `json:"sha"`
+	} `json:"head"`
+}
+
+// HeadSHA reads the PR with `gh api repos/{owner}/{repo}/pulls/{n}`.
+func (c *client) HeadSHA(repo *models.Repository, number int) (string, error) {
+	args := []string{"api", fmt.Sprintf("repos/%s/pulls/%d", repo.FullName(), number), "--jq", ".head.sha"}
+	out, err := c.execCommand("gh", append(args, hostnameArgs(repo.Host)...)...).Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("failed to fetch %s#%d: %s", repo.FullName(), number, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", ClassifyError(err, repo.Path)
+	}
+
+	sha := strings.TrimSpace(string(out))
+	if sha == "" {
+		return "", fmt.Errorf("empty head commit returned for %s#%d", repo.FullName(), number)
+	}
+	return sha, nil
+}
+
+// HeadSHA reads the PR from GET /repos/{owner}/{repo}/pulls/{n}.
+func (c *apiClient) HeadSHA(repo *models.Repository, number int) (string, error) {
+	token, err := c.token(repo.Host)
+	if err != nil {
+		return "", err
+	}
+
+	endpoint := fmt.Sprintf("%s/repos/%s/pulls/%d", c.endpoints(repo.Host).rest, repo.FullName(), number)
+	body, err := c.do(http.MethodGet, endpoint, token, nil, repo.Path)
+	if err != nil {
+		return "", err
+	}
+
+	var pr prHead
+	if err := json.Unmarshal(body, &pr); err != nil {
+		return "", fmt.Errorf("failed to parse %s#%d: %w", repo.FullName(), number, err)
+	}
+	if pr.Head.SHA == "" {
+		return "", fmt.Errorf("empty head commit returned for %s#%d", repo.FullName(), number)
+	}
+	return pr.Head.SHA, nil
+}
+
+// HeadSHA forwards to the wrapped client: a single PR is never cached.
+func (c *cachingClient) HeadSHA(repo *models.Repository, number int) (string, error) {
+	h, ok := c.Client.(HeadClient)
+	if !ok {
+		return "", fmt.Errorf("the GitHub client can't look up single PRs")
+	}
+	return h.HeadSHA(repo, number)
+}
@@ -0,0 +1,66 @@
+package github
+
+// DefaultMaxPRs is the default maximum number of open PRs fetched per repository.
+const DefaultMaxPRs = 200
+
+// Option configures a Client created by NewClient or NewAPIClient.
+type Option func(*clientOptions)
+
+// clientOptions holds the settings shared by all Client implementations.
+type clientOptions struct {
+	host   string
+	retry  RetryConfig
+	maxPRs int
+	// fetchBody requests PR descriptions, for their Depends-on trailers
+	fetchBody bool
+}
+
+// newClientOptions applies opts on top of the defaults.
+func newClientOptions(opts []Option) clientOptions {
+	o := clientOptions{
+		host:   DefaultHost,
+		retry:  DefaultRetryConfig,
+		maxPRs: DefaultMaxPRs,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithHost sets the host used for auth checks and user lookups, e.g. a
+// GitHub Enterprise Server. PR requests always go to the host recorded on
+// each repository.
+func WithHost(host string) Option {
+	return func(o *clientOptions) {
+		if host != "" {
+			o.host = host
+		}
+	}
+}
+
+// WithRetryConfig sets the retry behavior for transient failures.
+func WithRetryConfig(cfg RetryConfig) Option {
+	return func(o *clientOptions) {
+		o.retry = cfg
+	}
+}
+
+// WithMaxPRs caps the number of open PRs fetched per repository.
+// Repositories with more open PRs are reported as truncated.
+func WithMaxPRs(n int) Option {
+	return func(o *clientOptions) {
+		if n > 0 {
+			o.maxPRs = n
+		}
+	}
+}
+
+// WithPRBody sets whether PR descriptions are fetched, so that their
+// Depends-on trailers can link PRs (see config link_depends_on). They are
+// left out by default, since they add to the cost of every scan.
+func WithPRBody(fetch bool) Option {
+	return func(o *clientOptions) {
+		o.fetchBody = fetch
+	}
+}
@@ -0,0 +1,37 @@
+package github
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewClientOptions(t *testing.T) {
+	o := newClientOptions(nil)
+	if o.host != DefaultHost || o.maxPRs != DefaultMaxPRs || o.retry != DefaultRetryConfig {
+		t.Errorf("unexpected defaults: %+v", o)
+	}
+
+	retry := RetryConfig{MaxAttempts: 1, InitialWait: time.Second, MaxWait: time.Second}
+	o = newClientOptions([]Option{WithHost("ghe.corp.com"), WithMaxPRs(50), WithRetryConfig(retry), WithPRBody(true)})
+	if o.host != "ghe.corp.com" || o.maxPRs != 50 || o.retry != retry || !o.fetchBody {
+		t.Errorf("options not applied: %+v", o)
+	}
+
+	// Zero values keep the defaults
+	o = newClientOptions([]Option{WithHost(""), WithMaxPRs(0)})
+	if o.host != DefaultHost || o.maxPRs != DefaultMaxPRs {
+		t.Errorf("zero values should keep defaults: %+v", o)
+	}
+}
+
+func TestNewClient_AppliesOptions(t *testing.T) {
+	gh, ok := NewClient(WithHost("ghe.corp.com"), WithMaxPRs(10), WithPRBody(true)).(*client)
+	if !ok || gh.host != "ghe.corp.com" || gh.maxPRs != 10 || !gh.fetchBody {
+		t.Errorf("NewClient() did not apply options: %+v", gh)
+	}
+
+	api, ok := NewAPIClient(WithHost("ghe.corp.com"), WithMaxPRs(10), WithPRBody(true)).(*apiClient)
+	if !ok || api.host != "ghe.corp.com" || api.maxPRs != 10 || !api.fetchBody {
+		t.Errorf("NewAPIClient() did not apply options: %+v", api)
+	}
+}
@@ -1,6 +1,7 @@
 package github
 
 import (
+	"slices"
 	"sync"
 
 	"prt/internal/models"
@@ -18,6 +19,7 @@ type FetchProgress func(done, total int, repo *models.Repository)
 type Orchestrator struct {
 	client      Client
 	concurrency int
+	batchSize   int
 }
 
 // NewOrchestrator creates an orchestrator with the given client and default concurrency.
@@ -25,6 +27,7 @@ func NewOrchestrator(client Client) *Orchestrator {
 	return &Orchestrator{
 		client:      client,
 		concurrency: DefaultConcurrency,
+		batchSize:   DefaultBatchSize,
 	}
 }
 
@@ -36,11 +39,16 @@ func NewOrchestratorWithConcurrency(client Client, concurrency int) *Orchestrato
 	return &Orchestrator{
 		client:      client,
 		concurrency: concurrency,
+		batchSize:   DefaultBatchSize,
 	}
 }
 
 // FetchAllPRs fetches PRs from all repositories concurrently.
 // It uses a semaphore to limit concurrency and avoid rate limiting.
+// If the client implements BatchClient, repositories are fetched in batches
+// (one request per batch, never mixing hosts) instead of one request per
+// repository. Repositories already marked ScanStatusSkipped (e.g. remotes on
+// hosts that aren't configured) are reported without being fetched.
 // The progress callback is invoked after each repository completes.
 // Errors are stored in individual repository's ScanError field;
 // this function does not return an error for partial failures.
@@ -55,33 +63,54 @@ func (o *Orchestrator) FetchAllPRs(repos []*models.Repository, progress FetchPro
 	// Semaphore to limit concurrency (avoid rate limiting)
 	sem := make(chan struct{}, o.concurrency)
 
-	for _, repo := range repos {
-		wg.Add(1)
-		go func(r *models.Repository) {
-			defer wg.Done()
-
-			sem <- struct{}{}        // Acquire
-			defer func() { <-sem }() // Release
-
-			prs, err := o.client.ListPRs(r.Path)
-			if err != nil {
-				r.ScanError = err
-				r.ScanStatus = models.ScanStatusError
-			} else if len(prs) == 0 {
-				r.ScanStatus = models.ScanStatusNoPRs
-			} else {
-				r.PRs = prs
-				r.ScanStatus = models.ScanStatusSuccess
-				// Set repo context on each PR
-				for _, pr := range prs {
-					pr.RepoName = r.Name
-					pr.RepoOwner = r.Owner
-					pr.RepoPath = r.Path
+	var fetch []*models.Repository
+	for _, r := range repos {
+		if r.ScanStatus == models.ScanStatusSkipped {
+			results <- r
+			continue
+		}
+		fetch = append(fetch, r)
+	}
+
+	if batcher, ok := o.client.(BatchClient); ok && o.batchSize > 1 {
+		var batches [][]*models.Repository
+		for _, group := range groupByHost(fetch) {
+			batches = append(batches, chunkRepos(group, o.batchSize)...)
+		}
+		for _, batch := range batches {
+			wg.Add(1)
+			go func(b []*models.Repository) {
+				defer wg.Done()
+
+				sem <- struct{}{}        // Acquire
+				defer func() { <-sem }() // Release
+
+				batchResults := batcher.ListPRsBatch(b)
+				for i, r := range b {
+					var res BatchResult
+					if i < len(batchResults) {
+						res = batchResults[i]
+					}
+					applyResult(r, res)
+					results <- r
 				}
-			}
+			}(batch)
+		}
+	} else {
+		for _, repo := range fetch {
+			wg.Add(1)
+			go func(r *models.Repository) {
+				defer wg.Done()
 
-			results <- r
-		}(repo)
+				sem <- struct{}{}        // Acquire
+				defer func() { <-sem }() // Release
+
+				prs, truncated, err := o.client.ListPRs(r)
+				applyResult(r, BatchResult{PRs: prs, Truncated: truncated, Err: err})
+
+				results <- r
+			}(repo)
+		}
 	}
 
 	// Close results channel when all goroutines complete
@@ -101,6 +130,44 @@ func (o *Orchestrator) FetchAllPRs(repos []*models.Repository, progress FetchPro
 	}
 }
 
+// applyResult records the outcome of fetching a repository's PRs on the
+// repository itself, setting ScanStatus/ScanError, the truncation and cache
+// flags, its recently closed PRs, and each PR's repo context.
+func applyResult(r *models.Repository, res BatchResult) {
+	prs, err := res.PRs, res.Err
+	r.Truncated = res.Truncated && err == nil
+	r.Cached = res.Cached && err == nil
+	if err != nil {
+		r.ScanError = err
+		r.ScanStatus = models.ScanStatusError
+	} else if len(prs) == 0 {
+		r.ScanStatus = models.ScanStatusNoPRs
+	} else {
+		r.PRs = prs
+		r.ClosedPRs = res.Closed
+		r.ScanStatus = models.ScanStatusSuccess
+		// Set repo context on each PR
+		for _, pr := range slices.Concat(prs, res.Closed) {
+			pr.RepoName = r.Name
+			pr.RepoOwner = r.Owner
+			pr.RepoPath = r.Path
+		}
+	}
+}
+
+// chunkRepos splits repos into consecutive batches of at most size repos.
+func chunkRepos(repos []*models.Repository, size int) [][]*models.Repository {
+	var batches [][]*models.Repository
+	for start := 0; start < len(repos); start += size {
+		end := start + size
+		if end > len(repos) {
+			end = len(repos)
+		}
+		batches = append(batches, repos[start:end])
+	}
+	return batches
+}
+
 // FetchAllPRs is a convenience function that creates a default orchestrator
 // and fetches PRs from all repositories.
 func FetchAllPRs(repos []*models.Repository, client Client, progress FetchProgress) {
@@ -27,11 +27,12 @@ func (m *mockClient) CheckAndGetUser() (string, error) {
 	return "testuser", nil
 }
 
-func (m *mockClient) ListPRs(repoPath string) ([]*models.PR, error) {
+func (m *mockClient) ListPRs(repo *models.Repository) ([]*models.PR, bool, error) {
 	if m.listPRsFunc != nil {
-		return m.listPRsFunc(repoPath)
+		prs, err := m.listPRsFunc(repo.Path)
+		return prs, false, err
 	}
-	return nil, nil
+	return nil, false, nil
 }
 
 func TestNewOrchestrator(t *testing.T) {
@@ -352,3 +353,240 @@ func TestFetchAllPRs_ProgressCount(t *testing.T) {
 		}
 	}
 }
+
+// mockBatchClient implements BatchClient for testing
+type mockBatchClient struct {
+	mockClient
+	mu      sync.Mutex
+	batches [][]*models.Repository
+	batchFn func(repos []*models.Repository) []BatchResult
+}
+
+func (m *mockBatchClient) ListPRsBatch(repos []*models.Repository) []BatchResult {
+	m.mu.Lock()
+	m.batches = append(m.batches, repos)
+	m.mu.Unlock()
+	return m.batchFn(repos)
+}
+
+func TestFetchAllPRs_UsesBatchClient(t *testing.T) {
+	client := &mockBatchClient{
+		mockClient: mockClient{
+			listPRsFunc: func(repoPath string) ([]*models.PR, error) {
+				t.Error("ListPRs should not be called when batching is available")
+				return nil, nil
+			},
+		},
+		batchFn: func(repos []*models.Repository) []BatchResult {
+			results := make([]BatchResult, len(repos))
+			for i, r := range repos {
+				switch r.Name {
+				case "bad":
+					results[i].Err = errors.New("bad repo")
+				case "empty":
+				default:
+					results[i].PRs = []*models.PR{{Number: 1}}
+				}
+			}
+			return results
+		},
+	}
+
+	repos := []*models.Repository{
+		{Name: "a", Owner: "org", Path: "/a"},
+		{Name: "bad", Owner: "org", Path: "/bad"},
+		{Name: "empty", Owner: "org", Path: "/empty"},
+		{Name: "b", Owner: "org", Path: "/b"},
+		{Name: "c", Owner: "org", Path: "/c"},
+	}
+
+	o := NewOrchestrator(client)
+	o.batchSize = 2
+
+	var progressCalls int32
+	o.FetchAllPRs(repos, func(done, total int, repo *models.Repository) {
+		atomic.AddInt32(&progressCalls, 1)
+	})
+
+	if len(client.batches) != 3 {
+		t.Errorf("expected 3 batches for 5 repos with batch size 2, got %d", len(client.batches))
+	}
+	if progressCalls != 5 {
+		t.Errorf("expected 5 progress calls (one per repo), got %d", progressCalls)
+	}
+
+	want := map[string]models.ScanStatus{
+		"a":     models.ScanStatusSuccess,
+		"bad":   models.ScanStatusError,
+		"empty": models.ScanStatusNoPRs,
+		"b":     models.ScanStatusSuccess,
+		"c":     models.ScanStatusSuccess,
+	}
+	for _, r := range repos {
+		if r.ScanStatus != want[r.Name] {
+			t.Errorf("repo %s: status = %s, want %s", r.Name, r.ScanStatus, want[r.Name])
+		}
+	}
+	if repos[1].ScanError == nil {
+		t.Error("expected ScanError on failed repo")
+	}
+	if repos[0].PRs[0].RepoOwner != "org" || repos[0].PRs[0].RepoPath != "/a" {
+		t.Error("expected repo context to be set on batched PRs")
+	}
+}
+
+func TestChunkRepos(t *testing.T) {
+	repos := make([]*models.Repository, 7)
+	for i := range repos {
+		repos[i] = &models.Repository{}
+	}
+
+	batches := chunkRepos(repos, 3)
+	if len(batches) != 3 {
+		t.Fatalf("expected 3 batches, got %d", len(batches))
+	}
+	if len(batches[0]) != 3 || len(batches[1]) != 3 || len(batches[2]) != 1 {
+		t.Errorf("unexpected batch sizes: %d, %d, %d", len(batches[0]), len(batches[1]), len(batches[2]))
+	}
+}
+
+func TestFetchAllPRs_BatchesByHostAndPassesSkipped(t *testing.T) {
+	client := &mockBatchClient{
+		batchFn: func(repos []*models.Repository) []BatchResult {
+			return make([]BatchResult, len(repos))
+		},
+	}
+
+	skipErr := errors.New("unknown host")
+	repos := []*models.Repository{
+		{Name: "a", Owner: "org", Host: "github.com"},
+		{Name: "b", Owner: "corp", Host: "ghe.corp.com"},
+		{Name: "c", Owner: "org"},
+		{Name: "d", Owner: "grp", Host: "gitlab.com", ScanStatus: models.ScanStatusSkipped, ScanError: skipErr},
+	}
+
+	var progressCalls int32
+	NewOrchestrator(client).FetchAllPRs(repos, func(done, total int, repo *models.Repository) {
+		atomic.AddInt32(&progressCalls, 1)
+	})
+
+	if progressCalls != 4 {
+		t.Errorf("expected 4 progress calls (including skipped), got %d", progressCalls)
+	}
+	if len(client.batches) != 2 {
+		t.Fatalf("expected one batch per host, got %d", len(client.batches))
+	}
+	for _, batch := range client.batches {
+		for _, r := range batch {
+			if r.Name == "d" {
+				t.Error("skipped repo should not be fetched")
+			}
+			if batchHost(batch) != r.Host && !(batchHost(batch) == "" || r.Host == "") {
+				t.Errorf("batch mixes hosts: %q and %q", batchHost(batch), r.Host)
+			}
+		}
+	}
+	if repos[3].ScanStatus != models.ScanStatusSkipped || repos[3].ScanError != skipErr {
+		t.Error("skipped repo status should be left untouched")
+	}
+}
+
+func TestGroupByHost(t *testing.T) {
+	repos := []*models.Repository{
+		{Name: "a"},
+		{Name: "b", Host: "ghe.corp.com"},
+		{Name: "c", Host: "github.com"},
+		{Name: "d", Host: "ghe.corp.com"},
+	}
+
+	groups := groupByHost(repos)
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 groups, got %d", len(groups))
+	}
+	if len(groups[0]) != 2 || groups[0][0].Name != "a" || groups[0][1].Name != "c" {
+		t.Errorf("github.com group = %v, want [a c]", groups[0])
+	}
+	if len(groups[1]) != 2 || groups[1][0].Name != "b" || groups[1][1].Name != "d" {
+		t.Errorf("ghe.corp.com group = %v, want [b d]", groups[1])
+	}
+}
+
+func TestFetchAllPRs_RecordsTruncation(t *testing.T) {
+	client := &mockBatchClient{
+		batchFn: func(repos []*models.Repository) []BatchResult {
+			results := make([]BatchResult, len(repos))
+			for i, r := range repos {
+				results[i].PRs = []*models.PR{{Number: 1}}
+				results[i].Truncated = r.Name == "mono"
+			}
+			return results
+		},
+	}
+
+	repos := []*models.Repository{
+		{Name: "mono", Owner: "org"},
+		{Name: "small", Owner: "org"},
+	}
+	NewOrchestrator(client).FetchAllPRs(repos, nil)
+
+	if !repos[0].Truncated {
+		t.Error("expected mono to be flagged as truncated")
+	}
+	if repos[1].Truncated {
+		t.Error("expected small not to be flagged as truncated")
+	}
+}
+
+func TestFetchAllPRs_RecordsCached(t *testing.T) {
+	client := &mockBatchClient{
+		batchFn: func(repos []*models.Repository) []BatchResult {
+			results := make([]BatchResult, len(repos))
+			for i, r := range repos {
+				results[i].Cached = r.Name == "stable"
+				if r.Name == "broken" {
+					results[i].Cached = true
+					results[i].Err = errors.New("boom")
+				}
+			}
+			return results
+		},
+	}
+
+	repos := []*models.Repository{
+		{Name: "stable", Owner: "org"},
+		{Name: "fresh", Owner: "org"},
+		{Name: "broken", Owner: "org"},
+	}
+	NewOrchestrator(client).FetchAllPRs(repos, nil)
+
+	if !repos[0].Cached {
+		t.Error("expected stable to be flagged as cached")
+	}
+	if repos[1].Cached || repos[2].Cached {
+		t.Error("expected only successful cached results to be flagged")
+	}
+}
+
+func TestFetchAllPRs_RecordsClosedPRs(t *testing.T) {
+	client := &mockBatchClient{
+		batchFn: func(repos []*models.Repository) []BatchResult {
+			results := make([]BatchResult, len(repos))
+			for i := range repos {
+				results[i].PRs = []*models.PR{{Number: 2, HeadBranch: "b", BaseBranch: "a"}}
+				results[i].Closed = []*models.PR{{Number: 1, HeadBranch: "a", State: models.PRStateMerged}}
+			}
+			return results
+		},
+	}
+
+	repos := []*models.Repository{{Name: "api", Owner: "org", Path: "/code/api"}}
+	NewOrchestrator(client).FetchAllPRs(repos, nil)
+
+	closed := repos[0].ClosedPRs
+	if len(closed) != 1 || closed[0].Number != 1 {
+		t.Fatalf("ClosedPRs = %v, want #1", closed)
+	}
+	if closed[0].RepoFullName() != "org/api" || closed[0].RepoPath != "/code/api" {
+		t.Errorf("closed PR repo context = %s at %q, want org/api at /code/api", closed[0].RepoFullName(), closed[0].RepoPath)
+	}
+}
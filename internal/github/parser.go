@@ -18,19 +18,51 @@ type ghPR struct {
 	} `json:"author"`
 	State             string          `json:"state"`
 	IsDraft           bool            `json:"isDraft"`
+	IsCrossRepository bool            `json:"isCrossRepository"`
+	HeadOwner         *ghUser         `json:"headRepositoryOwner"` // Null if the fork was deleted
+	Body              string          `json:"body"`
 	CreatedAt         string          `json:"createdAt"`
+	UpdatedAt         string          `json:"updatedAt"`
 	BaseRefName       string          `json:"baseRefName"`
 	HeadRefName       string          `json:"headRefName"`
+	HeadRefOid        string          `json:"headRefOid"`
+	Labels            []ghLabel       `json:"labels"`
+	Milestone         *ghMilestone    `json:"milestone"`
+	Additions         int             `json:"additions"`
+	Deletions         int             `json:"deletions"`
+	ChangedFiles      int             `json:"changedFiles"`
+	Mergeable         string          `json:"mergeable"`
+	MergeStateStatus  string          `json:"mergeStateStatus"`
 	StatusCheckRollup []ghStatusCheck `json:"statusCheckRollup"`
-	ReviewRequests    []ghUser        `json:"reviewRequests"`
+	ReviewRequests    []ghReviewer    `json:"reviewRequests"`
 	Assignees         []ghUser        `json:"assignees"`
 	Reviews           []ghReview      `json:"reviews"`
+	ReviewDecision    string          `json:"reviewDecision"`
 }
 
-// ghStatusCheck represents a CI status check from gh CLI output.
+// ghStatusCheck represents a CI status check from gh CLI output: a check
+// run (with a name, status, and conclusion) or a commit status (with a
+// context and state).
 type ghStatusCheck struct {
-	Context string `json:"context"`
-	State   string `json:"state"`
+	TypeName   string `json:"__typename"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+	DetailsURL string `json:"detailsUrl"`
+	Context    string `json:"context"`
+	State      string `json:"state"`
+	TargetURL  string `json:"targetUrl"`
+	// Required is set from the base branch's protection where it is known
+	// (see gqlPR.toGHPR); gh pr list doesn't report it
+	Required *bool `json:"-"`
+}
+
+// toCheck converts a status check to a models.Check.
+func (c ghStatusCheck) toCheck() models.Check {
+	if c.TypeName == "CheckRun" || c.Name != "" {
+		return models.Check{Name: c.Name, State: c.Status, Conclusion: c.Conclusion, URL: c.DetailsURL, Required: c.Required}
+	}
+	return models.Check{Name: c.Context, State: c.State, URL: c.TargetURL, Required: c.Required}
 }
 
 // ghUser represents a user reference from gh CLI output.
@@ -38,6 +70,24 @@ type ghUser struct {
 	Login string `json:"login"`
 }
 
+// ghLabel represents a PR label from gh CLI output.
+type ghLabel struct {
+	Name string `json:"name"`
+}
+
+// ghMilestone represents a PR's milestone from gh CLI output.
+type ghMilestone struct {
+	Title string `json:"title"`
+}
+
+// ghReviewer is a requested reviewer from gh CLI output: a user, or a team
+// whose slug gh reports as "org/team-slug".
+type ghReviewer struct {
+	TypeName string `json:"__typename"`
+	Login    string `json:"login"`
+	Slug     string `json:"slug"`
+}
+
 // ghReview represents a code review from gh CLI output.
 type ghReview struct {
 	Author struct {
@@ -45,6 +95,9 @@ type ghReview struct {
 	} `json:"author"`
 	State       string `json:"state"`
 	SubmittedAt string `json:"submittedAt"`
+	Commit      struct {
+		Oid string `json:"oid"`
+	} `json:"commit"`
 }
 
 // ParsePRList parses the JSON output from `gh pr list --json ...` into PR models.
@@ -73,10 +126,46 @@ func convertPR(gpr ghPR) (*models.PR, error) {
 		return nil, fmt.Errorf("invalid createdAt %q: %w", gpr.CreatedAt, err)
 	}
 
-	// Convert reviewRequests to []string
-	reviewRequests := make([]string, len(gpr.ReviewRequests))
-	for i, rr := range gpr.ReviewRequests {
-		reviewRequests[i] = rr.Login
+	// updatedAt is optional so that output from older gh versions still parses
+	var updatedAt time.Time
+	if gpr.UpdatedAt != "" {
+		updatedAt, err = time.Parse(time.RFC3339, gpr.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid updatedAt %q: %w", gpr.UpdatedAt, err)
+		}
+	}
+
+	var milestone string
+	if gpr.Milestone != nil {
+		milestone = gpr.Milestone.Title
+	}
+
+	var headOwner string
+	if gpr.HeadOwner != nil {
+		headOwner = gpr.HeadOwner.Login
+	}
+
+	// Split reviewRequests into users and teams
+	reviewRequests := make([]string, 0, len(gpr.ReviewRequests))
+	var teamReviewRequests []string
+	for _, rr := range gpr.ReviewRequests {
+		switch {
+		case rr.TypeName == "Team" && rr.Slug != "":
+			teamReviewRequests = append(teamReviewRequests, rr.Slug)
+		case rr.Login != "":
+			reviewRequests = append(reviewRequests, rr.Login)
+		}
+	}
+
+	checks := make([]models.Check, len(gpr.StatusCheckRollup))
+	for i, c := range gpr.StatusCheckRollup {
+		checks[i] = c.toCheck()
+	}
+
+	// Convert labels to []string
+	labels := make([]string, len(gpr.Labels))
+	for i, l := range gpr.Labels {
+		labels[i] = l.Name
 	}
 
 	// Convert assignees to []string
@@ -97,51 +186,38 @@ func convertPR(gpr ghPR) (*models.PR, error) {
 			Author:    r.Author.Login,
 			State:     models.ReviewState(r.State),
 			Submitted: submitted,
+			CommitSHA: r.Commit.Oid,
 		}
 	}
 
 	return &models.PR{
-		Number:         gpr.Number,
-		Title:          gpr.Title,
-		URL:            gpr.URL,
-		Author:         gpr.Author.Login,
-		State:          models.PRState(gpr.State),
-		IsDraft:        gpr.IsDraft,
-		BaseBranch:     gpr.BaseRefName,
-		HeadBranch:     gpr.HeadRefName,
-		CreatedAt:      createdAt,
-		CIStatus:       computeCIStatus(gpr.StatusCheckRollup),
-		ReviewRequests: reviewRequests,
-		Assignees:      assignees,
-		Reviews:        reviews,
+		Number:             gpr.Number,
+		Title:              gpr.Title,
+		URL:                gpr.URL,
+		Author:             gpr.Author.Login,
+		State:              models.PRState(gpr.State),
+		IsDraft:            gpr.IsDraft,
+		BaseBranch:         gpr.BaseRefName,
+		HeadBranch:         gpr.HeadRefName,
+		HeadSHA:            gpr.HeadRefOid,
+		IsCrossRepository:  gpr.IsCrossRepository,
+		HeadOwner:          headOwner,
+		DependsOn:          models.ParseDependsOn(gpr.Body),
+		Labels:             labels,
+		Milestone:          milestone,
+		Additions:          gpr.Additions,
+		Deletions:          gpr.Deletions,
+		ChangedFiles:       gpr.ChangedFiles,
+		Mergeable:          models.MergeableState(gpr.Mergeable),
+		MergeStateStatus:   models.MergeStateStatus(gpr.MergeStateStatus),
+		CreatedAt:          createdAt,
+		UpdatedAt:          updatedAt,
+		CIStatus:           models.ComputeCIStatus(checks),
+		Checks:             checks,
+		ReviewRequests:     reviewRequests,
+		TeamReviewRequests: teamReviewRequests,
+		Assignees:          assignees,
+		Reviews:            reviews,
+		ReviewDecision:     models.ReviewDecision(gpr.ReviewDecision),
 	}, nil
 }
-
-// computeCIStatus determines overall CI status from individual status checks.
-// Priority: failing > pending > passing > none
-func computeCIStatus(checks []ghStatusCheck) models.CIStatus {
-	if len(checks) == 0 {
-		return models.CIStatusNone
-	}
-
-	hasFailing := false
-	hasPending := false
-
-	for _, check := range checks {
-		switch check.State {
-		case "FAILURE", "ERROR", "CANCELLED", "TIMED_OUT", "ACTION_REQUIRED":
-			hasFailing = true
-		case "PENDING", "EXPECTED", "QUEUED", "IN_PROGRESS", "WAITING":
-			hasPending = true
-			// SUCCESS, SKIPPED, NEUTRAL are considered passing
-		}
-	}
-
-	if hasFailing {
-		return models.CIStatusFailing
-	}
-	if hasPending {
-		return models.CIStatusPending
-	}
-	return models.CIStatusPassing
-}
@@ -19,6 +19,7 @@ func TestParsePRList(t *testing.T) {
 			"createdAt": "2024-12-15T10:30:00Z",
 			"baseRefName": "main",
 			"headRefName": "feature-auth",
+			"headRefOid": "9f2c1e7",
 			"statusCheckRollup": [
 				{ "context": "ci/build", "state": "SUCCESS" },
 				{ "context": "ci/test", "state": "SUCCESS" }
@@ -28,7 +29,8 @@ func TestParsePRList(t *testing.T) {
 			"reviews": [{
 				"author": { "login": "reviewer1" },
 				"state": "APPROVED",
-				"submittedAt": "2024-12-16T14:00:00Z"
+				"submittedAt": "2024-12-16T14:00:00Z",
+				"commit": { "oid": "4b8d0a3" }
 			}]
 		}]`)
 
@@ -68,6 +70,9 @@ func TestParsePRList(t *testing.T) {
 		if pr.HeadBranch != "feature-auth" {
 			t.Errorf("HeadBranch = %q, want %q", pr.HeadBranch, "feature-auth")
 		}
+		if pr.HeadSHA != "9f2c1e7" {
+			t.Errorf("HeadSHA = %q, want %q", pr.HeadSHA, "9f2c1e7")
+		}
 
 		// Check timestamp
 		expectedTime, _ := time.Parse(time.RFC3339, "2024-12-15T10:30:00Z")
@@ -100,6 +105,9 @@ func TestParsePRList(t *testing.T) {
 		if pr.Reviews[0].State != models.ReviewStateApproved {
 			t.Errorf("Reviews[0].State = %q, want %q", pr.Reviews[0].State, models.ReviewStateApproved)
 		}
+		if pr.Reviews[0].CommitSHA != "4b8d0a3" {
+			t.Errorf("Reviews[0].CommitSHA = %q, want %q", pr.Reviews[0].CommitSHA, "4b8d0a3")
+		}
 	})
 
 	t.Run("draft PR", func(t *testing.T) {
@@ -230,6 +238,25 @@ func TestParsePRList(t *testing.T) {
 		}
 	})
 
+	t.Run("invalid updatedAt timestamp", func(t *testing.T) {
+		data := []byte(`[{
+			"number": 1,
+			"title": "Test",
+			"url": "https://github.com/org/repo/pull/1",
+			"author": { "login": "user" },
+			"state": "OPEN",
+			"createdAt": "2024-12-15T10:30:00Z",
+			"updatedAt": "yesterday",
+			"baseRefName": "main",
+			"headRefName": "branch"
+		}]`)
+
+		_, err := ParsePRList(data)
+		if err == nil {
+			t.Error("ParsePRList() expected error for invalid updatedAt")
+		}
+	})
+
 	t.Run("empty arrays handled correctly", func(t *testing.T) {
 		data := []byte(`[{
 			"number": 1,
@@ -303,131 +330,40 @@ func TestParsePRList(t *testing.T) {
 	})
 }
 
-func TestComputeCIStatus(t *testing.T) {
+func TestGHStatusCheck_ToCheck(t *testing.T) {
+	required := true
+
 	tests := []struct {
-		name   string
-		checks []ghStatusCheck
-		want   models.CIStatus
+		name  string
+		check ghStatusCheck
+		want  models.Check
 	}{
 		{
-			name:   "no checks",
-			checks: []ghStatusCheck{},
-			want:   models.CIStatusNone,
-		},
-		{
-			name:   "nil checks",
-			checks: nil,
-			want:   models.CIStatusNone,
-		},
-		{
-			name: "all passing",
-			checks: []ghStatusCheck{
-				{Context: "ci/build", State: "SUCCESS"},
-				{Context: "ci/test", State: "SUCCESS"},
-			},
-			want: models.CIStatusPassing,
-		},
-		{
-			name: "one failing",
-			checks: []ghStatusCheck{
-				{Context: "ci/build", State: "SUCCESS"},
-				{Context: "ci/test", State: "FAILURE"},
-			},
-			want: models.CIStatusFailing,
-		},
-		{
-			name: "one pending with success",
-			checks: []ghStatusCheck{
-				{Context: "ci/build", State: "SUCCESS"},
-				{Context: "ci/test", State: "PENDING"},
-			},
-			want: models.CIStatusPending,
-		},
-		{
-			name: "failing takes priority over pending",
-			checks: []ghStatusCheck{
-				{Context: "ci/build", State: "PENDING"},
-				{Context: "ci/test", State: "FAILURE"},
-			},
-			want: models.CIStatusFailing,
-		},
-		{
-			name: "error state is failing",
-			checks: []ghStatusCheck{
-				{Context: "ci/build", State: "ERROR"},
-			},
-			want: models.CIStatusFailing,
+			name:  "check run",
+			check: ghStatusCheck{TypeName: "CheckRun", Name: "build", Status: "COMPLETED", Conclusion: "FAILURE", DetailsURL: "https://ci/1"},
+			want:  models.Check{Name: "build", State: "COMPLETED", Conclusion: "FAILURE", URL: "https://ci/1"},
 		},
 		{
-			name: "cancelled is failing",
-			checks: []ghStatusCheck{
-				{Context: "ci/build", State: "CANCELLED"},
-			},
-			want: models.CIStatusFailing,
+			name:  "check run without a type name",
+			check: ghStatusCheck{Name: "build", Status: "IN_PROGRESS"},
+			want:  models.Check{Name: "build", State: "IN_PROGRESS"},
 		},
 		{
-			name: "timed out is failing",
-			checks: []ghStatusCheck{
-				{Context: "ci/build", State: "TIMED_OUT"},
-			},
-			want: models.CIStatusFailing,
+			name:  "status context",
+			check: ghStatusCheck{TypeName: "StatusContext", Context: "ci/lint", State: "SUCCESS", TargetURL: "https://ci/2"},
+			want:  models.Check{Name: "ci/lint", State: "SUCCESS", URL: "https://ci/2"},
 		},
 		{
-			name: "action required is failing",
-			checks: []ghStatusCheck{
-				{Context: "ci/build", State: "ACTION_REQUIRED"},
-			},
-			want: models.CIStatusFailing,
-		},
-		{
-			name: "expected is pending",
-			checks: []ghStatusCheck{
-				{Context: "ci/build", State: "EXPECTED"},
-			},
-			want: models.CIStatusPending,
-		},
-		{
-			name: "queued is pending",
-			checks: []ghStatusCheck{
-				{Context: "ci/build", State: "QUEUED"},
-			},
-			want: models.CIStatusPending,
-		},
-		{
-			name: "in progress is pending",
-			checks: []ghStatusCheck{
-				{Context: "ci/build", State: "IN_PROGRESS"},
-			},
-			want: models.CIStatusPending,
-		},
-		{
-			name: "waiting is pending",
-			checks: []ghStatusCheck{
-				{Context: "ci/build", State: "WAITING"},
-			},
-			want: models.CIStatusPending,
-		},
-		{
-			name: "skipped is passing",
-			checks: []ghStatusCheck{
-				{Context: "ci/build", State: "SKIPPED"},
-			},
-			want: models.CIStatusPassing,
-		},
-		{
-			name: "neutral is passing",
-			checks: []ghStatusCheck{
-				{Context: "ci/build", State: "NEUTRAL"},
-			},
-			want: models.CIStatusPassing,
+			name:  "required",
+			check: ghStatusCheck{Context: "ci/lint", State: "PENDING", Required: &required},
+			want:  models.Check{Name: "ci/lint", State: "PENDING", Required: &required},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := computeCIStatus(tt.checks)
-			if got != tt.want {
-				t.Errorf("computeCIStatus() = %q, want %q", got, tt.want)
+			if got := tt.check.toCheck(); got != tt.want {
+				t.Errorf("toCheck() = %+v, want %+v", got, tt.want)
 			}
 		})
 	}
@@ -443,9 +379,21 @@ func TestParsePRList_RealWorldSample(t *testing.T) {
 			"author": {"login": "alice"},
 			"state": "OPEN",
 			"isDraft": false,
+			"isCrossRepository": true,
+			"headRepositoryOwner": {"id": "U_1", "login": "alice"},
+			"body": "Fixes a crash.\r\n\r\nDepends-on: example/auth#12\r\n",
 			"createdAt": "2024-12-19T09:15:30Z",
+			"updatedAt": "2024-12-19T11:30:00Z",
 			"baseRefName": "main",
 			"headRefName": "fix/nil-pointer",
+			"labels": [{"id": "LA_1", "name": "bug", "color": "d73a4a"}],
+			"milestone": {"number": 3, "title": "v1.2", "description": "", "dueOn": null},
+			"additions": 120,
+			"deletions": 30,
+			"changedFiles": 4,
+			"mergeable": "CONFLICTING",
+			"mergeStateStatus": "DIRTY",
+			"reviewDecision": "APPROVED",
 			"statusCheckRollup": [
 				{"context": "ci/lint", "state": "SUCCESS"},
 				{"context": "ci/test", "state": "SUCCESS"},
@@ -453,8 +401,9 @@ func TestParsePRList_RealWorldSample(t *testing.T) {
 				{"context": "security/scan", "state": "SKIPPED"}
 			],
 			"reviewRequests": [
-				{"login": "bob"},
-				{"login": "carol"}
+				{"__typename": "User", "login": "bob"},
+				{"__typename": "User", "login": "carol"},
+				{"__typename": "Team", "name": "Backend", "slug": "example/backend"}
 			],
 			"assignees": [
 				{"login": "alice"}
@@ -485,10 +434,38 @@ func TestParsePRList_RealWorldSample(t *testing.T) {
 
 	pr := prs[0]
 
-	// Verify multiple review requests
+	// Verify multiple review requests, with teams kept apart from users
 	if len(pr.ReviewRequests) != 2 {
 		t.Errorf("Expected 2 review requests, got %d", len(pr.ReviewRequests))
 	}
+	if len(pr.TeamReviewRequests) != 1 || pr.TeamReviewRequests[0] != "example/backend" {
+		t.Errorf("TeamReviewRequests = %v, want [example/backend]", pr.TeamReviewRequests)
+	}
+
+	if len(pr.Labels) != 1 || pr.Labels[0] != "bug" {
+		t.Errorf("Labels = %v, want [bug]", pr.Labels)
+	}
+	if pr.Additions != 120 || pr.Deletions != 30 || pr.ChangedFiles != 4 {
+		t.Errorf("size = +%d/-%d in %d files, want +120/-30 in 4 files", pr.Additions, pr.Deletions, pr.ChangedFiles)
+	}
+	if pr.Milestone != "v1.2" {
+		t.Errorf("Milestone = %q, want v1.2", pr.Milestone)
+	}
+	if pr.Mergeable != models.MergeableConflicting || pr.MergeStateStatus != models.MergeStateDirty {
+		t.Errorf("merge state = %s/%s, want CONFLICTING/DIRTY", pr.Mergeable, pr.MergeStateStatus)
+	}
+	if pr.ReviewDecision != models.ReviewDecisionApproved {
+		t.Errorf("ReviewDecision = %q, want APPROVED", pr.ReviewDecision)
+	}
+	if !pr.IsCrossRepository || pr.HeadOwner != "alice" {
+		t.Errorf("IsCrossRepository = %v, HeadOwner = %q, want a fork owned by alice", pr.IsCrossRepository, pr.HeadOwner)
+	}
+	if len(pr.DependsOn) != 1 || pr.DependsOn[0] != "example/auth#12" {
+		t.Errorf("DependsOn = %v, want [example/auth#12]", pr.DependsOn)
+	}
+	if want := time.Date(2024, 12, 19, 11, 30, 0, 0, time.UTC); !pr.UpdatedAt.Equal(want) {
+		t.Errorf("UpdatedAt = %v, want %v", pr.UpdatedAt, want)
+	}
 
 	// Verify multiple reviews (history)
 	if len(pr.Reviews) != 2 {
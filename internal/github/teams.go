@@ -0,0 +1,114 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strings"
+)
+
+// TeamLister is implemented by clients that can list the teams the
+// authenticated user belongs to, so review requests to those teams can be
+// treated as requests to the user.
+type TeamLister interface {
+	// ListMyTeams returns the user's teams as "org/team-slug".
+	ListMyTeams() ([]string, error)
+}
+
+// teamsPageSize is the number of teams requested per page of /user/teams.
+const teamsPageSize = 100
+
+// ghTeam is a team as returned by GET /user/teams.
+type ghTeam struct {
+	Slug         string `json:"slug"`
+	Organization struct {
+		Login string `json:"login"`
+	} `json:"organization"`
+}
+
+// parseTeams parses one page of /user/teams into "org/team-slug" names.
+func parseTeams(data []byte) ([]string, error) {
+	var teams []ghTeam
+	if err := json.Unmarshal(data, &teams); err != nil {
+		return nil, fmt.Errorf("failed to parse teams: %w", err)
+	}
+
+	names := make([]string, 0, len(teams))
+	for _, t := range teams {
+		if t.Slug != "" && t.Organization.Login != "" {
+			names = append(names, t.Organization.Login+"/"+t.Slug)
+		}
+	}
+	return names, nil
+}
+
+// ListMyTeams lists the user's teams with `gh api user/teams`, which needs
+// the read:org scope that `gh auth login` grants by default.
+func (c *client) ListMyTeams() ([]string, error) {
+	args := []string{"api", "user/teams", "--paginate", "--jq", `.[] | .organization.login + "/" + .slug`}
+	args = append(args, hostnameArgs(c.host)...)
+
+	var teams []string
+	err := c.retryer.Do(func() error {
+		out, err := c.execCommand("gh", args...).Output()
+		if err != nil {
+			if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+				return fmt.Errorf("failed to list teams: %s", strings.TrimSpace(string(exitErr.Stderr)))
+			}
+			return ClassifyError(err, "")
+		}
+
+		teams = nil
+		for _, line := range strings.Split(string(out), "\n") {
+			if line = strings.TrimSpace(line); line != "" && line != "/" {
+				teams = append(teams, line)
+			}
+		}
+		return nil
+	})
+	return teams, err
+}
+
+// ListMyTeams lists the user's teams from /user/teams on the client's host.
+// The token needs the read:org scope.
+func (c *apiClient) ListMyTeams() ([]string, error) {
+	token, err := c.token(c.host)
+	if err != nil {
+		return nil, err
+	}
+
+	var teams []string
+	for page := 1; ; page++ {
+		url := fmt.Sprintf("%s/user/teams?per_page=%d&page=%d", c.endpoints(c.host).rest, teamsPageSize, page)
+
+		var body []byte
+		err := c.retryer.Do(func() error {
+			b, err := c.do(http.MethodGet, url, token, nil, "")
+			body = b
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list teams: %w", err)
+		}
+
+		names, err := parseTeams(body)
+		if err != nil {
+			return nil, err
+		}
+		teams = append(teams, names...)
+
+		if len(names) < teamsPageSize {
+			return teams, nil
+		}
+	}
+}
+
+// ListMyTeams forwards to the wrapped client, if it can list teams; team
+// memberships are not cached. Otherwise the user has no known teams.
+func (c *cachingClient) ListMyTeams() ([]string, error) {
+	if lister, ok := c.Client.(TeamLister); ok {
+		return lister.ListMyTeams()
+	}
+	return nil, nil
+}
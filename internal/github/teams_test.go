@@ -0,0 +1,101 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"os/exec"
+	"reflect"
+	"testing"
+)
+
+func TestParseTeams(t *testing.T) {
+	data := []byte(`[
+		{"slug": "backend", "organization": {"login": "org"}},
+		{"slug": "", "organization": {"login": "org"}},
+		{"slug": "web", "organization": {"login": "other"}}
+	]`)
+
+	teams, err := parseTeams(data)
+	if err != nil {
+		t.Fatalf("parseTeams() error = %v", err)
+	}
+	if want := []string{"org/backend", "other/web"}; !reflect.DeepEqual(teams, want) {
+		t.Errorf("parseTeams() = %v, want %v", teams, want)
+	}
+
+	if _, err := parseTeams([]byte(`{"message": "nope"}`)); err == nil {
+		t.Error("expected an error for a non-array response")
+	}
+}
+
+func TestListMyTeams_GH(t *testing.T) {
+	var capturedArgs []string
+	c := &client{
+		host:         "ghe.corp.com",
+		execLookPath: exec.LookPath,
+		execCommand: func(name string, arg ...string) *exec.Cmd {
+			capturedArgs = arg
+			return exec.Command("printf", "org/backend\n/\norg/web\n")
+		},
+		retryer: testRetryer(),
+	}
+
+	teams, err := c.ListMyTeams()
+	if err != nil {
+		t.Fatalf("ListMyTeams() error = %v", err)
+	}
+	if want := []string{"org/backend", "org/web"}; !reflect.DeepEqual(teams, want) {
+		t.Errorf("ListMyTeams() = %v, want %v", teams, want)
+	}
+
+	wantArgs := []string{"api", "user/teams", "--paginate", "--jq", `.[] | .organization.login + "/" + .slug`, "--hostname", "ghe.corp.com"}
+	if !reflect.DeepEqual(capturedArgs, wantArgs) {
+		t.Errorf("args = %v, want %v", capturedArgs, wantArgs)
+	}
+}
+
+func TestListMyTeams_GHCommandFails(t *testing.T) {
+	c := &client{
+		execLookPath: exec.LookPath,
+		execCommand: func(name string, arg ...string) *exec.Cmd {
+			return exec.Command("false")
+		},
+		retryer: testRetryer(),
+	}
+
+	if _, err := c.ListMyTeams(); err == nil {
+		t.Error("expected an error when gh fails")
+	}
+}
+
+func TestListMyTeams_API(t *testing.T) {
+	srv := newFakeGitHub(t)
+	for i := 0; i < teamsPageSize+5; i++ {
+		srv.teams = append(srv.teams, fmt.Sprintf("org/team-%d", i))
+	}
+	c := &apiClient{
+		endpoints: func(string) apiEndpoints {
+			return apiEndpoints{rest: srv.URL, graphql: srv.URL + "/graphql"}
+		},
+		resolveToken: func(string) (string, error) { return srv.token, nil },
+		httpClient:   http.DefaultClient,
+		retryer:      testRetryer(),
+	}
+
+	teams, err := c.ListMyTeams()
+	if err != nil {
+		t.Fatalf("ListMyTeams() error = %v", err)
+	}
+	if !reflect.DeepEqual(teams, srv.teams) {
+		t.Errorf("ListMyTeams() returned %d teams, want all %d across pages", len(teams), len(srv.teams))
+	}
+}
+
+func TestListMyTeams_CachingClientWithoutLister(t *testing.T) {
+	c := &cachingClient{Client: &mockClient{}}
+
+	teams, err := c.ListMyTeams()
+	if err != nil || len(teams) != 0 {
+		t.Errorf("ListMyTeams() = %v, %v; want no teams and no error", teams, err)
+	}
+}
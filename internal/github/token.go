@@ -0,0 +1,112 @@
+package github
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultHost is the hostname of public GitHub.
+const DefaultHost = "github.com"
+
+// ResolveToken finds a GitHub API token for host without invoking gh.
+// Lookup order for github.com:
+//  1. GH_TOKEN environment variable
+//  2. GITHUB_TOKEN environment variable
+//  3. oauth_token for the host in gh's hosts.yml
+//
+// Other hosts (GitHub Enterprise Server) use GH_ENTERPRISE_TOKEN and
+// GITHUB_ENTERPRISE_TOKEN instead, matching gh's own behavior.
+//
+// Returns GHAuthError if no token can be found.
+func ResolveToken(host string) (string, error) {
+	if host == "" {
+		host = DefaultHost
+	}
+
+	envVars := tokenEnvVars(host)
+	for _, env := range envVars {
+		if token := os.Getenv(env); token != "" {
+			return token, nil
+		}
+	}
+
+	if token, err := tokenFromHostsFile(ghHostsPath(), host); err == nil && token != "" {
+		return token, nil
+	}
+
+	loginCmd := "gh auth login"
+	if host != DefaultHost {
+		loginCmd += " --hostname " + host
+	}
+
+	return "", &GHAuthError{
+		Message: fmt.Sprintf(`No GitHub token found for %s.
+
+Please set one of:
+  export %s=<token>
+  export %s=<token>
+
+Or authenticate with the GitHub CLI:
+  %s`, host, envVars[0], envVars[1], loginCmd),
+	}
+}
+
+// tokenEnvVars returns the environment variables that may hold a token for
+// host, in lookup order.
+func tokenEnvVars(host string) []string {
+	if host == DefaultHost {
+		return []string{"GH_TOKEN", "GITHUB_TOKEN"}
+	}
+	return []string{"GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN"}
+}
+
+// ghHostsPath returns the location of gh's hosts.yml, honoring the same
+// environment variables gh itself uses.
+func ghHostsPath() string {
+	if dir := os.Getenv("GH_CONFIG_DIR"); dir != "" {
+		return filepath.Join(dir, "hosts.yml")
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "gh", "hosts.yml")
+	}
+	if runtime.GOOS == "windows" {
+		if dir := os.Getenv("AppData"); dir != "" {
+			return filepath.Join(dir, "GitHub CLI", "hosts.yml")
+		}
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "gh", "hosts.yml")
+}
+
+// ghHostEntry is the subset of a hosts.yml entry we need.
+type ghHostEntry struct {
+	OAuthToken string `yaml:"oauth_token"`
+}
+
+// tokenFromHostsFile reads the oauth_token for host from a gh hosts.yml file.
+// Newer gh versions keep tokens in the system keyring instead, in which case
+// no token is present and an empty string is returned.
+func tokenFromHostsFile(path, host string) (string, error) {
+	if path == "" {
+		return "", fmt.Errorf("gh hosts file location unknown")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	var hosts map[string]ghHostEntry
+	if err := yaml.Unmarshal(data, &hosts); err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return hosts[host].OAuthToken, nil
+}
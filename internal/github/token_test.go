@@ -0,0 +1,119 @@
+package github
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// isolateTokenEnv clears token sources so tests don't pick up a real token.
+func isolateTokenEnv(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("GH_TOKEN", "")
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_ENTERPRISE_TOKEN", "")
+	t.Setenv("GITHUB_ENTERPRISE_TOKEN", "")
+	t.Setenv("GH_CONFIG_DIR", dir)
+	return dir
+}
+
+func TestResolveToken_EnvPrecedence(t *testing.T) {
+	isolateTokenEnv(t)
+	t.Setenv("GITHUB_TOKEN", "github-token")
+
+	token, err := ResolveToken("github.com")
+	if err != nil || token != "github-token" {
+		t.Errorf("ResolveToken() = %q, %v; want github-token", token, err)
+	}
+
+	t.Setenv("GH_TOKEN", "gh-token")
+	token, err = ResolveToken("github.com")
+	if err != nil || token != "gh-token" {
+		t.Errorf("ResolveToken() = %q, %v; want GH_TOKEN to take precedence", token, err)
+	}
+}
+
+func TestResolveToken_EnterpriseHost(t *testing.T) {
+	dir := isolateTokenEnv(t)
+	t.Setenv("GH_TOKEN", "public-token")
+
+	hosts := `github.mycorp.com:
+    oauth_token: gho_enterprise
+`
+	if err := os.WriteFile(filepath.Join(dir, "hosts.yml"), []byte(hosts), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// GH_TOKEN only applies to github.com
+	token, err := ResolveToken("github.mycorp.com")
+	if err != nil || token != "gho_enterprise" {
+		t.Errorf("ResolveToken() = %q, %v; want gho_enterprise", token, err)
+	}
+
+	t.Setenv("GH_ENTERPRISE_TOKEN", "env-enterprise")
+	token, err = ResolveToken("github.mycorp.com")
+	if err != nil || token != "env-enterprise" {
+		t.Errorf("ResolveToken() = %q, %v; want GH_ENTERPRISE_TOKEN to take precedence", token, err)
+	}
+
+	t.Setenv("GH_ENTERPRISE_TOKEN", "")
+	_, err = ResolveToken("other.corp.com")
+	var authErr *GHAuthError
+	if !errors.As(err, &authErr) || !strings.Contains(authErr.Message, "--hostname other.corp.com") {
+		t.Errorf("expected GHAuthError mentioning the host, got %v", err)
+	}
+}
+
+func TestResolveToken_HostsFile(t *testing.T) {
+	dir := isolateTokenEnv(t)
+
+	hosts := `github.com:
+    user: octocat
+    oauth_token: gho_fromfile
+    git_protocol: ssh
+`
+	if err := os.WriteFile(filepath.Join(dir, "hosts.yml"), []byte(hosts), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := ResolveToken("github.com")
+	if err != nil || token != "gho_fromfile" {
+		t.Errorf("ResolveToken() = %q, %v; want gho_fromfile", token, err)
+	}
+
+	// Empty host defaults to github.com
+	token, err = ResolveToken("")
+	if err != nil || token != "gho_fromfile" {
+		t.Errorf("ResolveToken(\"\") = %q, %v; want gho_fromfile", token, err)
+	}
+}
+
+func TestResolveToken_NotFound(t *testing.T) {
+	isolateTokenEnv(t)
+
+	_, err := ResolveToken("github.com")
+	var authErr *GHAuthError
+	if !errors.As(err, &authErr) {
+		t.Fatalf("expected GHAuthError, got %T (%v)", err, err)
+	}
+	if authErr.Message == "" {
+		t.Error("expected actionable error message")
+	}
+}
+
+func TestTokenFromHostsFile_KeyringOnly(t *testing.T) {
+	// Newer gh versions store the token in the keyring and omit oauth_token
+	path := filepath.Join(t.TempDir(), "hosts.yml")
+	os.WriteFile(path, []byte("github.com:\n    user: octocat\n"), 0600)
+
+	token, err := tokenFromHostsFile(path, "github.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token != "" {
+		t.Errorf("expected empty token, got %q", token)
+	}
+}
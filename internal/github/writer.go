@@ -0,0 +1,233 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"prt/internal/models"
+)
+
+// WriteClient is a Client that can also act on PRs: review, comment on,
+// and merge them, and re-run their failed checks.
+//
+// Writes are not retried, since repeating one that reached GitHub (e.g. a
+// comment) would repeat its effect.
+//
+// Approvals and merges are tied to pr.HeadSHA, the head commit the user
+// saw: GitHub refuses them if commits were pushed since.
+type WriteClient interface {
+	Client
+	// ApprovePR submits an approving review of pr's head commit, with an
+	// optional body.
+	ApprovePR(pr *models.PR, body string) error
+	// CommentPR adds a comment to pr's conversation.
+	CommentPR(pr *models.PR, body string) error
+	// MergePR merges pr's head commit using method.
+	MergePR(pr *models.PR, method MergeMethod) error
+	// RerunFailedJobs re-runs the failed jobs of one of pr's GitHub Actions
+	// workflow runs (see FailedWorkflowRuns).
+	RerunFailedJobs(pr *models.PR, runID int64) error
+}
+
+// MergeMethod is how a PR's commits are merged into its base branch.
+type MergeMethod string
+
+// MergeMethod constants match the merge methods GitHub offers.
+const (
+	MergeMethodMerge  MergeMethod = "merge"  // Create a merge commit
+	MergeMethodSquash MergeMethod = "squash" // Squash all commits into one
+	MergeMethodRebase MergeMethod = "rebase" // Rebase the commits onto the base branch
+)
+
+// MergeMethods lists the valid merge methods.
+var MergeMethods = []MergeMethod{MergeMethodMerge, MergeMethodSquash, MergeMethodRebase}
+
+// workflowRunPattern matches the run ID in the URL of a GitHub Actions
+// job, e.g. https://github.com/org/api/actions/runs/123/job/456.
+var workflowRunPattern = regexp.MustCompile(`/actions/runs/(\d+)`)
+
+// FailedWorkflowRuns returns the IDs of the GitHub Actions workflow runs
+// behind pr's failing checks, in check order. Failing checks from other CI
+// systems can't be re-run through GitHub and are left out.
+func FailedWorkflowRuns(pr *models.PR) []int64 {
+	var ids []int64
+	for _, check := range pr.FailingChecks() {
+		m := workflowRunPattern.FindStringSubmatch(check.URL)
+		if m == nil {
+			continue
+		}
+		id, err := strconv.ParseInt(m[1], 10, 64)
+		if err == nil && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// prRepository returns the repository pr belongs to, with the host taken
+// from the PR's URL (github.com if it has none).
+func prRepository(pr *models.PR) *models.Repository {
+	repo := &models.Repository{Owner: pr.RepoOwner, Name: pr.RepoName, Path: pr.RepoPath, Host: DefaultHost}
+	if u, err := url.Parse(pr.URL); err == nil && u.Host != "" {
+		repo.Host = strings.ToLower(u.Host)
+	}
+	return repo
+}
+
+// ApprovePR approves pr by posting the review with `gh api`, as
+// `gh pr review` can't name the commit reviewed.
+func (c *client) ApprovePR(pr *models.PR, body string) error {
+	repo := prRepository(pr)
+	args := []string{"api", fmt.Sprintf("repos/%s/pulls/%d/reviews", repo.FullName(), pr.Number),
+		"--method", http.MethodPost, "-f", "event=APPROVE"}
+	if pr.HeadSHA != "" {
+		args = append(args, "-f", "commit_id="+pr.HeadSHA)
+	}
+	if body != "" {
+		args = append(args, "-f", "body="+body)
+	}
+	return c.run(pr, "approve", append(args, hostnameArgs(repo.Host)...)...)
+}
+
+// CommentPR comments on pr with `gh pr comment`.
+func (c *client) CommentPR(pr *models.PR, body string) error {
+	return c.write(pr, "comment on", "pr", "comment", strconv.Itoa(pr.Number), "--body", body)
+}
+
+// MergePR merges pr with `gh pr merge`.
+func (c *client) MergePR(pr *models.PR, method MergeMethod) error {
+	args := []string{"pr", "merge", strconv.Itoa(pr.Number), "--" + string(method)}
+	if pr.HeadSHA != "" {
+		args = append(args, "--match-head-commit", pr.HeadSHA)
+	}
+	return c.write(pr, "merge", args...)
+}
+
+// RerunFailedJobs re-runs a workflow run's failed jobs with
+// `gh run rerun --failed`.
+func (c *client) RerunFailedJobs(pr *models.PR, runID int64) error {
+	return c.write(pr, "re-run checks of", "run", "rerun", strconv.FormatInt(runID, 10), "--failed")
+}
+
+// write runs a gh command against pr's repository (see run).
+func (c *client) write(pr *models.PR, action string, args ...string) error {
+	return c.run(pr, action, append(args, "--repo", repoArg(prRepository(pr)))...)
+}
+
+// run runs a gh command acting on pr. A failure is reported as an
+// ActionError carrying gh's message, or classified if gh printed none.
+func (c *client) run(pr *models.PR, action string, args ...string) error {
+	_, err := c.execCommand("gh", args...).Output()
+	if err == nil {
+		return nil
+	}
+	if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+		return &ActionError{Action: action, PR: pr.Key(), Message: strings.TrimSpace(string(exitErr.Stderr))}
+	}
+	return ClassifyError(err, pr.RepoPath)
+}
+
+// ApprovePR submits an APPROVE review to /repos/{owner}/{repo}/pulls/{n}/reviews.
+func (c *apiClient) ApprovePR(pr *models.PR, body string) error {
+	payload := map[string]string{"event": "APPROVE"}
+	if pr.HeadSHA != "" {
+		payload["commit_id"] = pr.HeadSHA
+	}
+	if body != "" {
+		payload["body"] = body
+	}
+	return c.write(pr, "approve", http.MethodPost, fmt.Sprintf("pulls/%d/reviews", pr.Number), payload)
+}
+
+// CommentPR posts to /repos/{owner}/{repo}/issues/{n}/comments, which holds
+// a PR's conversation.
+func (c *apiClient) CommentPR(pr *models.PR, body string) error {
+	return c.write(pr, "comment on", http.MethodPost, fmt.Sprintf("issues/%d/comments", pr.Number), map[string]string{"body": body})
+}
+
+// MergePR merges pr with PUT /repos/{owner}/{repo}/pulls/{n}/merge.
+func (c *apiClient) MergePR(pr *models.PR, method MergeMethod) error {
+	payload := map[string]string{"merge_method": string(method)}
+	if pr.HeadSHA != "" {
+		payload["sha"] = pr.HeadSHA
+	}
+	return c.write(pr, "merge", http.MethodPut, fmt.Sprintf("pulls/%d/merge", pr.Number), payload)
+}
+
+// RerunFailedJobs re-runs a workflow run's failed jobs with
+// POST /repos/{owner}/{repo}/actions/runs/{id}/rerun-failed-jobs.
+func (c *apiClient) RerunFailedJobs(pr *models.PR, runID int64) error {
+	return c.write(pr, "re-run checks of", http.MethodPost, fmt.Sprintf("actions/runs/%d/rerun-failed-jobs", runID), map[string]string{})
+}
+
+// write sends a request to path under pr's repository on its host. GitHub
+// refusing the action is reported as an ActionError; authentication and
+// rate limit errors keep their types.
+func (c *apiClient) write(pr *models.PR, action, method, path string, payload interface{}) error {
+	repo := prRepository(pr)
+	token, err := c.token(repo.Host)
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("%s/repos/%s/%s", c.endpoints(repo.Host).rest, repo.FullName(), path)
+	_, err = c.do(method, endpoint, token, payload, pr.RepoPath)
+	if scanErr, ok := err.(*RepoScanError); ok {
+		return &ActionError{Action: action, PR: pr.Key(), Message: scanErr.Cause.Error()}
+	}
+	return err
+}
+
+// expire marks pr's repository's cache entry as stale after acting on one
+// of its PRs, so the next run fetches its PRs again.
+func (c *cachingClient) expire(pr *models.PR) {
+	repo := prRepository(pr)
+	entry, ok := c.cache.Get(repo)
+	if !ok {
+		return
+	}
+	entry.FetchedAt = time.Time{}
+	entry.ETag = ""
+	c.cache.Put(repo, entry)
+}
+
+// ApprovePR forwards to the wrapped client and expires the cached PRs.
+func (c *cachingClient) ApprovePR(pr *models.PR, body string) error {
+	return c.forward(pr, func(w WriteClient) error { return w.ApprovePR(pr, body) })
+}
+
+// CommentPR forwards to the wrapped client and expires the cached PRs.
+func (c *cachingClient) CommentPR(pr *models.PR, body string) error {
+	return c.forward(pr, func(w WriteClient) error { return w.CommentPR(pr, body) })
+}
+
+// MergePR forwards to the wrapped client and expires the cached PRs.
+func (c *cachingClient) MergePR(pr *models.PR, method MergeMethod) error {
+	return c.forward(pr, func(w WriteClient) error { return w.MergePR(pr, method) })
+}
+
+// RerunFailedJobs forwards to the wrapped client and expires the cached PRs.
+func (c *cachingClient) RerunFailedJobs(pr *models.PR, runID int64) error {
+	return c.forward(pr, func(w WriteClient) error { return w.RerunFailedJobs(pr, runID) })
+}
+
+// forward runs write on the wrapped client and, once it succeeded,
+// expires the cached PRs of pr's repository.
+func (c *cachingClient) forward(pr *models.PR, write func(w WriteClient) error) error {
+	w, ok := c.Client.(WriteClient)
+	if !ok {
+		return fmt.Errorf("the GitHub client can't act on PRs")
+	}
+	if err := write(w); err != nil {
+		return err
+	}
+	c.expire(pr)
+	return nil
+}
@@ -0,0 +1,86 @@
+package github
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"prt/internal/models"
+)
+
+func TestFailedWorkflowRuns(t *testing.T) {
+	pr := &models.PR{Checks: []models.Check{
+		{Name: "build", Conclusion: "FAILURE", URL: "https://github.com/org/api/actions/runs/123/job/1"},
+		{Name: "test", Conclusion: "FAILURE", URL: "https://github.com/org/api/actions/runs/123/job/2"},
+		{Name: "lint", Conclusion: "SUCCESS", URL: "https://github.com/org/api/actions/runs/456/job/3"},
+		{Name: "e2e", Conclusion: "TIMED_OUT", URL: "https://github.com/org/api/actions/runs/789/job/4"},
+		{Name: "flaky", Conclusion: "FAILURE", URL: "https://github.com/org/api/actions/runs/999/job/5", Ignored: true},
+		{Name: "ci/jenkins", State: "FAILURE", URL: "https://jenkins.example.com/job/api/12"},
+	}}
+
+	if got, want := FailedWorkflowRuns(pr), []int64{123, 789}; !slices.Equal(got, want) {
+		t.Errorf("FailedWorkflowRuns() = %v, want %v", got, want)
+	}
+	if got := FailedWorkflowRuns(&models.PR{}); len(got) != 0 {
+		t.Errorf("FailedWorkflowRuns() without checks = %v, want none", got)
+	}
+}
+
+func TestPRRepository(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://github.com/org/api/pull/7", "github.com"},
+		{"https://GHE.corp.com:8443/org/api/pull/7", "ghe.corp.com:8443"},
+		{"", DefaultHost},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			repo := prRepository(&models.PR{Number: 7, RepoOwner: "org", RepoName: "api", RepoPath: "/code/api", URL: tt.url})
+			if repo.Host != tt.want || repo.FullName() != "org/api" || repo.Path != "/code/api" {
+				t.Errorf("prRepository() = %+v, want org/api on %s", repo, tt.want)
+			}
+		})
+	}
+}
+
+func TestCachingClient_WriteExpiresCache(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	srv := newFakeGitHub(t)
+	inner := &apiClient{
+		endpoints: func(string) apiEndpoints {
+			return apiEndpoints{rest: srv.URL, graphql: srv.URL + "/graphql"}
+		},
+		resolveToken: func(string) (string, error) { return srv.token, nil },
+		httpClient:   http.DefaultClient,
+		retryer:      testRetryer(),
+	}
+	cache := newMemCache()
+	cache.entries["org/api"] = &CacheEntry{FetchedAt: now, PRs: []*models.PR{{Number: 7}}, ETag: `W/"abc"`}
+	cache.entries["org/empty"] = &CacheEntry{FetchedAt: now}
+	c := newTestCachingClient(inner, cache, 5*time.Minute, false, now)
+
+	if err := c.ApprovePR(&models.PR{Number: 7, RepoOwner: "org", RepoName: "api"}, ""); err != nil {
+		t.Fatalf("ApprovePR() error = %v", err)
+	}
+	if entry := cache.entries["org/api"]; !entry.FetchedAt.IsZero() || entry.ETag != "" || len(entry.PRs) != 1 {
+		t.Errorf("org/api entry = %+v, want it expired", entry)
+	}
+
+	// A refused write leaves the cache alone
+	if err := c.MergePR(&models.PR{Number: fakeUnmergeablePR, RepoOwner: "org", RepoName: "empty"}, MergeMethodMerge); err == nil {
+		t.Fatal("MergePR() should fail")
+	}
+	if !cache.entries["org/empty"].FetchedAt.Equal(now) {
+		t.Error("org/empty entry should not be expired by a failed write")
+	}
+
+	// Clients that can't write are reported
+	readOnly := newTestCachingClient(&mockBatchClient{}, cache, 5*time.Minute, false, now)
+	if err := readOnly.CommentPR(&models.PR{Number: 7, RepoOwner: "org", RepoName: "api"}, "hi"); err == nil {
+		t.Error("CommentPR() through a read-only client should fail")
+	}
+}
@@ -0,0 +1,221 @@
+package history
+
+import (
+	"sort"
+	"time"
+
+	"prt/internal/models"
+)
+
+// Category identifies the dashboard section a PR was sorted into. The
+// values are section keys, as in the JSON output; the built-in sections
+// are listed below, along with CategorySnoozed for snoozed and muted PRs.
+type Category string
+
+const (
+	CategoryMyPRs            Category = models.SectionMyPRs
+	CategoryNeedsMyAttention Category = models.SectionNeedsMyAttention
+	CategoryTeamPRs          Category = models.SectionTeamPRs
+	CategoryOtherPRs         Category = models.SectionOtherPRs
+	CategorySnoozed          Category = "snoozed"
+)
+
+// Report lists what changed between two snapshots.
+type Report struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+
+	Opened    []*models.PR   `json:"opened"`
+	Closed    []*models.PR   `json:"closed"` // Merged or closed; snapshots only hold open PRs
+	Moved     []Move         `json:"moved"`
+	CIChanged []CIChange     `json:"ci_changed"`
+	Reviewed  []ReviewChange `json:"reviewed"`
+}
+
+// Move is a PR that changed dashboard sections.
+type Move struct {
+	PR   *models.PR `json:"pr"`
+	From Category   `json:"from"`
+	To   Category   `json:"to"`
+}
+
+// CIChange is a PR whose CI status changed.
+type CIChange struct {
+	PR   *models.PR      `json:"pr"`
+	From models.CIStatus `json:"from"`
+	To   models.CIStatus `json:"to"`
+}
+
+// ReviewChange is a PR that received reviews.
+type ReviewChange struct {
+	PR      *models.PR      `json:"pr"`
+	Reviews []models.Review `json:"reviews"`
+}
+
+// IsEmpty reports whether nothing changed.
+func (r *Report) IsEmpty() bool {
+	return len(r.Opened) == 0 && len(r.Closed) == 0 && len(r.Moved) == 0 &&
+		len(r.CIChanged) == 0 && len(r.Reviewed) == 0
+}
+
+// Diff compares two snapshots. Only repositories scanned successfully in
+// both are compared, so a repository that failed to scan (or was filtered
+// out) in one of them doesn't show all its PRs as opened or closed.
+//
+// A PR missing from to isn't necessarily closed: it may have dropped out
+// of a repository truncated at max_prs_per_repo, or aged past maxAgeDays
+// (max_pr_age_days; 0 = no limit). Such PRs aren't reported as closed, nor
+// PRs new to a repository truncated in from as opened.
+func Diff(from, to *Snapshot, maxAgeDays int) *Report {
+	report := &Report{
+		From:      from.TakenAt,
+		To:        to.TakenAt,
+		Opened:    []*models.PR{},
+		Closed:    []*models.PR{},
+		Moved:     []Move{},
+		CIChanged: []CIChange{},
+		Reviewed:  []ReviewChange{},
+	}
+
+	common := scannedRepos(from.Result)
+	scanned := scannedRepos(to.Result)
+	for name := range common {
+		if !scanned[name] {
+			delete(common, name)
+		}
+	}
+
+	before := index(from.Result, common)
+	after := index(to.Result, common)
+	truncatedBefore := truncatedRepos(from.Result)
+	truncatedAfter := truncatedRepos(to.Result)
+
+	for _, key := range sortedKeys(after) {
+		curr := after[key]
+		old, ok := before[key]
+		if !ok {
+			if !truncatedBefore[curr.pr.RepoFullName()] {
+				report.Opened = append(report.Opened, curr.pr)
+			}
+			continue
+		}
+
+		if curr.category != old.category {
+			report.Moved = append(report.Moved, Move{PR: curr.pr, From: old.category, To: curr.category})
+		}
+		if curr.pr.CIStatus != old.pr.CIStatus {
+			report.CIChanged = append(report.CIChanged, CIChange{PR: curr.pr, From: old.pr.CIStatus, To: curr.pr.CIStatus})
+		}
+		if reviews := newReviews(old.pr, curr.pr); len(reviews) > 0 {
+			report.Reviewed = append(report.Reviewed, ReviewChange{PR: curr.pr, Reviews: reviews})
+		}
+	}
+
+	for _, key := range sortedKeys(before) {
+		pr := before[key].pr
+		if _, ok := after[key]; ok || truncatedAfter[pr.RepoFullName()] || agedOut(pr, to.TakenAt, maxAgeDays) {
+			continue
+		}
+		report.Closed = append(report.Closed, pr)
+	}
+
+	return report
+}
+
+// categorized is a PR along with the section it was sorted into.
+type categorized struct {
+	pr       *models.PR
+	category Category
+}
+
+// index returns the PRs of repos in result, keyed by PR key. Snoozed and
+// muted PRs are included, so hiding a PR reports it as moved, not closed.
+func index(result *models.ScanResult, repos map[string]bool) map[string]categorized {
+	prs := make(map[string]categorized)
+	add := func(pr *models.PR, category Category) {
+		if repos[pr.RepoFullName()] {
+			prs[pr.Key()] = categorized{pr: pr, category: category}
+		}
+	}
+	for _, section := range result.DisplaySections() {
+		for _, pr := range result.SectionPRs(section) {
+			add(pr, Category(section.Key))
+		}
+	}
+	for _, pr := range result.SnoozedPRs {
+		add(pr, CategorySnoozed)
+	}
+	return prs
+}
+
+// scannedRepos returns the full names of the repositories whose PRs were
+// fetched successfully.
+func scannedRepos(result *models.ScanResult) map[string]bool {
+	repos := make(map[string]bool)
+	for _, list := range [][]*models.Repository{result.ReposWithPRs, result.ReposWithoutPRs} {
+		for _, repo := range list {
+			repos[repo.FullName()] = true
+		}
+	}
+	return repos
+}
+
+// truncatedRepos returns the full names of the repositories with more open
+// PRs than were fetched.
+func truncatedRepos(result *models.ScanResult) map[string]bool {
+	repos := make(map[string]bool)
+	for _, repo := range result.ReposWithPRs {
+		if repo.Truncated {
+			repos[repo.FullName()] = true
+		}
+	}
+	return repos
+}
+
+// agedOut reports whether pr is older than maxAgeDays at now, so a scan
+// then leaves it out.
+func agedOut(pr *models.PR, now time.Time, maxAgeDays int) bool {
+	return maxAgeDays > 0 && pr.CreatedAt.Before(now.AddDate(0, 0, -maxAgeDays))
+}
+
+// sortedKeys returns the keys of prs ordered by repository, then number.
+func sortedKeys(prs map[string]categorized) []string {
+	keys := make([]string, 0, len(prs))
+	for key := range prs {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := prs[keys[i]].pr, prs[keys[j]].pr
+		if a.RepoFullName() != b.RepoFullName() {
+			return a.RepoFullName() < b.RepoFullName()
+		}
+		return a.Number < b.Number
+	})
+	return keys
+}
+
+// newReviews returns the submitted reviews on curr that old didn't have.
+func newReviews(old, curr *models.PR) []models.Review {
+	// Compare times by instant: snapshots read from disk lose the location
+	type reviewKey struct {
+		author    string
+		state     models.ReviewState
+		submitted int64
+	}
+	key := func(r models.Review) reviewKey {
+		return reviewKey{r.Author, r.State, r.Submitted.UnixNano()}
+	}
+
+	seen := make(map[reviewKey]bool, len(old.Reviews))
+	for _, r := range old.Reviews {
+		seen[key(r)] = true
+	}
+
+	var reviews []models.Review
+	for _, r := range curr.Reviews {
+		if r.State != models.ReviewStatePending && !seen[key(r)] {
+			reviews = append(reviews, r)
+		}
+	}
+	return reviews
+}
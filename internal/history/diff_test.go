@@ -0,0 +1,197 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"prt/internal/models"
+)
+
+// diffPR builds a PR in org/<repo>.
+func diffPR(repo string, number int, ci models.CIStatus, reviews ...models.Review) *models.PR {
+	return &models.PR{
+		Number:    number,
+		Title:     "PR",
+		RepoOwner: "org",
+		RepoName:  repo,
+		CIStatus:  ci,
+		Reviews:   reviews,
+	}
+}
+
+// diffResult builds a result with the given sections, where every PR's
+// repository was scanned successfully.
+func diffResult(my, attention, team []*models.PR) *models.ScanResult {
+	result := models.NewScanResult()
+	result.MyPRs = my
+	result.NeedsMyAttention = attention
+	result.TeamPRs = team
+
+	seen := make(map[string]bool)
+	for _, section := range [][]*models.PR{my, attention, team} {
+		for _, pr := range section {
+			if !seen[pr.RepoName] {
+				seen[pr.RepoName] = true
+				result.ReposWithPRs = append(result.ReposWithPRs, &models.Repository{Owner: "org", Name: pr.RepoName})
+			}
+		}
+	}
+	return result
+}
+
+func TestDiff(t *testing.T) {
+	from := time.Date(2025, 1, 1, 18, 0, 0, 0, time.UTC)
+	to := from.Add(14 * time.Hour)
+	review := models.Review{Author: "bob", State: models.ReviewStateCommented, Submitted: from.Add(-time.Hour)}
+	approval := models.Review{Author: "carol", State: models.ReviewStateApproved, Submitted: from.Add(time.Hour)}
+
+	before := diffResult(
+		[]*models.PR{diffPR("api", 1, models.CIStatusPending, review), diffPR("api", 2, models.CIStatusPassing)},
+		[]*models.PR{diffPR("web", 5, models.CIStatusPassing)},
+		nil,
+	)
+	after := diffResult(
+		[]*models.PR{diffPR("api", 1, models.CIStatusFailing, review, approval), diffPR("api", 3, models.CIStatusPending)},
+		nil,
+		[]*models.PR{diffPR("web", 5, models.CIStatusPassing)},
+	)
+
+	report := Diff(&Snapshot{TakenAt: from, Result: before}, &Snapshot{TakenAt: to, Result: after}, 0)
+
+	if !report.From.Equal(from) || !report.To.Equal(to) {
+		t.Errorf("report covers %v-%v, want %v-%v", report.From, report.To, from, to)
+	}
+	if len(report.Opened) != 1 || report.Opened[0].Key() != "org/api#3" {
+		t.Errorf("Opened = %v, want org/api#3", keys(report.Opened))
+	}
+	if len(report.Closed) != 1 || report.Closed[0].Key() != "org/api#2" {
+		t.Errorf("Closed = %v, want org/api#2", keys(report.Closed))
+	}
+	if len(report.Moved) != 1 || report.Moved[0].PR.Key() != "org/web#5" ||
+		report.Moved[0].From != CategoryNeedsMyAttention || report.Moved[0].To != CategoryTeamPRs {
+		t.Errorf("Moved = %+v, want org/web#5 from needs_my_attention to team_prs", report.Moved)
+	}
+	if len(report.CIChanged) != 1 || report.CIChanged[0].PR.Key() != "org/api#1" ||
+		report.CIChanged[0].From != models.CIStatusPending || report.CIChanged[0].To != models.CIStatusFailing {
+		t.Errorf("CIChanged = %+v, want org/api#1 pending -> failing", report.CIChanged)
+	}
+	if len(report.Reviewed) != 1 || len(report.Reviewed[0].Reviews) != 1 || report.Reviewed[0].Reviews[0].Author != "carol" {
+		t.Errorf("Reviewed = %+v, want only carol's new approval on org/api#1", report.Reviewed)
+	}
+	if report.IsEmpty() {
+		t.Error("IsEmpty() = true, want false")
+	}
+}
+
+func TestDiff_NoChanges(t *testing.T) {
+	result := diffResult([]*models.PR{diffPR("api", 1, models.CIStatusPassing)}, nil, nil)
+	report := Diff(&Snapshot{Result: result}, &Snapshot{Result: result}, 0)
+
+	if !report.IsEmpty() {
+		t.Errorf("expected an empty report, got %+v", report)
+	}
+}
+
+func TestDiff_Snoozed(t *testing.T) {
+	pr := diffPR("api", 1, models.CIStatusPassing)
+	before := diffResult(nil, []*models.PR{pr}, nil)
+	after := diffResult(nil, nil, nil)
+	after.ReposWithPRs = before.ReposWithPRs
+	after.SnoozedPRs = []*models.PR{pr}
+
+	report := Diff(&Snapshot{Result: before}, &Snapshot{Result: after}, 0)
+
+	if len(report.Closed) != 0 {
+		t.Errorf("Closed = %v, want none: snoozed PRs are still open", keys(report.Closed))
+	}
+	if len(report.Moved) != 1 || report.Moved[0].From != CategoryNeedsMyAttention || report.Moved[0].To != CategorySnoozed {
+		t.Errorf("Moved = %+v, want org/api#1 from needs_my_attention to snoozed", report.Moved)
+	}
+}
+
+func TestDiff_IgnoresReposNotScannedInBoth(t *testing.T) {
+	before := diffResult([]*models.PR{diffPR("api", 1, models.CIStatusPassing), diffPR("web", 2, models.CIStatusPassing)}, nil, nil)
+
+	// web failed to scan this time, and ops wasn't scanned before
+	after := diffResult([]*models.PR{diffPR("api", 1, models.CIStatusPassing), diffPR("ops", 3, models.CIStatusPassing)}, nil, nil)
+	after.ReposWithErrors = append(after.ReposWithErrors, &models.Repository{Owner: "org", Name: "web", ScanStatus: models.ScanStatusError})
+	before.ReposWithoutPRs = append(before.ReposWithoutPRs, &models.Repository{Owner: "org", Name: "docs"})
+
+	report := Diff(&Snapshot{Result: before}, &Snapshot{Result: after}, 0)
+
+	if len(report.Closed) != 0 {
+		t.Errorf("PRs of a repo that failed to scan should not be closed, got %v", keys(report.Closed))
+	}
+	if len(report.Opened) != 0 {
+		t.Errorf("PRs of a repo not scanned before should not be opened, got %v", keys(report.Opened))
+	}
+}
+
+func TestDiff_AgedOut(t *testing.T) {
+	now := time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC)
+	old := diffPR("api", 1, models.CIStatusPassing)
+	old.CreatedAt = now.AddDate(0, 0, -31)
+	merged := diffPR("api", 2, models.CIStatusPassing)
+	merged.CreatedAt = now.AddDate(0, 0, -2)
+	kept := diffPR("api", 3, models.CIStatusPassing)
+
+	before := diffResult([]*models.PR{old, merged, kept}, nil, nil)
+	after := diffResult([]*models.PR{kept}, nil, nil)
+
+	// #1 is past max_pr_age_days now, so the scan left it out
+	report := Diff(&Snapshot{TakenAt: now.Add(-time.Hour), Result: before}, &Snapshot{TakenAt: now, Result: after}, 30)
+	if len(report.Closed) != 1 || report.Closed[0].Key() != "org/api#2" {
+		t.Errorf("Closed = %v, want only org/api#2", keys(report.Closed))
+	}
+
+	// Without an age limit, it must have been closed
+	report = Diff(&Snapshot{TakenAt: now.Add(-time.Hour), Result: before}, &Snapshot{TakenAt: now, Result: after}, 0)
+	if len(report.Closed) != 2 {
+		t.Errorf("Closed = %v, want org/api#1 and org/api#2", keys(report.Closed))
+	}
+}
+
+func TestDiff_Truncated(t *testing.T) {
+	before := diffResult([]*models.PR{diffPR("api", 1, models.CIStatusPassing), diffPR("api", 2, models.CIStatusPassing)}, nil, nil)
+	after := diffResult([]*models.PR{diffPR("api", 1, models.CIStatusPassing), diffPR("api", 3, models.CIStatusPassing)}, nil, nil)
+
+	// api is over max_prs_per_repo now: #2 may just not have been fetched
+	after.ReposWithPRs[0].Truncated = true
+	report := Diff(&Snapshot{Result: before}, &Snapshot{Result: after}, 0)
+	if len(report.Closed) != 0 {
+		t.Errorf("Closed = %v, want none from a truncated repo", keys(report.Closed))
+	}
+	if len(report.Opened) != 1 || report.Opened[0].Key() != "org/api#3" {
+		t.Errorf("Opened = %v, want org/api#3", keys(report.Opened))
+	}
+
+	// It was truncated before: #3 may just not have been fetched then
+	after.ReposWithPRs[0].Truncated = false
+	before.ReposWithPRs[0].Truncated = true
+	report = Diff(&Snapshot{Result: before}, &Snapshot{Result: after}, 0)
+	if len(report.Opened) != 0 {
+		t.Errorf("Opened = %v, want none new to a truncated repo", keys(report.Opened))
+	}
+	if len(report.Closed) != 1 || report.Closed[0].Key() != "org/api#2" {
+		t.Errorf("Closed = %v, want org/api#2", keys(report.Closed))
+	}
+}
+
+func TestDiff_ReviewsSurviveRoundTrip(t *testing.T) {
+	// Times read back from disk compare equal by instant, not by location
+	submitted := time.Date(2025, 1, 1, 9, 0, 0, 0, time.FixedZone("CET", 3600))
+	old := diffPR("api", 1, models.CIStatusPassing, models.Review{Author: "bob", State: models.ReviewStateApproved, Submitted: submitted})
+	curr := diffPR("api", 1, models.CIStatusPassing, models.Review{Author: "bob", State: models.ReviewStateApproved, Submitted: submitted.UTC()})
+
+	if reviews := newReviews(old, curr); len(reviews) != 0 {
+		t.Errorf("newReviews() = %v, want none", reviews)
+	}
+}
+
+func keys(prs []*models.PR) []string {
+	var out []string
+	for _, pr := range prs {
+		out = append(out, pr.Key())
+	}
+	return out
+}
@@ -0,0 +1,155 @@
+// Package history keeps a snapshot of every scan on disk, so a later run
+// can report what changed since an earlier one (see prt diff).
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"prt/internal/config"
+	"prt/internal/models"
+)
+
+// version is bumped whenever the snapshot format changes, so snapshots
+// written by older versions of PRT are reported instead of misread.
+const version = 1
+
+// MaxSnapshots caps the number of snapshots kept regardless of age, so a
+// long watch session doesn't fill the history with thousands of files.
+const MaxSnapshots = 1000
+
+// nameLayout names snapshot files after the time they were taken (in UTC),
+// so lexical order is chronological order.
+const nameLayout = "20060102T150405.000000000Z"
+
+// Dir returns the default history directory: ~/.prt/history
+func Dir() string {
+	return filepath.Join(config.ConfigDir(), "history")
+}
+
+// Snapshot is the result of one scan as saved on disk.
+type Snapshot struct {
+	Version int                `json:"version"`
+	TakenAt time.Time          `json:"taken_at"`
+	Result  *models.ScanResult `json:"result"`
+}
+
+// Entry identifies a saved snapshot without loading it.
+type Entry struct {
+	TakenAt time.Time
+	path    string
+}
+
+// Store is an on-disk collection of snapshots, one JSON file each.
+type Store struct {
+	dir string
+}
+
+// NewStore creates a store rooted at dir. The directory is created on the
+// first save.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Save writes a snapshot of result taken at takenAt. The file is written
+// atomically so a concurrent run never lists a partial snapshot.
+func (s *Store) Save(result *models.ScanResult, takenAt time.Time) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(Snapshot{Version: version, TakenAt: takenAt, Result: result})
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, ".snapshot-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	name := takenAt.UTC().Format(nameLayout) + ".json"
+	return os.Rename(tmp.Name(), filepath.Join(s.dir, name))
+}
+
+// List returns the saved snapshots, oldest first. A missing directory
+// means there is no history yet.
+func (s *Store) List() ([]Entry, error) {
+	files, err := os.ReadDir(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, f := range files {
+		name, ok := strings.CutSuffix(f.Name(), ".json")
+		if !ok || f.IsDir() {
+			continue
+		}
+		takenAt, err := time.Parse(nameLayout, name)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, Entry{TakenAt: takenAt, path: filepath.Join(s.dir, f.Name())})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].TakenAt.Before(entries[j].TakenAt)
+	})
+	return entries, nil
+}
+
+// Load reads the snapshot for entry.
+func (s *Store) Load(entry Entry) (*Snapshot, error) {
+	data, err := os.ReadFile(entry.path)
+	if err != nil {
+		return nil, err
+	}
+
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %s: %w", filepath.Base(entry.path), err)
+	}
+	if snap.Version != version || snap.Result == nil {
+		return nil, fmt.Errorf("snapshot %s was written by an incompatible version of prt", filepath.Base(entry.path))
+	}
+
+	return &snap, nil
+}
+
+// Prune removes snapshots taken before cutoff, and the oldest snapshots
+// beyond MaxSnapshots.
+func (s *Store) Prune(cutoff time.Time) error {
+	entries, err := s.List()
+	if err != nil {
+		return err
+	}
+
+	for i, entry := range entries {
+		if !entry.TakenAt.Before(cutoff) && len(entries)-i <= MaxSnapshots {
+			break
+		}
+		if err := os.Remove(entry.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"prt/internal/models"
+)
+
+func snapshotResult(numbers ...int) *models.ScanResult {
+	result := models.NewScanResult()
+	repo := &models.Repository{Owner: "org", Name: "api", ScanStatus: models.ScanStatusSuccess}
+	for _, n := range numbers {
+		pr := &models.PR{Number: n, RepoOwner: "org", RepoName: "api", CIStatus: models.CIStatusPassing}
+		repo.PRs = append(repo.PRs, pr)
+		result.MyPRs = append(result.MyPRs, pr)
+	}
+	result.ReposWithPRs = append(result.ReposWithPRs, repo)
+	return result
+}
+
+func TestStore_SaveListLoad(t *testing.T) {
+	s := NewStore(filepath.Join(t.TempDir(), "history"))
+
+	entries, err := s.List()
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("List() on missing dir = %v, %v; want no entries", entries, err)
+	}
+
+	base := time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)
+	// Saved out of order; List sorts by time
+	for _, offset := range []time.Duration{2 * time.Hour, 0, time.Hour} {
+		if err := s.Save(snapshotResult(int(offset/time.Hour)+1), base.Add(offset)); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	entries, err = s.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("List() returned %d entries, want 3", len(entries))
+	}
+	for i, entry := range entries {
+		if want := base.Add(time.Duration(i) * time.Hour); !entry.TakenAt.Equal(want) {
+			t.Errorf("entries[%d].TakenAt = %v, want %v", i, entry.TakenAt, want)
+		}
+	}
+
+	snap, err := s.Load(entries[1])
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !snap.TakenAt.Equal(base.Add(time.Hour)) || len(snap.Result.MyPRs) != 1 || snap.Result.MyPRs[0].Number != 2 {
+		t.Errorf("Load() = %+v, want the snapshot taken at 10:00 with PR #2", snap)
+	}
+}
+
+func TestStore_ListIgnoresOtherFiles(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStore(dir)
+	s.Save(snapshotResult(1), time.Now())
+
+	os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("hi"), 0644)
+	os.WriteFile(filepath.Join(dir, "garbage.json"), []byte("{}"), 0644)
+	os.WriteFile(filepath.Join(dir, ".snapshot-123"), []byte("{"), 0644)
+
+	entries, err := s.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("List() returned %d entries, want 1", len(entries))
+	}
+}
+
+func TestStore_LoadRejectsOtherVersions(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStore(dir)
+	takenAt := time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)
+	s.Save(snapshotResult(1), takenAt)
+
+	entries, _ := s.List()
+	os.WriteFile(entries[0].path, []byte(`{"version": 99, "result": {}}`), 0644)
+
+	if _, err := s.Load(entries[0]); err == nil {
+		t.Error("expected an error for a snapshot from another version")
+	}
+}
+
+func TestStore_Prune(t *testing.T) {
+	s := NewStore(t.TempDir())
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for day := 0; day < 5; day++ {
+		s.Save(snapshotResult(day), base.Add(time.Duration(day)*24*time.Hour))
+	}
+
+	if err := s.Prune(base.Add(2 * 24 * time.Hour)); err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+
+	entries, _ := s.List()
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 snapshots after pruning, got %d", len(entries))
+	}
+	if !entries[0].TakenAt.Equal(base.Add(2 * 24 * time.Hour)) {
+		t.Errorf("oldest remaining snapshot = %v, want the one taken at the cutoff", entries[0].TakenAt)
+	}
+}
+
+func TestStore_PruneCapsCount(t *testing.T) {
+	s := NewStore(t.TempDir())
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < MaxSnapshots+2; i++ {
+		s.Save(models.NewScanResult(), base.Add(time.Duration(i)*time.Second))
+	}
+
+	if err := s.Prune(base); err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+
+	entries, _ := s.List()
+	if len(entries) != MaxSnapshots {
+		t.Fatalf("expected %d snapshots after pruning, got %d", MaxSnapshots, len(entries))
+	}
+	if !entries[0].TakenAt.Equal(base.Add(2 * time.Second)) {
+		t.Errorf("expected the oldest snapshots to be removed, oldest is %v", entries[0].TakenAt)
+	}
+}
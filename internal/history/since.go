@@ -0,0 +1,117 @@
+package history
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Since selects the earlier snapshot a diff starts from: either a number of
+// runs back, or the last snapshot taken at or before a point in time.
+type Since struct {
+	Runs int       // Runs back (1 = the previous run); used when Time is zero
+	Time time.Time // Point in time
+}
+
+// timeLayouts are the absolute times accepted by ParseTime.
+var timeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ParseSince parses a --since value relative to now. It accepts:
+//
+//	""                        the previous run
+//	3                         three runs back
+//	12h, 90m, 2d, 1w          a duration ago
+//	2025-01-02, 2025-01-02 09:00, RFC 3339
+func ParseSince(s string, now time.Time) (Since, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Since{Runs: 1}, nil
+	}
+
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < 1 {
+			return Since{}, fmt.Errorf("invalid --since %q: the number of runs back must be at least 1", s)
+		}
+		return Since{Runs: n}, nil
+	}
+
+	if d, err := ParseDuration(s); err == nil {
+		if d <= 0 {
+			return Since{}, fmt.Errorf("invalid --since %q: duration must be positive", s)
+		}
+		return Since{Time: now.Add(-d)}, nil
+	}
+
+	if t, err := ParseTime(s, now.Location()); err == nil {
+		return Since{Time: t}, nil
+	}
+
+	return Since{}, fmt.Errorf("invalid --since %q (expected a number of runs, a duration like 12h or 2d, or a date like 2025-01-02)", s)
+}
+
+// ParseDuration parses a Go duration, additionally accepting whole days
+// ("2d") and weeks ("1w"). It is shared by the flags and query terms that
+// take a duration.
+func ParseDuration(s string) (time.Duration, error) {
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			count, err := strconv.Atoi(n)
+			if err != nil {
+				return 0, err
+			}
+			return time.Duration(count) * unit, nil
+		}
+	}
+	return time.ParseDuration(s)
+}
+
+// ParseTime parses an absolute time given on the command line: a date
+// (2025-01-02), a date and time (2025-01-02 09:00), or RFC 3339. It is in
+// loc unless it includes a time zone.
+func ParseTime(s string, loc *time.Location) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q", s)
+}
+
+// Select picks the snapshot to diff from among entries (oldest first). For
+// a point in time that predates the history, the oldest snapshot is used.
+func (s Since) Select(entries []Entry) (Entry, error) {
+	if len(entries) == 0 {
+		return Entry{}, fmt.Errorf("no earlier scans in history yet; run prt to record one")
+	}
+
+	if s.Time.IsZero() {
+		runs := max(s.Runs, 1)
+		if runs > len(entries) {
+			return Entry{}, fmt.Errorf("only %d earlier scan%s in history", len(entries), plural(len(entries)))
+		}
+		return entries[len(entries)-runs], nil
+	}
+
+	selected := entries[0]
+	for _, entry := range entries {
+		if entry.TakenAt.After(s.Time) {
+			break
+		}
+		selected = entry
+	}
+	return selected, nil
+}
+
+// plural returns "s" unless n is 1.
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
@@ -0,0 +1,120 @@
+package history
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		input   string
+		want    Since
+		wantErr bool
+	}{
+		{input: "", want: Since{Runs: 1}},
+		{input: "3", want: Since{Runs: 3}},
+		{input: "12h", want: Since{Time: now.Add(-12 * time.Hour)}},
+		{input: "90m", want: Since{Time: now.Add(-90 * time.Minute)}},
+		{input: "2d", want: Since{Time: now.Add(-48 * time.Hour)}},
+		{input: "1w", want: Since{Time: now.Add(-7 * 24 * time.Hour)}},
+		{input: "2025-01-02", want: Since{Time: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)}},
+		{input: "2025-01-02 09:30", want: Since{Time: time.Date(2025, 1, 2, 9, 30, 0, 0, time.UTC)}},
+		{input: "2025-01-02T09:30", want: Since{Time: time.Date(2025, 1, 2, 9, 30, 0, 0, time.UTC)}},
+		{input: "2025-01-02T09:30:00Z", want: Since{Time: time.Date(2025, 1, 2, 9, 30, 0, 0, time.UTC)}},
+		{input: "0", wantErr: true},
+		{input: "-1h", wantErr: true},
+		{input: "yesterday", wantErr: true},
+		{input: "xd", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseSince(tt.input, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSince(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Runs != tt.want.Runs || !got.Time.Equal(tt.want.Time) {
+				t.Errorf("ParseSince(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{input: "90m", want: 90 * time.Minute},
+		{input: "12h", want: 12 * time.Hour},
+		{input: "2d", want: 48 * time.Hour},
+		{input: "1w", want: 7 * 24 * time.Hour},
+		{input: "xd", wantErr: true},
+		{input: "2", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseDuration(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDuration(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDuration(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSince_Select(t *testing.T) {
+	base := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	var entries []Entry
+	for i := 0; i < 3; i++ {
+		entries = append(entries, Entry{TakenAt: base.Add(time.Duration(i) * 24 * time.Hour)})
+	}
+
+	tests := []struct {
+		name    string
+		since   Since
+		want    time.Time
+		wantErr string
+	}{
+		{name: "previous run", since: Since{Runs: 1}, want: entries[2].TakenAt},
+		{name: "runs back", since: Since{Runs: 3}, want: entries[0].TakenAt},
+		{name: "too many runs back", since: Since{Runs: 4}, wantErr: "only 3 earlier scans"},
+		{name: "time between runs", since: Since{Time: base.Add(36 * time.Hour)}, want: entries[1].TakenAt},
+		{name: "exact time of a run", since: Since{Time: entries[1].TakenAt}, want: entries[1].TakenAt},
+		{name: "before history", since: Since{Time: base.Add(-time.Hour)}, want: entries[0].TakenAt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.since.Select(entries)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Select() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Select() error = %v", err)
+			}
+			if !got.TakenAt.Equal(tt.want) {
+				t.Errorf("Select() = %v, want %v", got.TakenAt, tt.want)
+			}
+		})
+	}
+}
+
+func TestSince_SelectEmptyHistory(t *testing.T) {
+	if _, err := (Since{Runs: 1}).Select(nil); err == nil {
+		t.Error("expected an error with no history")
+	}
+}
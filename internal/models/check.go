@@ -0,0 +1,69 @@
+package models
+
+// Check is one CI check on a PR's head commit: a check run (e.g. a GitHub
+// Actions job) or a commit status.
+type Check struct {
+	Name string `json:"name"`
+	// State is a check run's status (QUEUED, IN_PROGRESS, COMPLETED, ...)
+	// or a commit status's state (PENDING, SUCCESS, FAILURE, ERROR, ...)
+	State string `json:"state"`
+	// Conclusion is a completed check run's result (SUCCESS, FAILURE,
+	// SKIPPED, ...); commit statuses have none
+	Conclusion string `json:"conclusion,omitempty"`
+	URL        string `json:"url,omitempty"`
+	// Required reports whether the base branch's protection requires the
+	// check; nil if unknown
+	Required *bool `json:"required,omitempty"`
+	// Ignored is set for checks matching ci_ignore_checks, which don't
+	// count towards the PR's CI status
+	Ignored bool `json:"ignored,omitempty"`
+}
+
+// Status returns the check's outcome: failing, pending, or passing.
+// Skipped and neutral checks count as passing.
+func (c Check) Status() CIStatus {
+	result := c.Conclusion
+	if result == "" {
+		result = c.State
+	}
+	switch result {
+	case "FAILURE", "ERROR", "CANCELLED", "TIMED_OUT", "ACTION_REQUIRED", "STARTUP_FAILURE":
+		return CIStatusFailing
+	case "PENDING", "EXPECTED", "QUEUED", "IN_PROGRESS", "WAITING", "REQUESTED":
+		return CIStatusPending
+	}
+	return CIStatusPassing
+}
+
+// ComputeCIStatus rolls checks up into an overall CI status, leaving out
+// ignored checks. Priority: failing > pending > passing > none.
+func ComputeCIStatus(checks []Check) CIStatus {
+	status := CIStatusNone
+	for _, c := range checks {
+		if c.Ignored {
+			continue
+		}
+		switch c.Status() {
+		case CIStatusFailing:
+			return CIStatusFailing
+		case CIStatusPending:
+			status = CIStatusPending
+		default:
+			if status == CIStatusNone {
+				status = CIStatusPassing
+			}
+		}
+	}
+	return status
+}
+
+// FailingChecks returns the PR's failing checks that aren't ignored.
+func (pr *PR) FailingChecks() []Check {
+	var failing []Check
+	for _, c := range pr.Checks {
+		if !c.Ignored && c.Status() == CIStatusFailing {
+			failing = append(failing, c)
+		}
+	}
+	return failing
+}
@@ -0,0 +1,180 @@
+package models
+
+import "testing"
+
+func TestComputeCIStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		checks []Check
+		want   CIStatus
+	}{
+		{
+			name:   "no checks",
+			checks: []Check{},
+			want:   CIStatusNone,
+		},
+		{
+			name:   "nil checks",
+			checks: nil,
+			want:   CIStatusNone,
+		},
+		{
+			name: "all passing",
+			checks: []Check{
+				{Name: "ci/build", State: "SUCCESS"},
+				{Name: "ci/test", State: "SUCCESS"},
+			},
+			want: CIStatusPassing,
+		},
+		{
+			name: "one failing",
+			checks: []Check{
+				{Name: "ci/build", State: "SUCCESS"},
+				{Name: "ci/test", State: "FAILURE"},
+			},
+			want: CIStatusFailing,
+		},
+		{
+			name: "one pending with success",
+			checks: []Check{
+				{Name: "ci/build", State: "SUCCESS"},
+				{Name: "ci/test", State: "PENDING"},
+			},
+			want: CIStatusPending,
+		},
+		{
+			name: "failing takes priority over pending",
+			checks: []Check{
+				{Name: "ci/build", State: "PENDING"},
+				{Name: "ci/test", State: "FAILURE"},
+			},
+			want: CIStatusFailing,
+		},
+		{
+			name: "error state is failing",
+			checks: []Check{
+				{Name: "ci/build", State: "ERROR"},
+			},
+			want: CIStatusFailing,
+		},
+		{
+			name: "cancelled is failing",
+			checks: []Check{
+				{Name: "ci/build", State: "CANCELLED"},
+			},
+			want: CIStatusFailing,
+		},
+		{
+			name: "timed out is failing",
+			checks: []Check{
+				{Name: "ci/build", State: "TIMED_OUT"},
+			},
+			want: CIStatusFailing,
+		},
+		{
+			name: "action required is failing",
+			checks: []Check{
+				{Name: "ci/build", State: "ACTION_REQUIRED"},
+			},
+			want: CIStatusFailing,
+		},
+		{
+			name: "expected is pending",
+			checks: []Check{
+				{Name: "ci/build", State: "EXPECTED"},
+			},
+			want: CIStatusPending,
+		},
+		{
+			name: "queued is pending",
+			checks: []Check{
+				{Name: "ci/build", State: "QUEUED"},
+			},
+			want: CIStatusPending,
+		},
+		{
+			name: "in progress is pending",
+			checks: []Check{
+				{Name: "ci/build", State: "IN_PROGRESS"},
+			},
+			want: CIStatusPending,
+		},
+		{
+			name: "waiting is pending",
+			checks: []Check{
+				{Name: "ci/build", State: "WAITING"},
+			},
+			want: CIStatusPending,
+		},
+		{
+			name: "skipped is passing",
+			checks: []Check{
+				{Name: "ci/build", State: "SKIPPED"},
+			},
+			want: CIStatusPassing,
+		},
+		{
+			name: "neutral is passing",
+			checks: []Check{
+				{Name: "ci/build", State: "NEUTRAL"},
+			},
+			want: CIStatusPassing,
+		},
+		{
+			name: "conclusion wins over status",
+			checks: []Check{
+				{Name: "build", State: "COMPLETED", Conclusion: "FAILURE"},
+			},
+			want: CIStatusFailing,
+		},
+		{
+			name: "completed without conclusion is passing",
+			checks: []Check{
+				{Name: "build", State: "COMPLETED"},
+			},
+			want: CIStatusPassing,
+		},
+		{
+			name: "ignored failing check",
+			checks: []Check{
+				{Name: "ci/build", State: "SUCCESS"},
+				{Name: "flaky", State: "FAILURE", Ignored: true},
+			},
+			want: CIStatusPassing,
+		},
+		{
+			name: "only ignored checks",
+			checks: []Check{
+				{Name: "flaky", State: "FAILURE", Ignored: true},
+			},
+			want: CIStatusNone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ComputeCIStatus(tt.checks)
+			if got != tt.want {
+				t.Errorf("ComputeCIStatus() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPR_FailingChecks(t *testing.T) {
+	pr := &PR{Checks: []Check{
+		{Name: "build", State: "COMPLETED", Conclusion: "SUCCESS"},
+		{Name: "test", State: "COMPLETED", Conclusion: "FAILURE"},
+		{Name: "flaky", State: "COMPLETED", Conclusion: "FAILURE", Ignored: true},
+		{Name: "ci/lint", State: "ERROR"},
+		{Name: "deploy", State: "PENDING"},
+	}}
+
+	var names []string
+	for _, c := range pr.FailingChecks() {
+		names = append(names, c.Name)
+	}
+	if len(names) != 2 || names[0] != "test" || names[1] != "ci/lint" {
+		t.Errorf("FailingChecks() = %v, want [test ci/lint]", names)
+	}
+}
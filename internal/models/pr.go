@@ -40,11 +40,60 @@ const (
 	ReviewStateDismissed        ReviewState = "DISMISSED"
 )
 
+// ReviewDecision is GitHub's overall review verdict on a PR, which takes
+// the base branch's protection rules into account.
+type ReviewDecision string
+
+const (
+	ReviewDecisionApproved         ReviewDecision = "APPROVED"
+	ReviewDecisionChangesRequested ReviewDecision = "CHANGES_REQUESTED"
+	ReviewDecisionReviewRequired   ReviewDecision = "REVIEW_REQUIRED"
+)
+
+// MergeableState reports whether a PR merges cleanly into its base branch.
+// GitHub computes it in the background, so it is UNKNOWN until it has.
+type MergeableState string
+
+const (
+	MergeableMergeable   MergeableState = "MERGEABLE"
+	MergeableConflicting MergeableState = "CONFLICTING"
+	MergeableUnknown     MergeableState = "UNKNOWN"
+)
+
+// MergeStateStatus is GitHub's detailed merge readiness of a PR.
+type MergeStateStatus string
+
+const (
+	MergeStateClean    MergeStateStatus = "CLEAN"     // Mergeable, all checks passing
+	MergeStateDirty    MergeStateStatus = "DIRTY"     // Merge conflicts
+	MergeStateBlocked  MergeStateStatus = "BLOCKED"   // Blocked by branch protection
+	MergeStateBehind   MergeStateStatus = "BEHIND"    // Head branch is out of date
+	MergeStateUnstable MergeStateStatus = "UNSTABLE"  // Mergeable with non-passing checks
+	MergeStateHasHooks MergeStateStatus = "HAS_HOOKS" // Mergeable with pre-receive hooks
+	MergeStateDraft    MergeStateStatus = "DRAFT"     // Blocked because the PR is a draft
+	MergeStateUnknown  MergeStateStatus = "UNKNOWN"
+)
+
+// PRSize is a rough size class of a PR, from its changed lines.
+type PRSize string
+
+const (
+	SizeXS PRSize = "XS" // Fewer than 10 lines
+	SizeS  PRSize = "S"  // Fewer than 100 lines
+	SizeM  PRSize = "M"  // Fewer than 500 lines
+	SizeL  PRSize = "L"  // Fewer than 1000 lines
+	SizeXL PRSize = "XL"
+)
+
+// PRSizes lists the size classes from smallest to largest.
+var PRSizes = []PRSize{SizeXS, SizeS, SizeM, SizeL, SizeXL}
+
 // Review represents a single code review on a PR.
 type Review struct {
 	Author    string      `json:"author"`
 	State     ReviewState `json:"state"`
 	Submitted time.Time   `json:"submitted"`
+	CommitSHA string      `json:"commit_sha,omitempty"` // Head commit the review was made on
 }
 
 // PR represents a GitHub pull request.
@@ -64,22 +113,54 @@ type PR struct {
 	// Branches
 	BaseBranch string `json:"base_branch"` // Target (e.g., "main")
 	HeadBranch string `json:"head_branch"` // Source (e.g., "feature-x")
+	HeadSHA    string `json:"head_sha"`    // Latest commit on the head branch
+
+	// Origin
+	IsCrossRepository bool   `json:"is_cross_repository"` // Head branch is in a fork
+	HeadOwner         string `json:"head_owner"`          // Owner of the repository the head branch is in
+
+	// PRs named in "Depends-on:" trailers in the body, as written (see ParseDependsOn)
+	DependsOn []string `json:"depends_on,omitempty"`
+
+	// Labels and milestone
+	Labels    []string `json:"labels"`
+	Milestone string   `json:"milestone"` // Milestone title; empty if none
+
+	// Size
+	Additions    int `json:"additions"`     // Lines added
+	Deletions    int `json:"deletions"`     // Lines removed
+	ChangedFiles int `json:"changed_files"` // Files changed
+
+	// Merge state
+	Mergeable        MergeableState   `json:"mergeable"`
+	MergeStateStatus MergeStateStatus `json:"merge_state_status"`
 
 	// Timestamps
 	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 
-	// CI Status
+	// CI Status, rolled up from the checks that aren't ignored
 	CIStatus CIStatus `json:"ci_status"`
+	Checks   []Check  `json:"checks"`
 
 	// Review Information
-	ReviewRequests []string `json:"review_requests"`
-	Assignees      []string `json:"assignees"`
-	Reviews        []Review `json:"reviews"`
+	ReviewRequests     []string       `json:"review_requests"`
+	TeamReviewRequests []string       `json:"team_review_requests"` // Teams as "org/team-slug"
+	Assignees          []string       `json:"assignees"`
+	Reviews            []Review       `json:"reviews"`
+	ReviewDecision     ReviewDecision `json:"review_decision"` // Empty if no review is required
 
 	// Computed (set during categorization)
 	IsReviewRequestedFromMe bool        `json:"is_review_requested_from_me"`
+	RequestedTeam           string      `json:"requested_team,omitempty"` // My team the review was requested from, if not me directly
 	IsAssignedToMe          bool        `json:"is_assigned_to_me"`
 	MyReviewStatus          ReviewState `json:"my_review_status"`
+	NeedsReReview           bool        `json:"needs_re_review"` // New commits since my approval or change request
+
+	// Set from the user's marks (prt snooze, mute, pin)
+	Snoozed bool `json:"snoozed"`
+	Muted   bool `json:"muted"`
+	Pinned  bool `json:"pinned"`
 
 	// Repository context (set during aggregation)
 	RepoName  string `json:"repo_name"`
@@ -95,6 +176,12 @@ func (pr *PR) RepoFullName() string {
 	return pr.RepoOwner + "/" + pr.RepoName
 }
 
+// Key returns an identifier for the PR that is stable across scans,
+// in "owner/repo#number" format.
+func (pr *PR) Key() string {
+	return fmt.Sprintf("%s#%d", pr.RepoFullName(), pr.Number)
+}
+
 // Age returns the duration since the PR was created.
 func (pr *PR) Age() time.Duration {
 	return time.Since(pr.CreatedAt)
@@ -123,6 +210,59 @@ func (pr *PR) AgeString() string {
 	return "just now"
 }
 
+// ChangedLines returns the number of lines added and removed.
+func (pr *PR) ChangedLines() int {
+	return pr.Additions + pr.Deletions
+}
+
+// Size returns the PR's size class from its changed lines.
+func (pr *PR) Size() PRSize {
+	switch lines := pr.ChangedLines(); {
+	case lines < 10:
+		return SizeXS
+	case lines < 100:
+		return SizeS
+	case lines < 500:
+		return SizeM
+	case lines < 1000:
+		return SizeL
+	default:
+		return SizeXL
+	}
+}
+
+// HasConflicts returns true if the PR has merge conflicts with its base
+// branch, as far as GitHub has computed.
+func (pr *PR) HasConflicts() bool {
+	return pr.Mergeable == MergeableConflicting || pr.MergeStateStatus == MergeStateDirty
+}
+
+// OverallReview returns the PR's review state from each reviewer's latest
+// approval, change request, or dismissal: CHANGES_REQUESTED if anyone still
+// requests changes, APPROVED if anyone approved, otherwise NONE.
+func (pr *PR) OverallReview() ReviewState {
+	latest := make(map[string]Review)
+	for _, r := range pr.Reviews {
+		switch r.State {
+		case ReviewStateApproved, ReviewStateChangesRequested, ReviewStateDismissed:
+			if prev, ok := latest[r.Author]; !ok || !r.Submitted.Before(prev.Submitted) {
+				latest[r.Author] = r
+			}
+		}
+	}
+
+	state := ReviewStateNone
+	for _, r := range latest {
+		switch r.State {
+		case ReviewStateChangesRequested:
+			return ReviewStateChangesRequested
+		case ReviewStateApproved:
+			state = ReviewStateApproved
+		}
+	}
+	return state
+}
+
 // EffectiveState returns DRAFT if IsDraft is true, otherwise returns State.
 // This provides a unified way to check the PR's effective state.
 func (pr *PR) EffectiveState() PRState {
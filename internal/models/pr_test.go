@@ -66,6 +66,25 @@ func TestPR_AgeString(t *testing.T) {
 	}
 }
 
+func TestPR_Key(t *testing.T) {
+	tests := []struct {
+		name string
+		pr   PR
+		want string
+	}{
+		{"with owner", PR{Number: 42, RepoOwner: "org", RepoName: "api"}, "org/api#42"},
+		{"without owner", PR{Number: 7, RepoName: "api"}, "api#7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pr.Key(); got != tt.want {
+				t.Errorf("Key() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestPR_EffectiveState(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -118,6 +137,38 @@ func TestPR_EffectiveState(t *testing.T) {
 	}
 }
 
+func TestPR_OverallReview(t *testing.T) {
+	review := func(author string, state ReviewState, hoursAgo int) Review {
+		return Review{Author: author, State: state, Submitted: time.Now().Add(-time.Duration(hoursAgo) * time.Hour)}
+	}
+
+	tests := []struct {
+		name    string
+		reviews []Review
+		want    ReviewState
+	}{
+		{"no reviews", nil, ReviewStateNone},
+		{"only comments", []Review{review("bob", ReviewStateCommented, 1)}, ReviewStateNone},
+		{"approved", []Review{review("bob", ReviewStateApproved, 1)}, ReviewStateApproved},
+		{"changes requested by another", []Review{
+			review("bob", ReviewStateApproved, 1),
+			review("carol", ReviewStateChangesRequested, 2),
+		}, ReviewStateChangesRequested},
+		{"approval dismissed", []Review{
+			review("bob", ReviewStateApproved, 2),
+			review("bob", ReviewStateDismissed, 1),
+		}, ReviewStateNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (&PR{Reviews: tt.reviews}).OverallReview(); got != tt.want {
+				t.Errorf("OverallReview() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestPRState_Values(t *testing.T) {
 	// Verify enum values are as expected
 	if PRStateOpen != "OPEN" {
@@ -169,3 +220,48 @@ func TestReviewState_Values(t *testing.T) {
 		t.Errorf("ReviewStateDismissed = %v, want DISMISSED", ReviewStateDismissed)
 	}
 }
+
+func TestPR_Size(t *testing.T) {
+	tests := []struct {
+		additions, deletions int
+		want                 PRSize
+	}{
+		{0, 0, SizeXS},
+		{5, 4, SizeXS},
+		{8, 2, SizeS},
+		{90, 9, SizeS},
+		{100, 0, SizeM},
+		{400, 99, SizeM},
+		{500, 499, SizeL},
+		{1000, 0, SizeXL},
+	}
+
+	for _, tt := range tests {
+		pr := &PR{Additions: tt.additions, Deletions: tt.deletions}
+		if got := pr.Size(); got != tt.want {
+			t.Errorf("Size() with +%d/-%d = %s, want %s", tt.additions, tt.deletions, got, tt.want)
+		}
+	}
+}
+
+func TestPR_HasConflicts(t *testing.T) {
+	tests := []struct {
+		name string
+		pr   PR
+		want bool
+	}{
+		{"not computed", PR{}, false},
+		{"mergeable", PR{Mergeable: MergeableMergeable, MergeStateStatus: MergeStateClean}, false},
+		{"conflicting", PR{Mergeable: MergeableConflicting}, true},
+		{"dirty", PR{Mergeable: MergeableUnknown, MergeStateStatus: MergeStateDirty}, true},
+		{"behind", PR{Mergeable: MergeableMergeable, MergeStateStatus: MergeStateBehind}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pr.HasConflicts(); got != tt.want {
+				t.Errorf("HasConflicts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
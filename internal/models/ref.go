@@ -0,0 +1,117 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PRRef identifies a PR given on the command line. Owner is empty when
+// only the repository name was given, and Host unless it was given as a
+// URL.
+type PRRef struct {
+	Host   string
+	Owner  string
+	Repo   string
+	Number int
+}
+
+// ParsePRRef parses "owner/repo#123", "repo#123", or a pull request URL
+// like "https://github.com/owner/repo/pull/123".
+func ParsePRRef(s string) (PRRef, error) {
+	s = strings.TrimSpace(s)
+	invalid := fmt.Errorf("invalid PR %q (expected owner/repo#123, repo#123, or a pull request URL)", s)
+
+	if i := strings.Index(s, "://"); i >= 0 {
+		// host/owner/repo/pull/123[/files...]
+		parts := strings.Split(s[i+3:], "/")
+		if len(parts) < 5 || parts[3] != "pull" {
+			return PRRef{}, invalid
+		}
+		n, err := strconv.Atoi(parts[4])
+		if err != nil || n < 1 || parts[1] == "" || parts[2] == "" {
+			return PRRef{}, invalid
+		}
+		return PRRef{Host: strings.ToLower(parts[0]), Owner: parts[1], Repo: parts[2], Number: n}, nil
+	}
+
+	repo, num, ok := strings.Cut(s, "#")
+	n, err := strconv.Atoi(num)
+	if !ok || err != nil || n < 1 {
+		return PRRef{}, invalid
+	}
+	ref := PRRef{Repo: repo, Number: n}
+	if owner, name, ok := strings.Cut(repo, "/"); ok {
+		ref.Owner, ref.Repo = owner, name
+		if owner == "" || strings.Contains(name, "/") {
+			return PRRef{}, invalid
+		}
+	}
+	if ref.Repo == "" {
+		return PRRef{}, invalid
+	}
+	return ref, nil
+}
+
+// Matches reports whether pr is the PR the ref names. Names are compared
+// ignoring case, as GitHub does; a ref without an owner matches any owner.
+func (r PRRef) Matches(pr *PR) bool {
+	return pr.Number == r.Number && strings.EqualFold(pr.RepoName, r.Repo) &&
+		(r.Owner == "" || strings.EqualFold(pr.RepoOwner, r.Owner))
+}
+
+// Key returns the ref as a PR key ("owner/repo#123"), or "repo#123" if the
+// owner is unknown.
+func (r PRRef) Key() string {
+	if r.Owner == "" {
+		return fmt.Sprintf("%s#%d", r.Repo, r.Number)
+	}
+	return fmt.Sprintf("%s/%s#%d", r.Owner, r.Repo, r.Number)
+}
+
+// dependsOnTrailer matches a "Depends-on:" trailer line in a PR body.
+var dependsOnTrailer = regexp.MustCompile(`(?im)^[ \t]*depends-on:[ \t]*(.+?)[ \t\r]*$`)
+
+// ParseDependsOn returns the PRs named in "Depends-on:" trailers in a PR
+// body, one or more per line separated by commas or spaces:
+//
+//	Depends-on: org/api#123
+//	Depends-on: #45, web#67, https://github.com/org/lib/pull/8
+//
+// Refs are returned as written ("#45" for the same repository, "web#67"
+// for one with the same owner), with URLs shortened to owner/repo#123.
+// Anything else on a trailer line is ignored.
+func ParseDependsOn(body string) []string {
+	var refs []string
+	for _, m := range dependsOnTrailer.FindAllStringSubmatch(body, -1) {
+		for _, field := range strings.FieldsFunc(m[1], func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+			if num, ok := strings.CutPrefix(field, "#"); ok {
+				if n, err := strconv.Atoi(num); err == nil && n > 0 {
+					refs = append(refs, "#"+strconv.Itoa(n))
+				}
+				continue
+			}
+			if ref, err := ParsePRRef(field); err == nil {
+				refs = append(refs, ref.Key())
+			}
+		}
+	}
+	return refs
+}
+
+// DependsOnKeys returns the keys ("owner/repo#123") of the PRs pr depends
+// on, resolving the refs in DependsOn against pr's repository.
+func (pr *PR) DependsOnKeys() []string {
+	keys := make([]string, 0, len(pr.DependsOn))
+	for _, ref := range pr.DependsOn {
+		switch {
+		case strings.HasPrefix(ref, "#"):
+			ref = pr.RepoFullName() + ref
+		case !strings.Contains(ref, "/") && pr.RepoOwner != "":
+			ref = pr.RepoOwner + "/" + ref
+		}
+		keys = append(keys, ref)
+	}
+	return keys
+}
@@ -0,0 +1,105 @@
+package models
+
+import "testing"
+
+func TestParsePRRef(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    PRRef
+		wantErr bool
+	}{
+		{input: "org/api#123", want: PRRef{Owner: "org", Repo: "api", Number: 123}},
+		{input: "api#7", want: PRRef{Repo: "api", Number: 7}},
+		{input: " org/api#7 ", want: PRRef{Owner: "org", Repo: "api", Number: 7}},
+		{input: "https://github.com/org/api/pull/42", want: PRRef{Host: "github.com", Owner: "org", Repo: "api", Number: 42}},
+		{input: "https://GHE.corp.com/org/api/pull/42/files", want: PRRef{Host: "ghe.corp.com", Owner: "org", Repo: "api", Number: 42}},
+		{input: "org/api", wantErr: true},
+		{input: "org/api#", wantErr: true},
+		{input: "org/api#0", wantErr: true},
+		{input: "#12", wantErr: true},
+		{input: "/api#12", wantErr: true},
+		{input: "a/b/c#12", wantErr: true},
+		{input: "https://github.com/org/api/issues/42", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParsePRRef(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePRRef(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParsePRRef(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPRRef_Matches(t *testing.T) {
+	pr := &PR{RepoOwner: "Org", RepoName: "API", Number: 5}
+
+	tests := []struct {
+		ref  PRRef
+		want bool
+	}{
+		{PRRef{Owner: "org", Repo: "api", Number: 5}, true},
+		{PRRef{Repo: "api", Number: 5}, true},
+		{PRRef{Owner: "other", Repo: "api", Number: 5}, false},
+		{PRRef{Repo: "api", Number: 6}, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.ref.Matches(pr); got != tt.want {
+			t.Errorf("%s.Matches() = %v, want %v", tt.ref.Key(), got, tt.want)
+		}
+	}
+}
+
+func TestParseDependsOn(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{name: "none", body: "Fixes the login page.", want: nil},
+		{name: "one", body: "Fixes login.\n\nDepends-on: org/api#123\n", want: []string{"org/api#123"}},
+		{
+			name: "several per line",
+			body: "Depends-on: #45, web#67 https://github.com/org/lib/pull/8",
+			want: []string{"#45", "web#67", "org/lib#8"},
+		},
+		{name: "several lines", body: "depends-on: #1\r\nDEPENDS-ON: #2\r\n", want: []string{"#1", "#2"}},
+		{name: "indented", body: "  Depends-on:   org/api#9  ", want: []string{"org/api#9"}},
+		{name: "invalid refs skipped", body: "Depends-on: the API change, #0, org/api#x", want: nil},
+		{name: "not a trailer", body: "This depends-on: #5 in the middle of a line", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseDependsOn(tt.body)
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseDependsOn() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("ParseDependsOn()[%d] = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestPR_DependsOnKeys(t *testing.T) {
+	pr := &PR{RepoOwner: "org", RepoName: "web", DependsOn: []string{"#4", "api#5", "other/lib#6"}}
+
+	got := pr.DependsOnKeys()
+	want := []string{"org/web#4", "org/api#5", "other/lib#6"}
+	if len(got) != len(want) {
+		t.Fatalf("DependsOnKeys() = %v, want %v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("DependsOnKeys()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
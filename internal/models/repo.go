@@ -17,9 +17,20 @@ type Repository struct {
 	Path      string `json:"path"`       // e.g., "/Users/jdoe/code/prt"
 	RemoteURL string `json:"remote_url"` // e.g., "git@github.com:org/prt.git"
 	Owner     string `json:"owner"`      // e.g., "org"
+	Host      string `json:"host"`       // e.g., "github.com" or "github.mycorp.com"
 
 	// PRs associated with this repository
 	PRs []*PR `json:"prs"`
+	// ClosedPRs are recently merged or closed PRs, used to detect open PRs
+	// whose parent in a stack is gone. They are only fetched when the
+	// repository has open PRs, and only by batched fetches.
+	ClosedPRs []*PR `json:"closed_prs,omitempty"`
+	// Truncated is set when the repository has more open PRs than the
+	// per-repo cap (max_prs_per_repo), so PRs is incomplete.
+	Truncated bool `json:"truncated"`
+	// Cached is set when PRs were served from the on-disk cache
+	// instead of being fetched during this run.
+	Cached bool `json:"cached"`
 
 	// Scan metadata
 	// Note: ScanError is not JSON serialized because error interface doesn't marshal well
@@ -16,6 +16,12 @@ type ScanResult struct {
 	TeamPRs          []*PR `json:"team_prs"`
 	OtherPRs         []*PR `json:"other_prs"`
 
+	// Sections in display order, including the built-in ones above
+	Sections []*Section `json:"sections"`
+
+	// Snoozed and muted PRs, left out of the sections
+	SnoozedPRs []*PR `json:"snoozed_prs"`
+
 	// Repository information
 	ReposWithPRs    []*Repository `json:"repos_with_prs"`
 	ReposWithoutPRs []*Repository `json:"repos_without_prs"`
@@ -29,6 +35,9 @@ type ScanResult struct {
 	TotalPRsFound     int           `json:"total_prs_found"`
 	ScanDuration      time.Duration `json:"scan_duration_ns"`
 	Username          string        `json:"username"`
+
+	// The --query the PRs were filtered by, if any
+	Query string `json:"query,omitempty"`
 }
 
 // NewScanResult creates a new ScanResult with all slices and maps initialized.
@@ -38,6 +47,8 @@ func NewScanResult() *ScanResult {
 		NeedsMyAttention: make([]*PR, 0),
 		TeamPRs:          make([]*PR, 0),
 		OtherPRs:         make([]*PR, 0),
+		Sections:         make([]*Section, 0),
+		SnoozedPRs:       make([]*PR, 0),
 		ReposWithPRs:     make([]*Repository, 0),
 		ReposWithoutPRs:  make([]*Repository, 0),
 		ReposWithErrors:  make([]*Repository, 0),
@@ -45,9 +56,84 @@ func NewScanResult() *ScanResult {
 	}
 }
 
+// Built-in section keys; their PRs are kept in the ScanResult fields of the
+// same name rather than in Section.PRs.
+const (
+	SectionMyPRs            = "my_prs"
+	SectionNeedsMyAttention = "needs_my_attention"
+	SectionTeamPRs          = "team_prs"
+	SectionOtherPRs         = "other_prs"
+)
+
+// Section is a category of PRs defined by the sections config.
+type Section struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+	Icon string `json:"icon,omitempty"`
+	PRs  []*PR  `json:"prs"` // Nil for built-in sections
+}
+
+// IsBuiltin returns true if the section is one of the four built-in ones.
+func (s *Section) IsBuiltin() bool {
+	switch s.Key {
+	case SectionMyPRs, SectionNeedsMyAttention, SectionTeamPRs, SectionOtherPRs:
+		return true
+	}
+	return false
+}
+
+// defaultSections are the built-in sections in their default order, used
+// for results without a section layout (e.g. older history snapshots).
+var defaultSections = []*Section{
+	{Key: SectionMyPRs, Name: "My PRs"},
+	{Key: SectionNeedsMyAttention, Name: "Needs My Attention"},
+	{Key: SectionTeamPRs, Name: "Team PRs"},
+	{Key: SectionOtherPRs, Name: "Other PRs"},
+}
+
+// DisplaySections returns the sections in display order: Sections, or the
+// four built-in sections if it is empty.
+func (r *ScanResult) DisplaySections() []*Section {
+	if len(r.Sections) == 0 {
+		return defaultSections
+	}
+	return r.Sections
+}
+
+// SectionPRs returns the PRs in a section, from the matching ScanResult
+// field for built-in sections.
+func (r *ScanResult) SectionPRs(s *Section) []*PR {
+	switch s.Key {
+	case SectionMyPRs:
+		return r.MyPRs
+	case SectionNeedsMyAttention:
+		return r.NeedsMyAttention
+	case SectionTeamPRs:
+		return r.TeamPRs
+	case SectionOtherPRs:
+		return r.OtherPRs
+	}
+	return s.PRs
+}
+
+// CustomSections returns the sections that aren't built in.
+func (r *ScanResult) CustomSections() []*Section {
+	var custom []*Section
+	for _, s := range r.Sections {
+		if !s.IsBuiltin() {
+			custom = append(custom, s)
+		}
+	}
+	return custom
+}
+
 // TotalPRs returns the total count of all categorized PRs.
 func (r *ScanResult) TotalPRs() int {
-	return len(r.MyPRs) + len(r.NeedsMyAttention) + len(r.TeamPRs) + len(r.OtherPRs)
+	total := len(r.MyPRs) + len(r.NeedsMyAttention) + len(r.TeamPRs) + len(r.OtherPRs)
+	for _, s := range r.CustomSections() {
+		total += len(s.PRs)
+	}
+	return total
 }
 
 // HasPRs returns true if there are any PRs in any category.
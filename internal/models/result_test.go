@@ -59,6 +59,37 @@ func TestScanResult_TotalPRs(t *testing.T) {
 	if result.TotalPRs() != 7 {
 		t.Errorf("TotalPRs() = %d, want 7", result.TotalPRs())
 	}
+
+	result.Sections = []*Section{{Key: SectionMyPRs}, {Key: "security", PRs: []*PR{{Number: 8}}}}
+	if result.TotalPRs() != 8 {
+		t.Errorf("TotalPRs() with a custom section = %d, want 8", result.TotalPRs())
+	}
+}
+
+func TestScanResult_DisplaySections(t *testing.T) {
+	result := NewScanResult()
+	result.MyPRs = []*PR{{Number: 1}}
+
+	// Without a layout, the built-in sections in their default order
+	sections := result.DisplaySections()
+	if len(sections) != 4 || sections[0].Key != SectionMyPRs || sections[3].Key != SectionOtherPRs {
+		t.Fatalf("DisplaySections() = %+v, want the built-in sections", sections)
+	}
+	if prs := result.SectionPRs(sections[0]); len(prs) != 1 || prs[0].Number != 1 {
+		t.Errorf("SectionPRs(my_prs) = %v, want MyPRs", prs)
+	}
+
+	security := &Section{Key: "security", Name: "Security", PRs: []*PR{{Number: 2}}}
+	result.Sections = []*Section{security, {Key: SectionMyPRs, Name: "My PRs"}}
+	if sections := result.DisplaySections(); len(sections) != 2 || sections[0] != security {
+		t.Errorf("DisplaySections() = %+v, want the result's sections", sections)
+	}
+	if prs := result.SectionPRs(security); len(prs) != 1 || prs[0].Number != 2 {
+		t.Errorf("SectionPRs(security) = %v, want the section's PRs", prs)
+	}
+	if custom := result.CustomSections(); len(custom) != 1 || custom[0] != security {
+		t.Errorf("CustomSections() = %+v, want only security", custom)
+	}
 }
 
 func TestScanResult_HasPRs(t *testing.T) {
@@ -12,6 +12,12 @@ type StackNode struct {
 	// Child nodes (PRs that depend on this one)
 	Children []*StackNode `json:"children,omitempty"`
 
+	// PRs this one depends on, and PRs that depend on it, through
+	// Depends-on trailers, in any repository (excluded from JSON, where
+	// PR.DependsOn names them)
+	DependsOn  []*StackNode `json:"-"`
+	Dependents []*StackNode `json:"-"`
+
 	// Stack metadata
 	Depth    int  `json:"depth"`     // 0 = root
 	IsOrphan bool `json:"is_orphan"` // Parent was merged but this PR still targets that branch
@@ -27,16 +33,19 @@ type Stack struct {
 	AllNodes []*StackNode `json:"-"`
 }
 
-// IsBlocked returns true if this PR has an unmerged parent PR.
-// A blocked PR cannot be merged until its parent is merged first.
+// IsBlocked returns true if this PR has an unmerged parent PR, or depends
+// on an unmerged PR. A blocked PR cannot be merged until its parent and
+// dependencies are merged first.
 func (n *StackNode) IsBlocked() bool {
-	if n.Parent == nil {
-		return false
-	}
 	// If parent exists and is not merged, this PR is blocked
-	if n.Parent.PR != nil && n.Parent.PR.State != PRStateMerged {
+	if n.Parent != nil && n.Parent.PR != nil && n.Parent.PR.State != PRStateMerged {
 		return true
 	}
+	for _, dep := range n.DependsOn {
+		if dep.PR != nil && dep.PR.State != PRStateMerged {
+			return true
+		}
+	}
 	return false
 }
 
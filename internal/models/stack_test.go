@@ -59,6 +59,27 @@ func TestStackNode_IsBlocked(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "dependency open - blocked",
+			node: &StackNode{
+				PR: &PR{Number: 2, State: PRStateOpen},
+				DependsOn: []*StackNode{
+					{PR: &PR{Number: 1, State: PRStateMerged}},
+					{PR: &PR{Number: 7, State: PRStateOpen}},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "dependencies merged - not blocked",
+			node: &StackNode{
+				PR: &PR{Number: 2, State: PRStateOpen},
+				DependsOn: []*StackNode{
+					{PR: &PR{Number: 1, State: PRStateMerged}},
+				},
+			},
+			want: false,
+		},
 	}
 
 	for _, tt := range tests {
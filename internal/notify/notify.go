@@ -0,0 +1,200 @@
+// Package notify alerts the user, through a configurable command, when PRs
+// newly need their attention between runs.
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"prt/internal/models"
+)
+
+// EventType identifies what happened to a PR.
+type EventType string
+
+const (
+	// EventNeedsAttention fires when a PR enters Needs My Attention.
+	EventNeedsAttention EventType = "needs_attention"
+	// EventCIFailed fires when CI starts failing on one of my PRs.
+	EventCIFailed EventType = "ci_failed"
+	// EventApproved fires when someone approves one of my PRs.
+	EventApproved EventType = "approved"
+)
+
+// Event is one notification. It is passed to the notify command as JSON
+// on stdin.
+type Event struct {
+	Type    EventType  `json:"type"`
+	Title   string     `json:"title"`
+	Message string     `json:"message"`
+	URL     string     `json:"url"`
+	PR      *models.PR `json:"pr"`
+}
+
+// Detect compares a scan against the previous state and returns the events
+// to fire along with the state to save. With no previous state (the first
+// run) nothing fires, so enabling notifications doesn't replay every open
+// PR. PRs in repositories that failed to scan keep their previous state, so
+// a transient error doesn't cause duplicate alerts once the repo recovers.
+func Detect(prev *State, result *models.ScanResult) ([]Event, *State) {
+	next := NewState(result)
+
+	if prev == nil {
+		return nil, next
+	}
+
+	failed := make(map[string]bool)
+	for _, repo := range result.ReposWithErrors {
+		failed[repo.FullName()] = true
+	}
+	for key, st := range prev.PRs {
+		if _, ok := next.PRs[key]; !ok && failed[repoOfKey(key)] {
+			next.PRs[key] = st
+		}
+	}
+
+	var events []Event
+
+	for _, pr := range result.NeedsMyAttention {
+		if !prev.PRs[pr.Key()].NeedsAttention {
+			events = append(events, newEvent(EventNeedsAttention, pr, attentionTitle(pr),
+				fmt.Sprintf("%s by @%s", pr.Title, pr.Author)))
+		}
+	}
+
+	for _, pr := range result.MyPRs {
+		old := prev.PRs[pr.Key()]
+
+		if pr.CIStatus == models.CIStatusFailing && !old.CIFailing {
+			events = append(events, newEvent(EventCIFailed, pr,
+				fmt.Sprintf("CI failing on %s", pr.Key()), pr.Title))
+		}
+
+		if newApprovers := missing(approvers(pr), old.ApprovedBy); len(newApprovers) > 0 {
+			events = append(events, newEvent(EventApproved, pr,
+				fmt.Sprintf("%s approved by @%s", pr.Key(), strings.Join(newApprovers, ", @")), pr.Title))
+		}
+	}
+
+	return events, next
+}
+
+// newEvent builds an event for pr.
+func newEvent(typ EventType, pr *models.PR, title, message string) Event {
+	return Event{Type: typ, Title: title, Message: message, URL: pr.URL, PR: pr}
+}
+
+// attentionTitle says why a PR needs attention.
+func attentionTitle(pr *models.PR) string {
+	if pr.NeedsReReview {
+		return fmt.Sprintf("Re-review needed: %s", pr.Key())
+	}
+	if pr.IsAssignedToMe && !pr.IsReviewRequestedFromMe {
+		return fmt.Sprintf("Assigned to you: %s", pr.Key())
+	}
+	if pr.RequestedTeam != "" {
+		return fmt.Sprintf("Review requested from @%s: %s", pr.RequestedTeam, pr.Key())
+	}
+	return fmt.Sprintf("Review requested: %s", pr.Key())
+}
+
+// missing returns the names in curr that are not in prev.
+func missing(curr, prev []string) []string {
+	seen := make(map[string]bool, len(prev))
+	for _, name := range prev {
+		seen[name] = true
+	}
+	var out []string
+	for _, name := range curr {
+		if !seen[name] {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+// repoOfKey returns the repository part of a PR key ("owner/repo#1").
+func repoOfKey(key string) string {
+	if i := strings.LastIndex(key, "#"); i >= 0 {
+		return key[:i]
+	}
+	return key
+}
+
+// Notifier runs the notify command for each event and remembers what was
+// notified in a state file.
+type Notifier struct {
+	command   string
+	statePath string
+	// execCommand allows mocking exec.Command for testing
+	execCommand func(name string, arg ...string) *exec.Cmd
+}
+
+// NewNotifier creates a notifier running command (a shell command line)
+// and keeping its state at statePath.
+func NewNotifier(command, statePath string) *Notifier {
+	return &Notifier{
+		command:     command,
+		statePath:   statePath,
+		execCommand: exec.Command,
+	}
+}
+
+// Process fires the events for result and saves the new state. The state
+// is saved even if the command fails, so a broken command doesn't repeat
+// the same alerts on every run.
+func (n *Notifier) Process(result *models.ScanResult) error {
+	prev, err := LoadState(n.statePath)
+	if err != nil {
+		return fmt.Errorf("failed to read notification state: %w", err)
+	}
+
+	events, next := Detect(prev, result)
+
+	var errs []string
+	for _, event := range events {
+		if err := n.send(event); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if err := next.Save(n.statePath); err != nil {
+		return fmt.Errorf("failed to save notification state: %w", err)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("notify_command failed: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// send runs the command for one event, with the event as JSON on stdin and
+// its main fields in PRT_NOTIFY_* environment variables.
+func (n *Notifier) send(event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	cmd := n.execCommand("sh", "-c", n.command)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = append(os.Environ(),
+		"PRT_NOTIFY_TYPE="+string(event.Type),
+		"PRT_NOTIFY_TITLE="+event.Title,
+		"PRT_NOTIFY_MESSAGE="+event.Message,
+		"PRT_NOTIFY_URL="+event.URL,
+	)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
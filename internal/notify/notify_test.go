@@ -0,0 +1,181 @@
+package notify
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"prt/internal/models"
+)
+
+func attention(number int) *models.PR {
+	return &models.PR{Number: number, Title: "Review me", Author: "alice", RepoOwner: "org", RepoName: "api",
+		URL: "https://github.com/org/api/pull/1", IsReviewRequestedFromMe: true}
+}
+
+func mine(number int, ci models.CIStatus, approvers ...string) *models.PR {
+	pr := &models.PR{Number: number, Title: "My change", RepoOwner: "org", RepoName: "web", CIStatus: ci}
+	for _, a := range approvers {
+		pr.Reviews = append(pr.Reviews, models.Review{Author: a, State: models.ReviewStateApproved})
+	}
+	return pr
+}
+
+func TestDetect(t *testing.T) {
+	prev := &State{Version: stateVersion, PRs: map[string]PRState{
+		"org/api#1": {NeedsAttention: true},
+		"org/web#5": {ApprovedBy: []string{"bob"}},
+		"org/web#6": {CIFailing: true},
+	}}
+
+	result := models.NewScanResult()
+	result.NeedsMyAttention = []*models.PR{
+		attention(1), // Already notified
+		attention(2), // New
+		{Number: 3, RepoOwner: "org", RepoName: "api", Author: "carol", IsAssignedToMe: true},
+		{Number: 4, RepoOwner: "org", RepoName: "api", Author: "carol", NeedsReReview: true},
+		{Number: 8, RepoOwner: "org", RepoName: "api", Author: "carol", IsReviewRequestedFromMe: true, RequestedTeam: "org/backend"},
+	}
+	result.MyPRs = []*models.PR{
+		mine(5, models.CIStatusPassing, "bob", "carol"), // carol is new
+		mine(6, models.CIStatusFailing),                 // Still failing
+		mine(7, models.CIStatusFailing),                 // Newly failing
+	}
+
+	events, next := Detect(prev, result)
+
+	want := []struct {
+		typ   EventType
+		title string
+	}{
+		{EventNeedsAttention, "Review requested: org/api#2"},
+		{EventNeedsAttention, "Assigned to you: org/api#3"},
+		{EventNeedsAttention, "Re-review needed: org/api#4"},
+		{EventNeedsAttention, "Review requested from @org/backend: org/api#8"},
+		{EventApproved, "org/web#5 approved by @carol"},
+		{EventCIFailed, "CI failing on org/web#7"},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("Detect() returned %d events, want %d: %+v", len(events), len(want), events)
+	}
+	for i, w := range want {
+		if events[i].Type != w.typ || events[i].Title != w.title {
+			t.Errorf("event %d = %s %q, want %s %q", i, events[i].Type, events[i].Title, w.typ, w.title)
+		}
+	}
+	if events[0].Message != "Review me by @alice" || events[0].URL == "" || events[0].PR == nil {
+		t.Errorf("event payload = %+v", events[0])
+	}
+
+	// Running again with the new state fires nothing
+	if again, _ := Detect(next, result); len(again) != 0 {
+		t.Errorf("expected no duplicate events, got %+v", again)
+	}
+}
+
+func TestDetect_FirstRun(t *testing.T) {
+	result := models.NewScanResult()
+	result.NeedsMyAttention = []*models.PR{attention(1)}
+
+	events, next := Detect(nil, result)
+	if len(events) != 0 {
+		t.Errorf("expected no events on the first run, got %+v", events)
+	}
+	if !next.PRs["org/api#1"].NeedsAttention {
+		t.Error("expected the first run to record state")
+	}
+}
+
+func TestDetect_KeepsStateOfFailedRepos(t *testing.T) {
+	prev := &State{Version: stateVersion, PRs: map[string]PRState{
+		"org/api#1": {NeedsAttention: true},
+		"org/old#9": {NeedsAttention: true},
+	}}
+
+	// org/api failed to scan; org/old's PR is gone for good
+	failed := models.NewScanResult()
+	failed.ReposWithErrors = []*models.Repository{{Owner: "org", Name: "api"}}
+	_, next := Detect(prev, failed)
+
+	if !next.PRs["org/api#1"].NeedsAttention {
+		t.Error("expected state of the failed repo to be kept")
+	}
+	if _, ok := next.PRs["org/old#9"]; ok {
+		t.Error("expected state of closed PRs to be dropped")
+	}
+
+	recovered := models.NewScanResult()
+	recovered.NeedsMyAttention = []*models.PR{attention(1)}
+	if events, _ := Detect(next, recovered); len(events) != 0 {
+		t.Errorf("expected no duplicate alert after the repo recovered, got %+v", events)
+	}
+}
+
+func TestNotifier_Process(t *testing.T) {
+	dir := t.TempDir()
+	statePath := filepath.Join(dir, "state.json")
+	out := filepath.Join(dir, "out")
+
+	// The command appends the env vars and stdin payload to a file
+	n := NewNotifier(`printf '%s|%s|%s\n' "$PRT_NOTIFY_TYPE" "$PRT_NOTIFY_TITLE" "$PRT_NOTIFY_URL" >> `+out+`; cat >> `+out, statePath)
+
+	before := models.NewScanResult()
+	if err := n.Process(before); err != nil {
+		t.Fatalf("first Process() error = %v", err)
+	}
+	if _, err := os.Stat(out); err == nil {
+		t.Error("expected no notification on the first run")
+	}
+
+	after := models.NewScanResult()
+	after.NeedsMyAttention = []*models.PR{attention(1)}
+	if err := n.Process(after); err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if err := n.Process(after); err != nil {
+		t.Fatalf("repeated Process() error = %v", err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("expected the command to run: %v", err)
+	}
+	lines := strings.SplitN(string(data), "\n", 2)
+	if lines[0] != "needs_attention|Review requested: org/api#1|https://github.com/org/api/pull/1" {
+		t.Errorf("env vars = %q", lines[0])
+	}
+
+	var event Event
+	if err := json.Unmarshal([]byte(lines[1]), &event); err != nil {
+		t.Fatalf("stdin payload is not a single JSON event (duplicate alert?): %v\n%s", err, lines[1])
+	}
+	if event.Type != EventNeedsAttention || event.PR == nil || event.PR.Number != 1 {
+		t.Errorf("payload = %+v", event)
+	}
+}
+
+func TestNotifier_CommandFailure(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	(&State{Version: stateVersion, PRs: map[string]PRState{}}).Save(statePath)
+
+	n := NewNotifier("echo boom >&2; exit 3", statePath)
+	result := models.NewScanResult()
+	result.NeedsMyAttention = []*models.PR{attention(1)}
+
+	err := n.Process(result)
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("Process() error = %v, want the command's stderr", err)
+	}
+
+	// The event is still recorded, so a broken command doesn't repeat it
+	n.execCommand = func(name string, arg ...string) *exec.Cmd {
+		t.Error("expected no command to run for an already-recorded event")
+		return exec.Command("true")
+	}
+	if err := n.Process(result); err != nil {
+		t.Errorf("second Process() error = %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package notify
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"prt/internal/config"
+	"prt/internal/models"
+)
+
+// stateVersion is bumped whenever the state format changes. A state file
+// with a different version is treated as missing.
+const stateVersion = 1
+
+// StatePath returns the default state file: ~/.prt/notify-state.json
+func StatePath() string {
+	return filepath.Join(config.ConfigDir(), "notify-state.json")
+}
+
+// State records what was already notified, so each event fires once no
+// matter how often prt runs in between.
+type State struct {
+	Version int `json:"version"`
+	// PRs maps PR keys (see models.PR.Key) to what was last seen.
+	PRs map[string]PRState `json:"prs"`
+}
+
+// PRState is the notification-relevant state of one PR.
+type PRState struct {
+	NeedsAttention bool     `json:"needs_attention,omitempty"`
+	CIFailing      bool     `json:"ci_failing,omitempty"`
+	ApprovedBy     []string `json:"approved_by,omitempty"`
+}
+
+// LoadState reads the state file at path. A missing or outdated file yields
+// nil, meaning there is no previous run to compare against.
+func LoadState(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil || s.Version != stateVersion {
+		return nil, nil
+	}
+	if s.PRs == nil {
+		s.PRs = make(map[string]PRState)
+	}
+	return &s, nil
+}
+
+// Save writes the state to path atomically, so a concurrent run (e.g. cron
+// and watch mode) never reads a partial file.
+func (s *State) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".notify-state-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// NewState captures the notification-relevant state of a scan.
+func NewState(result *models.ScanResult) *State {
+	s := &State{Version: stateVersion, PRs: make(map[string]PRState)}
+
+	for _, pr := range result.NeedsMyAttention {
+		s.PRs[pr.Key()] = PRState{NeedsAttention: true}
+	}
+	for _, pr := range result.MyPRs {
+		st := s.PRs[pr.Key()]
+		st.CIFailing = pr.CIStatus == models.CIStatusFailing
+		st.ApprovedBy = approvers(pr)
+		s.PRs[pr.Key()] = st
+	}
+
+	return s
+}
+
+// approvers returns the sorted, distinct authors of approving reviews.
+func approvers(pr *models.PR) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, r := range pr.Reviews {
+		if r.State == models.ReviewStateApproved && !seen[r.Author] {
+			seen[r.Author] = true
+			names = append(names, r.Author)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
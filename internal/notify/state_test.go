@@ -0,0 +1,86 @@
+package notify
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"prt/internal/models"
+)
+
+func TestState_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "state.json")
+
+	s := &State{Version: stateVersion, PRs: map[string]PRState{
+		"org/api#1": {NeedsAttention: true},
+		"org/api#2": {CIFailing: true, ApprovedBy: []string{"bob"}},
+	}}
+	if err := s.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	got, err := LoadState(path)
+	if err != nil || got == nil {
+		t.Fatalf("LoadState() = %v, %v", got, err)
+	}
+	if !got.PRs["org/api#1"].NeedsAttention || !got.PRs["org/api#2"].CIFailing ||
+		len(got.PRs["org/api#2"].ApprovedBy) != 1 {
+		t.Errorf("LoadState() = %+v, want saved state", got.PRs)
+	}
+}
+
+func TestLoadState_MissingOrOutdated(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"missing", ""},
+		{"corrupt", "{not json"},
+		{"old version", `{"version": 0, "prs": {}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.name+".json")
+			if tt.content != "" {
+				os.WriteFile(path, []byte(tt.content), 0644)
+			}
+			got, err := LoadState(path)
+			if err != nil || got != nil {
+				t.Errorf("LoadState() = %v, %v; want no previous state", got, err)
+			}
+		})
+	}
+}
+
+func TestNewState(t *testing.T) {
+	result := models.NewScanResult()
+	result.NeedsMyAttention = []*models.PR{{Number: 1, RepoOwner: "org", RepoName: "api"}}
+	result.MyPRs = []*models.PR{{
+		Number: 2, RepoOwner: "org", RepoName: "api", CIStatus: models.CIStatusFailing,
+		Reviews: []models.Review{
+			{Author: "carol", State: models.ReviewStateApproved},
+			{Author: "bob", State: models.ReviewStateApproved},
+			{Author: "bob", State: models.ReviewStateApproved},
+			{Author: "dave", State: models.ReviewStateCommented},
+		},
+	}}
+
+	s := NewState(result)
+
+	if !s.PRs["org/api#1"].NeedsAttention {
+		t.Error("expected org/api#1 to need attention")
+	}
+	mine := s.PRs["org/api#2"]
+	if !mine.CIFailing || len(mine.ApprovedBy) != 2 || mine.ApprovedBy[0] != "bob" || mine.ApprovedBy[1] != "carol" {
+		t.Errorf("org/api#2 state = %+v, want failing CI approved by [bob carol]", mine)
+	}
+}
+
+func TestStatePath(t *testing.T) {
+	if filepath.Base(StatePath()) != "notify-state.json" {
+		t.Errorf("StatePath() = %q", StatePath())
+	}
+}
@@ -0,0 +1,200 @@
+// Package priority ranks PRs by how much they need the current user's
+// action, weighing review requests, CI state, approvals, stacked PRs that
+// wait on them, age, and size.
+package priority
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"prt/internal/config"
+	"prt/internal/models"
+)
+
+// MaxAgeDays caps how many days of age count towards a PR's score.
+const MaxAgeDays = 30
+
+// SizeLimitLines is the number of changed lines at which a PR no longer
+// gets the small-PR bonus.
+const SizeLimitLines = 1000
+
+// Item is a scored PR.
+type Item struct {
+	PR     *models.PR `json:"pr"`
+	Score  float64    `json:"score"`
+	Action string     `json:"action"` // What to do, e.g. "Review requested"; empty if not actionable
+	Reason string     `json:"reason"` // One line explaining the score
+}
+
+// IsActionable returns true if the PR calls for an action by the user.
+func (i Item) IsActionable() bool {
+	return i.Action != ""
+}
+
+// Scorer scores the PRs of one scan result.
+type Scorer struct {
+	weights  config.PriorityWeights
+	username string
+	team     map[string]bool
+	blocking map[string]int // PR key -> number of PRs stacked on it or depending on it
+	blocked  map[string]bool
+	now      time.Time
+}
+
+// NewScorer creates a Scorer for PRs in result, using the weights and team
+// from cfg, with ages measured at now.
+func NewScorer(result *models.ScanResult, cfg *config.Config, now time.Time) *Scorer {
+	s := &Scorer{
+		weights:  cfg.Priority,
+		username: result.Username,
+		team:     make(map[string]bool),
+		blocking: make(map[string]int),
+		blocked:  make(map[string]bool),
+		now:      now,
+	}
+	for _, member := range cfg.TeamMembers {
+		s.team[member] = true
+	}
+	for _, stack := range result.Stacks {
+		for _, node := range stack.AllNodes {
+			if node.PR == nil {
+				continue
+			}
+			s.blocking[node.PR.Key()] = countDescendants(node) + len(node.Dependents)
+			s.blocked[node.PR.Key()] = node.IsBlocked()
+		}
+	}
+	return s
+}
+
+// countDescendants returns the number of PRs stacked on top of node,
+// directly or indirectly.
+func countDescendants(node *models.StackNode) int {
+	n := 0
+	for _, child := range node.Children {
+		n += 1 + countDescendants(child)
+	}
+	return n
+}
+
+// factor is one contribution to a PR's score.
+type factor struct {
+	weight float64
+	label  string
+}
+
+// Score scores pr. PRs that call for no action get a zero score.
+func (s *Scorer) Score(pr *models.PR) Item {
+	actions := s.actions(pr)
+	if len(actions) == 0 {
+		return Item{PR: pr}
+	}
+
+	factors := actions
+	if n := s.blocking[pr.Key()]; n > 0 {
+		factors = append(factors, factor{s.weights.Blocking * float64(n), plural(n, "blocks %d PR", "blocks %d PRs")})
+	}
+	if days := int(s.now.Sub(pr.CreatedAt).Hours() / 24); days > 0 && !pr.CreatedAt.IsZero() {
+		label := fmt.Sprintf("open %dd", days)
+		if days > MaxAgeDays {
+			days = MaxAgeDays
+		}
+		factors = append(factors, factor{s.weights.AgePerDay * float64(days), label})
+	}
+	if lines := pr.ChangedLines(); lines > 0 {
+		bonus := 0.0
+		if lines < SizeLimitLines {
+			bonus = s.weights.SmallSize * float64(SizeLimitLines-lines) / SizeLimitLines
+		}
+		factors = append(factors, factor{bonus, fmt.Sprintf("+%d/-%d", pr.Additions, pr.Deletions)})
+	}
+
+	item := Item{PR: pr, Action: actions[0].label}
+	labels := make([]string, 0, len(factors))
+	for _, f := range factors {
+		item.Score += f.weight
+		labels = append(labels, f.label)
+	}
+	item.Reason = strings.Join(labels, " · ")
+	return item
+}
+
+// actions returns the action factors that apply to pr, heaviest first.
+func (s *Scorer) actions(pr *models.PR) []factor {
+	w := s.weights
+	var actions []factor
+
+	if pr.Author == s.username {
+		ci := pr.CIStatus
+		review := pr.OverallReview()
+		if ci == models.CIStatusFailing {
+			actions = append(actions, factor{w.CIFailing, "CI failing"})
+		}
+		if review == models.ReviewStateChangesRequested {
+			actions = append(actions, factor{w.ChangesRequested, "Changes requested"})
+		}
+		if review == models.ReviewStateApproved && !pr.IsDraft && !s.blocked[pr.Key()] &&
+			ci != models.CIStatusFailing && ci != models.CIStatusPending {
+			actions = append(actions, factor{w.ReadyToMerge, "Ready to merge"})
+		}
+	} else if !pr.IsDraft {
+		switch {
+		case pr.NeedsReReview:
+			actions = append(actions, factor{w.ReReview, "Re-review: new commits since your review"})
+		case pr.IsReviewRequestedFromMe && pr.MyReviewStatus != models.ReviewStateApproved:
+			label := "Review requested"
+			if pr.RequestedTeam != "" {
+				label += " from @" + pr.RequestedTeam
+			}
+			actions = append(actions, factor{w.ReviewRequested, label})
+		case pr.IsAssignedToMe && pr.MyReviewStatus != models.ReviewStateApproved:
+			actions = append(actions, factor{w.ReviewRequested, "Assigned to you"})
+		}
+		if s.team[pr.Author] && pr.MyReviewStatus == models.ReviewStateNone &&
+			pr.OverallReview() != models.ReviewStateApproved {
+			actions = append(actions, factor{w.ApprovalsPending, "Awaiting approval"})
+		}
+	}
+
+	sort.SliceStable(actions, func(i, j int) bool {
+		return actions[i].weight > actions[j].weight
+	})
+	return actions
+}
+
+// Next returns up to limit actionable PRs from result, highest score first
+// (oldest first for equal scores). A limit of 0 returns all of them.
+func Next(result *models.ScanResult, cfg *config.Config, now time.Time, limit int) []Item {
+	scorer := NewScorer(result, cfg, now)
+
+	items := make([]Item, 0)
+	for _, section := range result.DisplaySections() {
+		for _, pr := range result.SectionPRs(section) {
+			if item := scorer.Score(pr); item.IsActionable() {
+				items = append(items, item)
+			}
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Score != items[j].Score {
+			return items[i].Score > items[j].Score
+		}
+		return items[i].PR.CreatedAt.Before(items[j].PR.CreatedAt)
+	})
+
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	return items
+}
+
+// plural formats n with one if it is 1, otherwise with many.
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return fmt.Sprintf(one, n)
+	}
+	return fmt.Sprintf(many, n)
+}
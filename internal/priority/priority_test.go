@@ -0,0 +1,304 @@
+package priority
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"prt/internal/config"
+	"prt/internal/models"
+	"prt/internal/stacks"
+)
+
+var now = time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+// testPR builds a PR in org/api opened the given number of days ago.
+func testPR(number int, author string, daysOld int) *models.PR {
+	return &models.PR{
+		Number:    number,
+		Title:     "PR",
+		Author:    author,
+		State:     models.PRStateOpen,
+		RepoOwner: "org",
+		RepoName:  "api",
+		CIStatus:  models.CIStatusPassing,
+		CreatedAt: now.Add(-time.Duration(daysOld) * 24 * time.Hour),
+		// Set by the categorizer for every PR
+		MyReviewStatus: models.ReviewStateNone,
+	}
+}
+
+func approval(author string) models.Review {
+	return models.Review{Author: author, State: models.ReviewStateApproved, Submitted: now.Add(-time.Hour)}
+}
+
+func testConfig() *config.Config {
+	return &config.Config{TeamMembers: []string{"alice"}, Priority: config.DefaultPriorityWeights()}
+}
+
+func TestScorer_Score(t *testing.T) {
+	w := config.DefaultPriorityWeights()
+
+	tests := []struct {
+		name       string
+		pr         func() *models.PR
+		wantAction string
+		wantScore  float64
+		wantReason string
+	}{
+		{
+			name: "review requested",
+			pr: func() *models.PR {
+				pr := testPR(1, "bob", 0)
+				pr.IsReviewRequestedFromMe = true
+				return pr
+			},
+			wantAction: "Review requested",
+			wantScore:  w.ReviewRequested,
+			wantReason: "Review requested",
+		},
+		{
+			name: "team review request names the team",
+			pr: func() *models.PR {
+				pr := testPR(1, "bob", 0)
+				pr.IsReviewRequestedFromMe = true
+				pr.RequestedTeam = "org/backend"
+				return pr
+			},
+			wantAction: "Review requested from @org/backend",
+			wantScore:  w.ReviewRequested,
+		},
+		{
+			name: "already approved by me",
+			pr: func() *models.PR {
+				pr := testPR(1, "bob", 0)
+				pr.IsReviewRequestedFromMe = true
+				pr.MyReviewStatus = models.ReviewStateApproved
+				return pr
+			},
+		},
+		{
+			name: "re-review outranks the request",
+			pr: func() *models.PR {
+				pr := testPR(1, "bob", 0)
+				pr.IsReviewRequestedFromMe = true
+				pr.NeedsReReview = true
+				pr.MyReviewStatus = models.ReviewStateChangesRequested
+				return pr
+			},
+			wantAction: "Re-review: new commits since your review",
+			wantScore:  w.ReReview,
+		},
+		{
+			name: "drafts by others are not actionable",
+			pr: func() *models.PR {
+				pr := testPR(1, "bob", 0)
+				pr.IsDraft = true
+				pr.IsReviewRequestedFromMe = true
+				return pr
+			},
+		},
+		{
+			name: "my PR with failing CI and changes requested",
+			pr: func() *models.PR {
+				pr := testPR(1, "me", 0)
+				pr.CIStatus = models.CIStatusFailing
+				pr.Reviews = []models.Review{{Author: "bob", State: models.ReviewStateChangesRequested, Submitted: now}}
+				return pr
+			},
+			wantAction: "CI failing",
+			wantScore:  w.CIFailing + w.ChangesRequested,
+			wantReason: "CI failing · Changes requested",
+		},
+		{
+			name: "my approved PR is ready to merge",
+			pr: func() *models.PR {
+				pr := testPR(1, "me", 0)
+				pr.Reviews = []models.Review{approval("bob")}
+				return pr
+			},
+			wantAction: "Ready to merge",
+			wantScore:  w.ReadyToMerge,
+		},
+		{
+			name: "my approved PR with pending CI is not ready",
+			pr: func() *models.PR {
+				pr := testPR(1, "me", 0)
+				pr.CIStatus = models.CIStatusPending
+				pr.Reviews = []models.Review{approval("bob")}
+				return pr
+			},
+		},
+		{
+			name: "my PR waiting on reviewers",
+			pr:   func() *models.PR { return testPR(1, "me", 3) },
+		},
+		{
+			name:       "teammate's PR awaiting approval",
+			pr:         func() *models.PR { return testPR(1, "alice", 0) },
+			wantAction: "Awaiting approval",
+			wantScore:  w.ApprovalsPending,
+		},
+		{
+			name: "teammate's approved PR",
+			pr: func() *models.PR {
+				pr := testPR(1, "alice", 0)
+				pr.Reviews = []models.Review{approval("bob")}
+				return pr
+			},
+		},
+		{
+			name: "age is capped",
+			pr: func() *models.PR {
+				pr := testPR(1, "bob", 45)
+				pr.IsAssignedToMe = true
+				return pr
+			},
+			wantAction: "Assigned to you",
+			wantScore:  w.ReviewRequested + MaxAgeDays*w.AgePerDay,
+			wantReason: "Assigned to you · open 45d",
+		},
+		{
+			name: "small PRs get a bonus",
+			pr: func() *models.PR {
+				pr := testPR(1, "bob", 0)
+				pr.IsReviewRequestedFromMe = true
+				pr.Additions, pr.Deletions = 180, 20
+				return pr
+			},
+			wantAction: "Review requested",
+			wantScore:  w.ReviewRequested + w.SmallSize*0.8,
+			wantReason: "Review requested · +180/-20",
+		},
+		{
+			name: "large PRs get no bonus",
+			pr: func() *models.PR {
+				pr := testPR(1, "bob", 0)
+				pr.IsReviewRequestedFromMe = true
+				pr.Additions = 1500
+				return pr
+			},
+			wantAction: "Review requested",
+			wantScore:  w.ReviewRequested,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := models.NewScanResult()
+			result.Username = "me"
+			item := NewScorer(result, testConfig(), now).Score(tt.pr())
+
+			if item.Action != tt.wantAction {
+				t.Errorf("Action = %q, want %q", item.Action, tt.wantAction)
+			}
+			if diff := item.Score - tt.wantScore; diff > 1e-9 || diff < -1e-9 {
+				t.Errorf("Score = %v, want %v", item.Score, tt.wantScore)
+			}
+			if tt.wantReason != "" && item.Reason != tt.wantReason {
+				t.Errorf("Reason = %q, want %q", item.Reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestScorer_Blocking(t *testing.T) {
+	base := testPR(1, "me", 0)
+	base.HeadBranch = "feature-a"
+	base.BaseBranch = "main"
+	base.Reviews = []models.Review{approval("bob")}
+	middle := testPR(2, "me", 0)
+	middle.HeadBranch = "feature-b"
+	middle.BaseBranch = "feature-a"
+	middle.Reviews = []models.Review{approval("bob")}
+	top := testPR(3, "me", 0)
+	top.HeadBranch = "feature-c"
+	top.BaseBranch = "feature-b"
+
+	result := models.NewScanResult()
+	result.Username = "me"
+	result.MyPRs = []*models.PR{base, middle, top}
+	result.Stacks["org/api"] = stacks.DetectStacks(result.MyPRs)
+
+	scorer := NewScorer(result, testConfig(), now)
+	w := config.DefaultPriorityWeights()
+
+	item := scorer.Score(base)
+	if item.Score != w.ReadyToMerge+2*w.Blocking || !strings.Contains(item.Reason, "blocks 2 PRs") {
+		t.Errorf("base = %+v, want ready to merge and blocking 2 PRs", item)
+	}
+	if item := scorer.Score(middle); item.IsActionable() {
+		t.Errorf("middle = %+v, want not actionable: it can't merge before its parent", item)
+	}
+}
+
+func TestScorer_BlockingDependents(t *testing.T) {
+	lib := testPR(1, "me", 0)
+	lib.RepoName = "lib"
+	lib.Reviews = []models.Review{approval("bob")}
+	app := testPR(2, "me", 0)
+	app.Reviews = []models.Review{approval("bob")}
+	app.DependsOn = []string{"org/lib#1"}
+
+	result := models.NewScanResult()
+	result.Username = "me"
+	result.MyPRs = []*models.PR{lib, app}
+	result.Stacks["org/lib"] = stacks.DetectStacks([]*models.PR{lib})
+	result.Stacks["org/api"] = stacks.DetectStacks([]*models.PR{app})
+	stacks.LinkDependencies(result.Stacks)
+
+	scorer := NewScorer(result, testConfig(), now)
+	w := config.DefaultPriorityWeights()
+
+	if item := scorer.Score(lib); item.Score != w.ReadyToMerge+w.Blocking || !strings.Contains(item.Reason, "blocks 1 PR") {
+		t.Errorf("lib = %+v, want ready to merge and blocking 1 PR", item)
+	}
+	if item := scorer.Score(app); item.IsActionable() {
+		t.Errorf("app = %+v, want not actionable: it can't merge before its dependency", item)
+	}
+}
+
+func TestNext(t *testing.T) {
+	requested := testPR(1, "bob", 2)
+	requested.IsReviewRequestedFromMe = true
+	failing := testPR(2, "me", 1)
+	failing.CIStatus = models.CIStatusFailing
+	waiting := testPR(3, "me", 1)
+	teammate := testPR(4, "alice", 5)
+	older := testPR(5, "bob", 2)
+	older.CreatedAt = older.CreatedAt.Add(-time.Hour)
+	older.IsAssignedToMe = true
+	custom := testPR(6, "carol", 0)
+	custom.NeedsReReview = true
+
+	result := models.NewScanResult()
+	result.Username = "me"
+	result.MyPRs = []*models.PR{failing, waiting}
+	result.NeedsMyAttention = []*models.PR{requested, older}
+	result.TeamPRs = []*models.PR{teammate}
+	result.Sections = []*models.Section{
+		{Key: models.SectionMyPRs}, {Key: models.SectionNeedsMyAttention},
+		{Key: "security", PRs: []*models.PR{custom}},
+		{Key: models.SectionTeamPRs}, {Key: models.SectionOtherPRs},
+	}
+
+	items := Next(result, testConfig(), now, 0)
+
+	var got []int
+	for _, item := range items {
+		got = append(got, item.PR.Number)
+	}
+	want := []int{5, 1, 6, 2, 4}
+	if len(got) != len(want) {
+		t.Fatalf("Next() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Next() = %v, want %v", got, want)
+		}
+	}
+
+	if limited := Next(result, testConfig(), now, 2); len(limited) != 2 || limited[0].PR.Number != 5 {
+		t.Errorf("Next() with limit 2 = %d items, want the top 2", len(limited))
+	}
+}
@@ -4,23 +4,76 @@ package scanner
 
 import (
 	"fmt"
+	"net"
+	"net/url"
 	"os/exec"
 	"regexp"
 	"strings"
 
+	"prt/internal/config"
 	"prt/internal/models"
 )
 
-var (
-	// SSH format: git@github.com:owner/repo.git
-	sshRegex = regexp.MustCompile(`^git@github\.com:([^/]+)/([^/]+?)(\.git)?$`)
+// scpRegex matches scp-like SSH remotes: [user@]host:owner/repo[.git]
+var scpRegex = regexp.MustCompile(`^(?:[^@/]+@)?([^:/]+):([^/]+)/([^/]+?)(?:\.git)?/?$`)
 
-	// HTTPS format: https://github.com/owner/repo.git
-	httpsRegex = regexp.MustCompile(`^https?://github\.com/([^/]+)/([^/]+?)(\.git)?$`)
+// remoteSchemes are the URL schemes accepted by ParseRemote.
+var remoteSchemes = map[string]bool{
+	"https":   true,
+	"http":    true,
+	"ssh":     true,
+	"git+ssh": true,
+	"git":     true,
+}
 
-	// SSH URL format: ssh://git@github.com/owner/repo.git
-	sshURLRegex = regexp.MustCompile(`^ssh://git@github\.com/([^/]+)/([^/]+?)(\.git)?$`)
-)
+// UnknownHostError indicates a repository whose remote is on a host that is
+// not in the configured list of GitHub hosts.
+type UnknownHostError struct {
+	Host      string
+	RemoteURL string
+}
+
+func (e *UnknownHostError) Error() string {
+	return fmt.Sprintf("host %s is not in github_hosts", e.Host)
+}
+
+// ParseRemote extracts the host, owner, and repository name from a Git remote
+// URL on any host. The host includes the port when the URL specifies one.
+// Returns empty strings if the URL is not a recognized owner/repo remote.
+//
+// Supported formats:
+//   - SSH: git@github.mycorp.com:owner/repo.git
+//   - HTTPS: https://github.mycorp.com/owner/repo.git
+//   - SSH URL: ssh://git@ghe.io:2222/owner/repo.git
+//
+// The .git suffix is optional in all formats.
+func ParseRemote(remoteURL string) (host, owner, repo string) {
+	remoteURL = strings.TrimSpace(remoteURL)
+
+	// URL formats (https://, ssh://, ...)
+	if strings.Contains(remoteURL, "://") {
+		u, err := url.Parse(remoteURL)
+		if err != nil || !remoteSchemes[u.Scheme] || u.Host == "" {
+			return "", "", ""
+		}
+		parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return "", "", ""
+		}
+		name := strings.TrimSuffix(parts[1], ".git")
+		if name == "" {
+			return "", "", ""
+		}
+		return strings.ToLower(u.Host), parts[0], name
+	}
+
+	// scp-like SSH format
+	if matches := scpRegex.FindStringSubmatch(remoteURL); len(matches) == 4 {
+		return strings.ToLower(matches[1]), matches[2], matches[3]
+	}
+
+	return "", "", ""
+}
 
 // ParseGitHubRemote extracts the owner and repository name from a GitHub remote URL.
 // Returns empty strings if the URL is not a recognized GitHub format.
@@ -30,27 +83,39 @@ var (
 //   - HTTPS: https://github.com/owner/repo.git
 //   - SSH URL: ssh://git@github.com/owner/repo.git
 //
-// The .git suffix is optional in all formats.
+// The .git suffix is optional in all formats. Use ParseRemote and MatchHost
+// for remotes on GitHub Enterprise Server hosts.
 func ParseGitHubRemote(remoteURL string) (owner, repo string) {
-	remoteURL = strings.TrimSpace(remoteURL)
-
-	// Try SSH format
-	if matches := sshRegex.FindStringSubmatch(remoteURL); len(matches) >= 3 {
-		return matches[1], matches[2]
+	host, owner, repo := ParseRemote(remoteURL)
+	if MatchHost(host, []string{config.DefaultGitHubHost}) == "" {
+		return "", ""
 	}
+	return owner, repo
+}
 
-	// Try HTTPS format
-	if matches := httpsRegex.FindStringSubmatch(remoteURL); len(matches) >= 3 {
-		return matches[1], matches[2]
+// MatchHost returns the entry of hosts that remoteHost refers to, or "" if
+// none does. A remote host with a port (e.g. an SSH port like ghe.io:2222)
+// matches a configured entry with the same port or with no port at all.
+func MatchHost(remoteHost string, hosts []string) string {
+	remoteHost = strings.ToLower(remoteHost)
+	if remoteHost == "" {
+		return ""
 	}
-
-	// Try SSH URL format
-	if matches := sshURLRegex.FindStringSubmatch(remoteURL); len(matches) >= 3 {
-		return matches[1], matches[2]
+	hostname := remoteHost
+	if h, _, err := net.SplitHostPort(remoteHost); err == nil {
+		hostname = h
 	}
-
-	// Not a GitHub remote
-	return "", ""
+	for _, h := range hosts {
+		if h == remoteHost {
+			return h
+		}
+	}
+	for _, h := range hosts {
+		if h == hostname {
+			return h
+		}
+	}
+	return ""
 }
 
 // GetRemoteURL returns the URL of the "origin" remote for a Git repository.
@@ -68,23 +133,50 @@ func GetRemoteURL(repoPath string) (string, error) {
 }
 
 // InspectRepo examines a directory and returns Repository information if it's
-// a Git repository with a GitHub remote. Returns an error if the directory
+// a Git repository with a github.com remote. Returns an error if the directory
 // is not a Git repo or doesn't have a GitHub remote.
 func InspectRepo(path string) (*models.Repository, error) {
+	repo, err := InspectRepoForHosts(path, []string{config.DefaultGitHubHost})
+	if err != nil {
+		return nil, err
+	}
+	if repo.ScanStatus == models.ScanStatusSkipped {
+		return nil, repo.ScanError
+	}
+	return repo, nil
+}
+
+// InspectRepoForHosts examines a directory and returns Repository information
+// if it's a Git repository with an owner/repo remote. Returns an error if the
+// directory is not a Git repo or its remote can't be parsed.
+//
+// A remote on a host that is not in hosts is not an error: the repository is
+// returned with ScanStatusSkipped and an UnknownHostError, so it can be
+// reported instead of silently dropped.
+func InspectRepoForHosts(path string, hosts []string) (*models.Repository, error) {
 	remoteURL, err := GetRemoteURL(path)
 	if err != nil {
 		return nil, err
 	}
 
-	owner, name := ParseGitHubRemote(remoteURL)
+	remoteHost, owner, name := ParseRemote(remoteURL)
 	if owner == "" || name == "" {
 		return nil, fmt.Errorf("not a GitHub repository: %s", remoteURL)
 	}
 
-	return &models.Repository{
+	repo := &models.Repository{
 		Name:      name,
 		Path:      path,
 		RemoteURL: remoteURL,
 		Owner:     owner,
-	}, nil
+		Host:      MatchHost(remoteHost, hosts),
+	}
+
+	if repo.Host == "" {
+		repo.Host = remoteHost
+		repo.ScanStatus = models.ScanStatusSkipped
+		repo.ScanError = &UnknownHostError{Host: remoteHost, RemoteURL: remoteURL}
+	}
+
+	return repo, nil
 }
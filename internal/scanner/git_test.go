@@ -134,6 +134,63 @@ func TestParseGitHubRemote(t *testing.T) {
 	}
 }
 
+func TestParseRemote(t *testing.T) {
+	tests := []struct {
+		name      string
+		remoteURL string
+		wantHost  string
+		wantOwner string
+		wantRepo  string
+	}{
+		{"GHE SSH", "git@github.mycorp.com:team/service.git", "github.mycorp.com", "team", "service"},
+		{"GHE HTTPS", "https://github.mycorp.com/team/service", "github.mycorp.com", "team", "service"},
+		{"SSH URL with port", "ssh://git@ghe.io:2222/team/service.git", "ghe.io:2222", "team", "service"},
+		{"HTTPS with port and credentials", "https://user@ghe.io:8443/team/service.git", "ghe.io:8443", "team", "service"},
+		{"scp-like without user", "ghe.io:team/service.git", "ghe.io", "team", "service"},
+		{"host is lowercased", "git@GitHub.MyCorp.com:team/service.git", "github.mycorp.com", "team", "service"},
+		{"trailing slash", "https://github.com/owner/repo/", "github.com", "owner", "repo"},
+		{"too many path segments", "https://gitlab.com/group/sub/repo.git", "", "", ""},
+		{"unsupported scheme", "file:///srv/git/owner/repo.git", "", "", ""},
+		{"local path", "/srv/git/repo.git", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host, owner, repo := ParseRemote(tt.remoteURL)
+			if host != tt.wantHost || owner != tt.wantOwner || repo != tt.wantRepo {
+				t.Errorf("ParseRemote(%q) = %q, %q, %q; want %q, %q, %q",
+					tt.remoteURL, host, owner, repo, tt.wantHost, tt.wantOwner, tt.wantRepo)
+			}
+		})
+	}
+}
+
+func TestMatchHost(t *testing.T) {
+	hosts := []string{"github.com", "github.mycorp.com", "ghe.io:8443"}
+
+	tests := []struct {
+		name       string
+		remoteHost string
+		want       string
+	}{
+		{"exact", "github.mycorp.com", "github.mycorp.com"},
+		{"SSH port ignored", "github.mycorp.com:2222", "github.mycorp.com"},
+		{"configured port", "ghe.io:8443", "ghe.io:8443"},
+		{"case-insensitive", "GitHub.com", "github.com"},
+		{"unknown host", "gitlab.com", ""},
+		{"suffix is not a match", "evilgithub.com", ""},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchHost(tt.remoteHost, hosts); got != tt.want {
+				t.Errorf("MatchHost(%q) = %q, want %q", tt.remoteHost, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestGetRemoteURL(t *testing.T) {
 	// Skip if git is not available
 	if _, err := exec.LookPath("git"); err != nil {
@@ -123,12 +123,14 @@ func (s *scanner) Scan(cfg *config.Config) ([]*models.Repository, error) {
 		return nil, nil
 	}
 
-	return s.inspectReposParallel(repoPaths), nil
+	return s.inspectReposParallel(repoPaths, cfg.Hosts()), nil
 }
 
 // inspectReposParallel inspects multiple repositories concurrently.
-// It filters results by the configured patterns and returns valid GitHub repos.
-func (s *scanner) inspectReposParallel(paths []string) []*models.Repository {
+// It filters results by the configured patterns and returns repos with
+// owner/repo remotes. Repos on hosts outside hosts are included with
+// ScanStatusSkipped so callers can report them.
+func (s *scanner) inspectReposParallel(paths []string, hosts []string) []*models.Repository {
 	var (
 		wg    sync.WaitGroup
 		mu    sync.Mutex
@@ -144,9 +146,9 @@ func (s *scanner) inspectReposParallel(paths []string) []*models.Repository {
 			sem <- struct{}{}        // Acquire
 			defer func() { <-sem }() // Release
 
-			repo, err := InspectRepo(p)
+			repo, err := InspectRepoForHosts(p, hosts)
 			if err != nil {
-				// Not a valid GitHub repo - skip silently
+				// No parseable remote - skip silently
 				return
 			}
 
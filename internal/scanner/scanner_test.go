@@ -1,12 +1,14 @@
 package scanner
 
 import (
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"testing"
 
 	"prt/internal/config"
+	"prt/internal/models"
 )
 
 func TestNewScanner(t *testing.T) {
@@ -211,7 +213,7 @@ func TestScanner_Scan(t *testing.T) {
 		}
 	})
 
-	t.Run("skips non-GitHub repos", func(t *testing.T) {
+	t.Run("reports repos on unconfigured hosts as skipped", func(t *testing.T) {
 		tmpDir, err := os.MkdirTemp("", "scanner-nongithub-*")
 		if err != nil {
 			t.Fatalf("Failed to create temp dir: %v", err)
@@ -231,8 +233,41 @@ func TestScanner_Scan(t *testing.T) {
 		s, _ := NewScanner(3, nil)
 		repos, _ := s.Scan(&config.Config{SearchPaths: []string{tmpDir}})
 
-		if len(repos) != 0 {
-			t.Errorf("Scan() found %d repos, want 0 (should skip non-GitHub)", len(repos))
+		if len(repos) != 1 {
+			t.Fatalf("Scan() found %d repos, want 1 (reported as skipped)", len(repos))
+		}
+		if repos[0].ScanStatus != models.ScanStatusSkipped || repos[0].Host != "gitlab.com" {
+			t.Errorf("repo = %s on %q (%s), want skipped on gitlab.com", repos[0].Name, repos[0].Host, repos[0].ScanStatus)
+		}
+		var hostErr *UnknownHostError
+		if !errors.As(repos[0].ScanError, &hostErr) {
+			t.Errorf("ScanError = %v, want UnknownHostError", repos[0].ScanError)
+		}
+	})
+
+	t.Run("scans configured enterprise hosts", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		repoPath := filepath.Join(tmpDir, "svc")
+		os.MkdirAll(repoPath, 0755)
+		cmd := exec.Command("git", "init")
+		cmd.Dir = repoPath
+		cmd.Run()
+		cmd = exec.Command("git", "remote", "add", "origin", "ssh://git@ghe.corp.com:2222/platform/svc.git")
+		cmd.Dir = repoPath
+		cmd.Run()
+
+		s, _ := NewScanner(3, nil)
+		repos, _ := s.Scan(&config.Config{
+			SearchPaths: []string{tmpDir},
+			GitHubHosts: []string{"github.com", "ghe.corp.com"},
+		})
+
+		if len(repos) != 1 {
+			t.Fatalf("Scan() found %d repos, want 1", len(repos))
+		}
+		if repos[0].Host != "ghe.corp.com" || repos[0].FullName() != "platform/svc" || repos[0].ScanError != nil {
+			t.Errorf("repo = %s on %q (err %v), want platform/svc on ghe.corp.com", repos[0].FullName(), repos[0].Host, repos[0].ScanError)
 		}
 	})
 
@@ -0,0 +1,278 @@
+// Package server serves the dashboard over HTTP, for prt serve: an HTML
+// page, the JSON output, and a stream of server-sent events announcing
+// each new scan.
+package server
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"prt/internal/display"
+	"prt/internal/models"
+)
+
+// Server holds the latest scan result and serves it. It is safe for
+// concurrent use: the scan loop calls Update or Fail while requests are
+// served.
+type Server struct {
+	opts display.RenderOptions
+
+	mu         sync.Mutex
+	result     *models.ScanResult
+	highlights map[string]string // PR key -> change label, as in watch mode
+	updated    time.Time
+	refreshErr error
+	// clients receives each event for the open event streams
+	clients map[chan event]struct{}
+	closed  bool
+}
+
+// event is a server-sent event.
+type event struct {
+	name string
+	data string
+}
+
+// New returns a server rendering results with opts. Until the first
+// Update, the page says a scan is in progress.
+func New(opts display.RenderOptions) *Server {
+	return &Server{
+		opts:    opts,
+		clients: make(map[chan event]struct{}),
+	}
+}
+
+// Update replaces the served result with one scanned at the given time,
+// highlighting the PRs in highlights (PR key -> change label), and sends
+// an "update" event, carrying the JSON output, to the open event streams.
+func (s *Server) Update(result *models.ScanResult, highlights map[string]string, at time.Time) error {
+	data, err := display.RenderJSON(result, display.JSONOptions{ShowOtherPRs: s.opts.ShowOtherPRs})
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.result, s.highlights, s.updated, s.refreshErr = result, highlights, at, nil
+	s.broadcast(event{name: "update", data: data})
+	return nil
+}
+
+// Fail records a failed refresh, which the page shows next to the
+// previous result, and sends a "refresh_failed" event to the open event
+// streams.
+func (s *Server) Fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.refreshErr = err
+	s.broadcast(event{name: "refresh_failed", data: err.Error()})
+}
+
+// broadcast sends ev to every open event stream. A client that hasn't
+// taken the previous event yet misses this one; the page refetches the
+// whole dashboard on every event, so only the latest one matters.
+func (s *Server) broadcast(ev event) {
+	for ch := range s.clients {
+		select {
+		case ch <- ev:
+		default:
+		}
+	}
+}
+
+// Close ends the open event streams, so the HTTP server can shut down.
+func (s *Server) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.clients {
+		close(ch)
+		delete(s.clients, ch)
+	}
+	s.closed = true
+}
+
+// Handler returns the server's HTTP handler:
+//
+//	/                  the dashboard page
+//	/api/result.json   the latest result, as prt --json prints it
+//	/api/events        server-sent events: "update" after each scan, with
+//	                   the JSON result, and "refresh_failed" with the error
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.serveDashboard)
+	mux.HandleFunc("/api/result.json", s.serveResult)
+	mux.HandleFunc("/api/events", s.serveEvents)
+	return mux
+}
+
+// serveDashboard serves the dashboard page.
+func (s *Server) serveDashboard(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+
+	s.mu.Lock()
+	result, updated, refreshErr := s.result, s.updated, s.refreshErr
+	opts := s.opts
+	opts.Highlights = s.highlights
+	s.mu.Unlock()
+
+	var body string
+	if result == nil {
+		body = `<p class="empty">Scanning...</p>` + "\n"
+	} else {
+		var err error
+		if body, err = display.RenderHTML(result, opts); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	var status string
+	if !updated.IsZero() {
+		status = "Updated " + updated.Format("15:04:05")
+	}
+	if refreshErr != nil {
+		status += fmt.Sprintf(` · <span class="error">Refresh failed: %s</span>`, html.EscapeString(refreshErr.Error()))
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprintf(w, pageTemplate, status, body)
+}
+
+// serveResult serves the latest result as JSON, or 503 Service
+// Unavailable before the first scan finished.
+func (s *Server) serveResult(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	result := s.result
+	s.mu.Unlock()
+
+	if result == nil {
+		http.Error(w, "no scan has finished yet", http.StatusServiceUnavailable)
+		return
+	}
+	data, err := display.RenderJSON(result, display.JSONOptions{ShowOtherPRs: s.opts.ShowOtherPRs})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	io.WriteString(w, data)
+}
+
+// serveEvents streams events to the client until it disconnects or the
+// server is closed.
+func (s *Server) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	ch := make(chan event, 1)
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		http.Error(w, "the server is shutting down", http.StatusServiceUnavailable)
+		return
+	}
+	s.clients[ch] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		if _, ok := s.clients[ch]; ok {
+			delete(s.clients, ch)
+			close(ch)
+		}
+		s.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case ev, ok := <-ch:
+			if !ok {
+				return
+			}
+			writeEvent(w, ev)
+			flusher.Flush()
+		}
+	}
+}
+
+// writeEvent writes ev in the event stream format, with a data line for
+// each line of its data.
+func writeEvent(w io.Writer, ev event) {
+	fmt.Fprintf(w, "event: %s\n", ev.name)
+	for _, line := range strings.Split(strings.TrimSuffix(ev.data, "\n"), "\n") {
+		fmt.Fprintf(w, "data: %s\n", line)
+	}
+	io.WriteString(w, "\n")
+}
+
+// pageTemplate is the dashboard page, formatted with the status line and
+// the rendered dashboard. Its script refetches the page on every event and
+// swaps in the new dashboard.
+const pageTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>PRT</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2rem auto; max-width: 60rem; padding: 0 1rem; color: #1f2328; background: #fff; }
+header { display: flex; justify-content: space-between; align-items: baseline; border-bottom: 2px solid #5a32a3; margin-bottom: 1rem; }
+header h1 { margin: 0; color: #5a32a3; }
+h2 { background: #5a32a3; color: #fff; display: inline-block; padding: 0.1rem 0.6rem; font-size: 1rem; }
+h2 .count { opacity: 0.7; font-weight: normal; }
+h3.group { color: #0969da; font-size: 0.95rem; margin: 0.8rem 0 0.2rem; }
+ul.prs { list-style: none; margin: 0; padding-left: 1rem; border-left: 1px solid #d0d7de; }
+li.pr { margin: 0.4rem 0; }
+li.pr.blocked > .title { opacity: 0.6; }
+li.pr.highlighted > .title { background: #fff8c5; }
+.title a { color: inherit; text-decoration: none; font-weight: 600; }
+.title a:hover { text-decoration: underline; }
+.label { background: #ddf4ff; color: #0969da; border-radius: 1rem; padding: 0 0.5rem; font-size: 0.8rem; }
+.orphan, .highlight { color: #9a6700; font-size: 0.85rem; }
+.status, .branches, .summary, #status { color: #656d76; font-size: 0.85rem; }
+.approved { color: #0969da; }
+.waiting-review, .ci-passing { color: #1a7f37; }
+.changes-requested { color: #bc4c00; }
+.re-review { color: #bf3989; font-weight: 600; }
+.draft { font-style: italic; }
+.ci-failing, .conflicts, .error { color: #cf222e; }
+.ci-pending { color: #9a6700; }
+.size { font-weight: 600; }
+.empty { color: #8c959f; }
+footer.summary { border-top: 2px solid #5a32a3; margin-top: 1rem; padding-top: 0.5rem; }
+</style>
+</head>
+<body>
+<div id="dashboard">
+<header><h1>PRT</h1><span id="status">%s</span></header>
+%s</div>
+<script>
+const events = new EventSource("/api/events");
+async function refresh() {
+  const res = await fetch("/");
+  const page = new DOMParser().parseFromString(await res.text(), "text/html");
+  document.getElementById("dashboard").replaceWith(page.getElementById("dashboard"));
+}
+events.addEventListener("update", refresh);
+events.addEventListener("refresh_failed", refresh);
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,142 @@
+package server
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"prt/internal/display"
+	"prt/internal/models"
+)
+
+func testResult() *models.ScanResult {
+	result := models.NewScanResult()
+	result.Username = "me"
+	result.MyPRs = []*models.PR{
+		{Number: 1, Title: "Add login", URL: "https://github.com/org/api/pull/1", Author: "me", RepoOwner: "org", RepoName: "api"},
+	}
+	return result
+}
+
+// get requests path from h, returning the status code and body.
+func get(t *testing.T, h http.Handler, path string) (int, string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec.Code, rec.Body.String()
+}
+
+func TestServer_Dashboard(t *testing.T) {
+	s := New(display.RenderOptions{})
+	h := s.Handler()
+
+	code, body := get(t, h, "/")
+	if code != http.StatusOK || !strings.Contains(body, "Scanning...") {
+		t.Errorf("before the first scan, / = %d %q, want the scanning page", code, body)
+	}
+	if code, _ := get(t, h, "/missing"); code != http.StatusNotFound {
+		t.Errorf("/missing = %d, want 404", code)
+	}
+
+	at := time.Date(2025, 3, 1, 9, 30, 0, 0, time.Local)
+	if err := s.Update(testResult(), map[string]string{"org/api#1": "new"}, at); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	_, body = get(t, h, "/")
+	for _, want := range []string{
+		`<span id="status">Updated 09:30:00</span>`,
+		`<a href="https://github.com/org/api/pull/1">#1 Add login</a> <span class="highlight">● new</span>`,
+		`new EventSource("/api/events")`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("/ should contain %q, got:\n%s", want, body)
+		}
+	}
+
+	s.Fail(errors.New("rate limit <exceeded>"))
+	_, body = get(t, h, "/")
+	if !strings.Contains(body, `Refresh failed: rate limit &lt;exceeded&gt;`) || !strings.Contains(body, "#1 Add login") {
+		t.Errorf("after a failed refresh, / should show the error and the previous result, got:\n%s", body)
+	}
+}
+
+func TestServer_Result(t *testing.T) {
+	s := New(display.RenderOptions{})
+	h := s.Handler()
+
+	if code, _ := get(t, h, "/api/result.json"); code != http.StatusServiceUnavailable {
+		t.Errorf("before the first scan, /api/result.json = %d, want 503", code)
+	}
+
+	result := testResult()
+	if err := s.Update(result, nil, time.Now()); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	want, err := display.RenderJSON(result, display.JSONOptions{})
+	if err != nil {
+		t.Fatalf("RenderJSON() error = %v", err)
+	}
+	code, body := get(t, h, "/api/result.json")
+	if code != http.StatusOK || body != want {
+		t.Errorf("/api/result.json = %d %q, want %q", code, body, want)
+	}
+}
+
+func TestServer_Events(t *testing.T) {
+	s := New(display.RenderOptions{})
+	ts := httptest.NewServer(s.Handler())
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/api/events")
+	if err != nil {
+		t.Fatalf("GET /api/events error = %v", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q, want text/event-stream", ct)
+	}
+	reader := bufio.NewReader(resp.Body)
+
+	// readEvent reads the next event's lines, up to the blank line ending it
+	readEvent := func() []string {
+		t.Helper()
+		var lines []string
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				t.Fatalf("reading event: %v (got %q)", err, lines)
+			}
+			if line == "\n" {
+				return lines
+			}
+			lines = append(lines, strings.TrimSuffix(line, "\n"))
+		}
+	}
+
+	// The stream is registered once the response headers were sent
+	if err := s.Update(testResult(), nil, time.Now()); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	lines := readEvent()
+	if lines[0] != "event: update" || lines[1] != "data: {" || !strings.Contains(strings.Join(lines, "\n"), `data:   "total_prs": 1,`) {
+		t.Errorf("update event = %q", lines)
+	}
+
+	s.Fail(errors.New("network down"))
+	if lines := readEvent(); strings.Join(lines, "\n") != "event: refresh_failed\ndata: network down" {
+		t.Errorf("refresh_failed event = %q", lines)
+	}
+
+	s.Close()
+	if rest, err := io.ReadAll(reader); err != nil || len(rest) != 0 {
+		t.Errorf("after Close, the stream should end, got %q, %v", rest, err)
+	}
+	if len(s.clients) != 0 {
+		t.Errorf("clients = %d after Close, want 0", len(s.clients))
+	}
+}
@@ -0,0 +1,58 @@
+package stacks
+
+import (
+	"sort"
+	"strings"
+
+	"prt/internal/models"
+)
+
+// LinkDependencies links the nodes of stacks, which are keyed by repository,
+// through the Depends-on trailers of their PRs: each PR's node gets the
+// nodes of the PRs it depends on in DependsOn, and they get it in
+// Dependents. Unlike parents, dependencies can be in other repositories.
+// Dependencies on PRs that aren't in stacks, and on the PR itself, are
+// skipped; links set by an earlier call are replaced.
+func LinkDependencies(stacks map[string]*models.Stack) {
+	byKey := make(map[string]*models.StackNode)
+	for _, stack := range stacks {
+		if stack == nil {
+			continue
+		}
+		for _, node := range stack.AllNodes {
+			if node.PR != nil {
+				node.DependsOn, node.Dependents = nil, nil
+				byKey[strings.ToLower(node.PR.Key())] = node
+			}
+		}
+	}
+
+	// Walk nodes in a fixed order so that Dependents is deterministic
+	keys := make([]string, 0, len(byKey))
+	for key := range byKey {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		node := byKey[key]
+		for _, depKey := range node.PR.DependsOnKeys() {
+			dep, ok := byKey[strings.ToLower(depKey)]
+			if !ok || dep == node || containsNode(node.DependsOn, dep) {
+				continue
+			}
+			node.DependsOn = append(node.DependsOn, dep)
+			dep.Dependents = append(dep.Dependents, node)
+		}
+	}
+}
+
+// containsNode reports whether nodes includes node.
+func containsNode(nodes []*models.StackNode, node *models.StackNode) bool {
+	for _, n := range nodes {
+		if n == node {
+			return true
+		}
+	}
+	return false
+}
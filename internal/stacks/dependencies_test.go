@@ -0,0 +1,80 @@
+package stacks
+
+import (
+	"testing"
+
+	"prt/internal/models"
+)
+
+func TestLinkDependencies(t *testing.T) {
+	newPR := func(repo string, number int, dependsOn ...string) *models.PR {
+		pr := testPR(number, repo+"-branch", "main")
+		pr.RepoOwner, pr.RepoName = "org", repo
+		pr.DependsOn = dependsOn
+		return pr
+	}
+	lib := newPR("lib", 1)
+	api := newPR("api", 2, "lib#1", "org/missing#3")
+	web := newPR("web", 4, "org/API#2", "org/lib#1", "#4")
+	webDup := newPR("web", 5, "org/lib#1", "https://github.com/org/lib/pull/1")
+
+	stacks := map[string]*models.Stack{
+		"org/lib": DetectStacks([]*models.PR{lib}),
+		"org/api": DetectStacks([]*models.PR{api}),
+		"org/web": DetectStacks([]*models.PR{web, webDup}),
+		"org/nil": nil,
+	}
+	LinkDependencies(stacks)
+	// Linking again replaces the links rather than adding to them
+	LinkDependencies(stacks)
+
+	node := func(repo string, number int) *models.StackNode {
+		return GetStackForPR(stacks[repo], number)
+	}
+	numbers := func(nodes []*models.StackNode) []int {
+		var n []int
+		for _, node := range nodes {
+			n = append(n, node.PR.Number)
+		}
+		return n
+	}
+	equal := func(a, b []int) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if a[i] != b[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	tests := []struct {
+		repo           string
+		number         int
+		wantDependsOn  []int
+		wantDependents []int
+	}{
+		{"org/lib", 1, nil, []int{2, 4, 5}},
+		{"org/api", 2, []int{1}, []int{4}},
+		{"org/web", 4, []int{2, 1}, nil},
+		{"org/web", 5, []int{1}, nil},
+	}
+	for _, tt := range tests {
+		n := node(tt.repo, tt.number)
+		if got := numbers(n.DependsOn); !equal(got, tt.wantDependsOn) {
+			t.Errorf("%s#%d DependsOn = %v, want %v", tt.repo, tt.number, got, tt.wantDependsOn)
+		}
+		if got := numbers(n.Dependents); !equal(got, tt.wantDependents) {
+			t.Errorf("%s#%d Dependents = %v, want %v", tt.repo, tt.number, got, tt.wantDependents)
+		}
+	}
+
+	if !node("org/web", 4).IsBlocked() {
+		t.Error("org/web#4 should be blocked by its open dependencies")
+	}
+	if node("org/lib", 1).IsBlocked() {
+		t.Error("org/lib#1 has no dependencies and should not be blocked")
+	}
+}
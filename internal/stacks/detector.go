@@ -5,13 +5,16 @@ package stacks
 
 import (
 	"sort"
+	"strings"
 
 	"prt/internal/models"
 )
 
 // DetectStacks analyzes a set of PRs and builds a Stack representing their
 // parent-child relationships. A PR is considered a "child" of another PR if
-// its base branch matches the parent's head branch.
+// its base branch matches the parent's head branch. Head branches in forks
+// are told apart by their owner, so PRs from different forks with the same
+// branch name don't form false stacks.
 //
 // Example:
 //
@@ -29,10 +32,12 @@ func DetectStacks(prs []*models.PR) *models.Stack {
 		return stack
 	}
 
-	// Map: headBranch -> PR (for finding parents)
+	// Map: head branch (qualified by owner) -> PR (for finding parents)
 	headBranchToPR := make(map[string]*models.PR)
 	for _, pr := range prs {
-		headBranchToPR[pr.HeadBranch] = pr
+		if key, ok := headKey(pr); ok {
+			headBranchToPR[key] = pr
+		}
 	}
 
 	// Create nodes for all PRs
@@ -47,10 +52,15 @@ func DetectStacks(prs []*models.PR) *models.Stack {
 	// Build parent-child relationships
 	for _, pr := range prs {
 		// Is there a PR whose head branch is our base branch?
-		if parentPR, ok := headBranchToPR[pr.BaseBranch]; ok {
+		if parentPR, ok := headBranchToPR[baseKey(pr)]; ok {
 			parentNode := nodes[parentPR.Number]
 			childNode := nodes[pr.Number]
 
+			// Branches that point at each other would form a cycle
+			if isAncestorOrSelf(childNode, parentNode) {
+				continue
+			}
+
 			childNode.Parent = parentNode
 			parentNode.Children = append(parentNode.Children, childNode)
 		}
@@ -85,6 +95,37 @@ func DetectStacks(prs []*models.PR) *models.Stack {
 	return stack
 }
 
+// headKey identifies the branch a PR merges from as "owner:branch", where
+// owner is the owner of the repository the branch is in. It reports false
+// for a fork whose owner is unknown (e.g. a deleted fork), which can't be
+// matched with certainty.
+func headKey(pr *models.PR) (string, bool) {
+	owner := pr.HeadOwner
+	if owner == "" {
+		if pr.IsCrossRepository {
+			return "", false
+		}
+		owner = pr.RepoOwner
+	}
+	return strings.ToLower(owner) + ":" + pr.HeadBranch, true
+}
+
+// baseKey identifies the branch a PR merges into in the same form as
+// headKey; base branches are always in the PR's own repository.
+func baseKey(pr *models.PR) string {
+	return strings.ToLower(pr.RepoOwner) + ":" + pr.BaseBranch
+}
+
+// isAncestorOrSelf returns true if node is other or one of its ancestors.
+func isAncestorOrSelf(node, other *models.StackNode) bool {
+	for n := other; n != nil; n = n.Parent {
+		if n == node {
+			return true
+		}
+	}
+	return false
+}
+
 // setDepths recursively sets the depth of each node in the tree.
 func setDepths(node *models.StackNode, depth int) {
 	node.Depth = depth
@@ -118,8 +159,11 @@ func FindStackedPRs(stack *models.Stack) []*models.StackNode {
 }
 
 // GetStackForPR returns the root of the stack containing the given PR.
-// Returns nil if the PR is not found in the stack.
+// Returns nil if the PR is not found in the stack, or the stack is nil.
 func GetStackForPR(stack *models.Stack, prNumber int) *models.StackNode {
+	if stack == nil {
+		return nil
+	}
 	for _, node := range stack.AllNodes {
 		if node.PR.Number == prNumber {
 			return node.GetRoot()
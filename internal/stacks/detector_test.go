@@ -63,6 +63,72 @@ func TestDetectStacks_NoPRsStacked(t *testing.T) {
 	}
 }
 
+func TestDetectStacks_Cycles(t *testing.T) {
+	// A PR whose base is its own head, and two PRs based on each other's
+	// heads, must not form cycles
+	prs := []*models.PR{
+		testPR(1, "loop", "loop"),
+		testPR(2, "feature-a", "feature-b"),
+		testPR(3, "feature-b", "feature-a"),
+	}
+
+	stack := DetectStacks(prs)
+
+	if len(stack.Roots) != 2 {
+		t.Fatalf("expected 2 roots, got %d", len(stack.Roots))
+	}
+	for _, node := range stack.AllNodes {
+		if node.GetRoot() == nil {
+			t.Errorf("PR #%d has no root", node.PR.Number)
+		}
+		if node.Parent != nil && node.Parent.Parent == node {
+			t.Errorf("PR #%d is its own grandparent", node.PR.Number)
+		}
+	}
+	if stack.Roots[0].PR.Number != 1 || len(stack.Roots[0].Children) != 0 {
+		t.Errorf("PR #1 should be a root without children")
+	}
+}
+
+func TestDetectStacks_Forks(t *testing.T) {
+	forkPR := func(number int, headOwner, head, base string) *models.PR {
+		pr := testPR(number, head, base)
+		pr.RepoOwner = "org"
+		pr.HeadOwner = headOwner
+		pr.IsCrossRepository = headOwner != "org"
+		return pr
+	}
+	prs := []*models.PR{
+		forkPR(1, "org", "feature", "main"),
+		// Same branch name in two forks: neither is a parent of #4
+		forkPR(2, "alice", "fix", "main"),
+		forkPR(3, "bob", "fix", "main"),
+		forkPR(4, "carol", "fix-tests", "fix"),
+		// A fork PR can be stacked on a PR whose branch is in the repo
+		forkPR(5, "alice", "feature-docs", "feature"),
+		// Nor is a fork PR with the base repo's branch name a parent
+		forkPR(6, "dave", "release", "main"),
+		forkPR(7, "org", "hotfix", "release"),
+		// A deleted fork's branch can't be matched
+		forkPR(8, "", "cleanup", "main"),
+		forkPR(9, "org", "cleanup-2", "cleanup"),
+	}
+	prs[7].IsCrossRepository = true
+
+	stack := DetectStacks(prs)
+
+	wantParents := map[int]int{5: 1}
+	for _, node := range stack.AllNodes {
+		parent := 0
+		if node.Parent != nil {
+			parent = node.Parent.PR.Number
+		}
+		if parent != wantParents[node.PR.Number] {
+			t.Errorf("PR #%d parent = #%d, want #%d", node.PR.Number, parent, wantParents[node.PR.Number])
+		}
+	}
+}
+
 func TestDetectStacks_SimpleStack(t *testing.T) {
 	// PR 2 is stacked on PR 1
 	prs := []*models.PR{
@@ -313,6 +379,9 @@ func TestGetStackForPR(t *testing.T) {
 	if root != nil {
 		t.Error("non-existent PR should return nil")
 	}
+	if GetStackForPR(nil, 1) != nil {
+		t.Error("a nil stack should return nil")
+	}
 }
 
 func TestCountBlockedPRs(t *testing.T) {
@@ -0,0 +1,26 @@
+package stacks
+
+import "prt/internal/models"
+
+// MarkOrphans flags the roots of stack that are orphaned: PRs whose base
+// branch is the head branch of one of closed, the repository's recently
+// merged or closed PRs (see github.BatchResult.Closed). Such a PR still
+// targets its parent's branch after the parent is gone, so it needs to be
+// retargeted or rebased. Only roots can be orphaned, as a PR with an open
+// parent targets a live branch.
+func MarkOrphans(stack *models.Stack, closed []*models.PR) {
+	if stack == nil {
+		return
+	}
+
+	closedHeads := make(map[string]bool, len(closed))
+	for _, pr := range closed {
+		if key, ok := headKey(pr); ok {
+			closedHeads[key] = true
+		}
+	}
+
+	for _, node := range stack.AllNodes {
+		node.IsOrphan = node.Parent == nil && node.PR != nil && closedHeads[baseKey(node.PR)]
+	}
+}
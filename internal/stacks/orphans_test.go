@@ -0,0 +1,55 @@
+package stacks
+
+import (
+	"testing"
+
+	"prt/internal/models"
+)
+
+func TestMarkOrphans(t *testing.T) {
+	newPR := func(number int, head, base string) *models.PR {
+		pr := testPR(number, head, base)
+		pr.RepoOwner = "org"
+		return pr
+	}
+	merged := func(number int, head, base string) *models.PR {
+		pr := newPR(number, head, base)
+		pr.State = models.PRStateMerged
+		return pr
+	}
+
+	// feature (#1, merged) <- feature-api (#2) <- feature-ui (#3)
+	orphan := newPR(2, "feature-api", "feature")
+	child := newPR(3, "feature-ui", "feature-api")
+	// A fork's branch of the same name doesn't orphan PRs in this repository
+	forkTarget := newPR(4, "docs", "cleanup")
+	fork := merged(10, "cleanup", "main")
+	fork.HeadOwner, fork.IsCrossRepository = "someone", true
+	// The open PR's parent is open, even though an older PR from the same
+	// branch was closed
+	reopened := newPR(5, "retry", "main")
+	retried := newPR(6, "retry-tests", "retry")
+	closed := newPR(11, "retry", "main")
+	closed.State = models.PRStateClosed
+	onMain := newPR(7, "fix", "main")
+
+	stack := DetectStacks([]*models.PR{orphan, child, forkTarget, reopened, retried, onMain})
+	MarkOrphans(stack, []*models.PR{merged(1, "feature", "main"), fork, closed})
+
+	want := map[int]bool{2: true}
+	for _, node := range stack.AllNodes {
+		if node.IsOrphan != want[node.PR.Number] {
+			t.Errorf("#%d IsOrphan = %v, want %v", node.PR.Number, node.IsOrphan, want[node.PR.Number])
+		}
+	}
+
+	// Marking again without closed PRs clears the flags
+	MarkOrphans(stack, nil)
+	for _, node := range stack.AllNodes {
+		if node.IsOrphan {
+			t.Errorf("#%d IsOrphan = true after marking with no closed PRs", node.PR.Number)
+		}
+	}
+
+	MarkOrphans(nil, nil) // Must not panic
+}
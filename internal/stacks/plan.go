@@ -0,0 +1,168 @@
+package stacks
+
+import "prt/internal/models"
+
+// Readiness is whether a PR of a stack can be merged, check by check.
+type Readiness struct {
+	// Approved is set when the PR has the approvals its repository
+	// requires: GitHub's review decision is APPROVED, or no review is
+	// required and nobody requested changes.
+	Approved bool `json:"approved"`
+	// CIPassing is set when CI passed or the PR has no checks.
+	CIPassing bool `json:"ci_passing"`
+	// Mergeable is set when the PR is not a draft and GitHub computed that
+	// it merges cleanly.
+	Mergeable bool `json:"mergeable"`
+	// Blocked is set when the PR waits on an unmerged parent or dependency
+	// (see models.StackNode.IsBlocked).
+	Blocked bool `json:"blocked"`
+	// Orphan is set when the PR's parent was merged or closed, so it must
+	// be retargeted or rebased first.
+	Orphan bool `json:"orphan"`
+	// Ready is set when the PR can be merged now.
+	Ready bool `json:"ready"`
+	// FollowUp is set when the PR is ready but for the PRs it waits on,
+	// which are ready or follow-ups themselves: it can be merged once they
+	// are and it was retargeted (see MergePlan.FollowUps).
+	FollowUp bool `json:"follow_up"`
+	// Reasons says why the PR isn't ready, e.g. "CI failing".
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// PlanNode is a PR of a stack with its readiness.
+type PlanNode struct {
+	PR        *models.PR `json:"pr"`
+	Depth     int        `json:"depth"` // 0 = root of the stack
+	Parent    int        `json:"parent,omitempty"`
+	Readiness Readiness  `json:"readiness"`
+}
+
+// MergePlan is a stack's PRs with their readiness, the PRs that can be
+// merged right now, and the ones that can follow them.
+type MergePlan struct {
+	// Nodes lists the stack's PRs depth-first from the root, parents
+	// before their children.
+	Nodes []PlanNode `json:"nodes"`
+	// MergeOrder lists the PRs that can be merged now: they wait on no
+	// unmerged PR.
+	MergeOrder []*models.PR `json:"merge_order"`
+	// FollowUps lists the PRs that are ready but for the PRs they wait on,
+	// parents first. Once those are merged, a PR still targets its parent's
+	// branch until it is retargeted, which GitHub only does by itself when
+	// the parent's branch is deleted on merge; a rescan shows which PRs are
+	// ready then.
+	FollowUps []*models.PR `json:"follow_ups"`
+}
+
+// PlanMerge works out the readiness of each PR in the stack rooted at root,
+// the PRs that can be merged now, and the follow-ups stacked on them. PRs
+// stacked on a PR that isn't ready, or that depend on PRs outside the plan,
+// have to wait.
+func PlanMerge(root *models.StackNode) *MergePlan {
+	plan := &MergePlan{Nodes: []PlanNode{}, MergeOrder: []*models.PR{}, FollowUps: []*models.PR{}}
+	if root == nil || root.PR == nil {
+		return plan
+	}
+
+	// planned holds the PRs in the merge order or the follow-ups
+	planned := make(map[*models.StackNode]bool)
+	var walk func(node *models.StackNode, depth int)
+	walk = func(node *models.StackNode, depth int) {
+		r := readiness(node)
+		waitsOn := waitingOn(node)
+		switch {
+		case len(r.Reasons) > 0:
+		case len(waitsOn) == 0:
+			r.Ready = true
+			planned[node] = true
+			plan.MergeOrder = append(plan.MergeOrder, node.PR)
+		case allPlanned(waitsOn, planned):
+			r.FollowUp = true
+			planned[node] = true
+			plan.FollowUps = append(plan.FollowUps, node.PR)
+		}
+		for _, n := range waitsOn {
+			r.Reasons = append(r.Reasons, "Waits on "+n.PR.Key())
+		}
+
+		pn := PlanNode{PR: node.PR, Depth: depth, Readiness: r}
+		if node.Parent != nil && node.Parent.PR != nil {
+			pn.Parent = node.Parent.PR.Number
+		}
+		plan.Nodes = append(plan.Nodes, pn)
+
+		for _, child := range node.Children {
+			walk(child, depth+1)
+		}
+	}
+	walk(root, 0)
+
+	return plan
+}
+
+// readiness checks node's PR on its own, leaving out what it waits on.
+func readiness(node *models.StackNode) Readiness {
+	pr := node.PR
+	r := Readiness{
+		Blocked: node.IsBlocked(),
+		Orphan:  node.IsOrphan,
+	}
+
+	switch {
+	case pr.ReviewDecision == models.ReviewDecisionChangesRequested,
+		pr.ReviewDecision == "" && pr.OverallReview() == models.ReviewStateChangesRequested:
+		r.Reasons = append(r.Reasons, "Changes requested")
+	case pr.ReviewDecision == models.ReviewDecisionApproved, pr.ReviewDecision == "":
+		r.Approved = true
+	default:
+		r.Reasons = append(r.Reasons, "Not approved")
+	}
+
+	switch pr.CIStatus {
+	case models.CIStatusFailing:
+		r.Reasons = append(r.Reasons, "CI failing")
+	case models.CIStatusPending:
+		r.Reasons = append(r.Reasons, "CI pending")
+	default:
+		r.CIPassing = true
+	}
+
+	switch {
+	case pr.IsDraft:
+		r.Reasons = append(r.Reasons, "Draft")
+	case pr.HasConflicts():
+		r.Reasons = append(r.Reasons, "Conflicts")
+	case pr.Mergeable != models.MergeableMergeable:
+		r.Reasons = append(r.Reasons, "Mergeability not yet known")
+	default:
+		r.Mergeable = true
+	}
+
+	if r.Orphan {
+		r.Reasons = append(r.Reasons, "Parent was merged or closed: retarget or rebase")
+	}
+	return r
+}
+
+// waitingOn returns the nodes of the unmerged PRs node waits on: its
+// parent and its dependencies.
+func waitingOn(node *models.StackNode) []*models.StackNode {
+	var nodes []*models.StackNode
+	for _, n := range append([]*models.StackNode{node.Parent}, node.DependsOn...) {
+		if n == nil || n.PR == nil || n.PR.State == models.PRStateMerged {
+			continue
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
+
+// allPlanned reports whether every node in nodes is in planned.
+func allPlanned(nodes []*models.StackNode, planned map[*models.StackNode]bool) bool {
+	for _, n := range nodes {
+		if !planned[n] {
+			return false
+		}
+	}
+	return true
+}
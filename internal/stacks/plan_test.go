@@ -0,0 +1,125 @@
+package stacks
+
+import (
+	"reflect"
+	"testing"
+
+	"prt/internal/models"
+)
+
+func TestPlanMerge(t *testing.T) {
+	ready := func(number int, head, base string) *models.PR {
+		pr := testPR(number, head, base)
+		pr.RepoOwner, pr.RepoName = "org", "api"
+		pr.ReviewDecision = models.ReviewDecisionApproved
+		pr.CIStatus = models.CIStatusPassing
+		pr.Mergeable = models.MergeableMergeable
+		return pr
+	}
+
+	// main <- #1 <- #2 <- #3
+	//            <- #4 (CI failing) <- #5
+	root := ready(1, "auth", "main")
+	child := ready(2, "auth-api", "auth")
+	grandchild := ready(3, "auth-ui", "auth-api")
+	failing := ready(4, "auth-docs", "auth")
+	failing.CIStatus = models.CIStatusFailing
+	onFailing := ready(5, "auth-docs-2", "auth-docs")
+	stack := DetectStacks([]*models.PR{root, child, grandchild, failing, onFailing})
+
+	plan := PlanMerge(GetStackForPR(stack, 3))
+
+	numbers := func(prs []*models.PR) []int {
+		var n []int
+		for _, pr := range prs {
+			n = append(n, pr.Number)
+		}
+		return n
+	}
+	// Only the root can be merged now; #2 and #3 follow once their parents
+	// are merged and they are retargeted
+	if got, want := numbers(plan.MergeOrder), []int{1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeOrder = %v, want %v", got, want)
+	}
+	if got, want := numbers(plan.FollowUps), []int{2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FollowUps = %v, want %v", got, want)
+	}
+
+	var nodes []int
+	byNumber := make(map[int]PlanNode)
+	for _, n := range plan.Nodes {
+		nodes = append(nodes, n.PR.Number)
+		byNumber[n.PR.Number] = n
+	}
+	if want := []int{1, 2, 3, 4, 5}; !reflect.DeepEqual(nodes, want) {
+		t.Errorf("Nodes = %v, want depth-first %v", nodes, want)
+	}
+
+	if n := byNumber[1]; !n.Readiness.Ready || n.Readiness.FollowUp {
+		t.Errorf("#1 = %+v, want ready now", n)
+	}
+	n := byNumber[2]
+	if n.Depth != 1 || n.Parent != 1 || n.Readiness.Ready || !n.Readiness.FollowUp || !n.Readiness.Blocked ||
+		!reflect.DeepEqual(n.Readiness.Reasons, []string{"Waits on org/api#1"}) {
+		t.Errorf("#2 = %+v, want a follow-up blocked by #1", n)
+	}
+	r := byNumber[4].Readiness
+	if r.Ready || r.CIPassing || !r.Approved || !r.Mergeable || !reflect.DeepEqual(r.Reasons, []string{"CI failing", "Waits on org/api#1"}) {
+		t.Errorf("#4 readiness = %+v, want not ready because of CI", r)
+	}
+	r = byNumber[5].Readiness
+	if r.Ready || r.FollowUp || !reflect.DeepEqual(r.Reasons, []string{"Waits on org/api#4"}) {
+		t.Errorf("#5 readiness = %+v, want waiting on #4", r)
+	}
+}
+
+func TestPlanMerge_Readiness(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(pr *models.PR, node *models.StackNode)
+		want   []string
+	}{
+		{"ready", func(pr *models.PR, _ *models.StackNode) {}, nil},
+		{"no review required", func(pr *models.PR, _ *models.StackNode) { pr.ReviewDecision = "" }, nil},
+		{"review required", func(pr *models.PR, _ *models.StackNode) { pr.ReviewDecision = models.ReviewDecisionReviewRequired }, []string{"Not approved"}},
+		{"changes requested", func(pr *models.PR, _ *models.StackNode) {
+			pr.ReviewDecision = ""
+			pr.Reviews = []models.Review{{Author: "bob", State: models.ReviewStateChangesRequested}}
+		}, []string{"Changes requested"}},
+		{"no checks", func(pr *models.PR, _ *models.StackNode) { pr.CIStatus = models.CIStatusNone }, nil},
+		{"pending", func(pr *models.PR, _ *models.StackNode) { pr.CIStatus = models.CIStatusPending }, []string{"CI pending"}},
+		{"draft", func(pr *models.PR, _ *models.StackNode) { pr.IsDraft = true }, []string{"Draft"}},
+		{"conflicts", func(pr *models.PR, _ *models.StackNode) { pr.Mergeable = models.MergeableConflicting }, []string{"Conflicts"}},
+		{"unknown", func(pr *models.PR, _ *models.StackNode) { pr.Mergeable = models.MergeableUnknown }, []string{"Mergeability not yet known"}},
+		{"orphan", func(_ *models.PR, node *models.StackNode) { node.IsOrphan = true }, []string{"Parent was merged or closed: retarget or rebase"}},
+		{"dependency", func(_ *models.PR, node *models.StackNode) {
+			node.DependsOn = []*models.StackNode{{PR: &models.PR{Number: 9, RepoOwner: "org", RepoName: "lib", State: models.PRStateOpen}}}
+		}, []string{"Waits on org/lib#9"}},
+		{"merged dependency", func(_ *models.PR, node *models.StackNode) {
+			node.DependsOn = []*models.StackNode{{PR: &models.PR{Number: 9, State: models.PRStateMerged}}}
+		}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := testPR(1, "feature", "main")
+			pr.ReviewDecision = models.ReviewDecisionApproved
+			pr.CIStatus = models.CIStatusPassing
+			pr.Mergeable = models.MergeableMergeable
+			node := &models.StackNode{PR: pr}
+			tt.modify(pr, node)
+
+			r := PlanMerge(node).Nodes[0].Readiness
+			if !reflect.DeepEqual(r.Reasons, tt.want) || r.Ready != (tt.want == nil) {
+				t.Errorf("readiness = %+v, want reasons %v", r, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanMerge_Nil(t *testing.T) {
+	plan := PlanMerge(nil)
+	if plan.Nodes == nil || plan.MergeOrder == nil || plan.FollowUps == nil || len(plan.Nodes) != 0 {
+		t.Errorf("PlanMerge(nil) = %+v, want an empty plan", plan)
+	}
+}
@@ -0,0 +1,192 @@
+// Package state keeps the PRs the user snoozed, muted, or pinned on disk,
+// so the categorizer can hide or promote them on every run.
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"prt/internal/config"
+	"prt/internal/models"
+)
+
+// version is bumped whenever the state format changes, so state written by
+// a newer version of PRT is reported instead of misread.
+const version = 1
+
+// Path returns the default state file: ~/.prt/state.json
+func Path() string {
+	return filepath.Join(config.ConfigDir(), "state.json")
+}
+
+// State holds the user's marks on PRs, keyed by PR key ("owner/repo#123").
+type State struct {
+	Version int                `json:"version"`
+	PRs     map[string]*PRMark `json:"prs"`
+}
+
+// PRMark is what the user set on one PR.
+type PRMark struct {
+	Snooze *Snooze `json:"snooze,omitempty"`
+	Muted  bool    `json:"muted,omitempty"`
+	Pinned bool    `json:"pinned,omitempty"`
+}
+
+// IsEmpty returns true if nothing is set.
+func (m *PRMark) IsEmpty() bool {
+	return m.Snooze == nil && !m.Muted && !m.Pinned
+}
+
+// Snooze hides a PR until a deadline passes, until the PR gets new
+// activity, or both (whichever comes first).
+type Snooze struct {
+	At          time.Time  `json:"at"`
+	Until       *time.Time `json:"until,omitempty"` // Deadline; nil = none
+	UntilUpdate bool       `json:"until_update"`    // Wake on new commits or reviews
+	HeadSHA     string     `json:"head_sha,omitempty"`
+}
+
+// New returns an empty state.
+func New() *State {
+	return &State{Version: version, PRs: make(map[string]*PRMark)}
+}
+
+// Mark returns the mark for the PR with key, or nil if there is none.
+func (s *State) Mark(key string) *PRMark {
+	return s.PRs[key]
+}
+
+// Update applies fn to the mark for key, creating it if needed, and drops
+// the mark if fn leaves it empty.
+func (s *State) Update(key string, fn func(m *PRMark)) {
+	m := s.PRs[key]
+	if m == nil {
+		m = &PRMark{}
+	}
+	fn(m)
+	if m.IsEmpty() {
+		delete(s.PRs, key)
+		return
+	}
+	s.PRs[key] = m
+}
+
+// IsSnoozed reports whether pr is snoozed at now. A snooze ends when its
+// deadline passes or, with UntilUpdate, when commits are pushed or someone
+// other than username reviews the PR after it was snoozed.
+func (s *State) IsSnoozed(pr *models.PR, username string, now time.Time) bool {
+	m := s.Mark(pr.Key())
+	if m == nil || m.Snooze == nil {
+		return false
+	}
+	return m.Snooze.isActive(pr, username, now)
+}
+
+// isActive reports whether the snooze still holds for pr at now.
+func (z *Snooze) isActive(pr *models.PR, username string, now time.Time) bool {
+	if z.Until != nil && !now.Before(*z.Until) {
+		return false
+	}
+	if !z.UntilUpdate {
+		return true
+	}
+
+	if z.HeadSHA != "" && pr.HeadSHA != "" && pr.HeadSHA != z.HeadSHA {
+		return false
+	}
+	for _, r := range pr.Reviews {
+		if r.Author != username && r.Submitted.After(z.At) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsMuted reports whether pr is muted.
+func (s *State) IsMuted(pr *models.PR) bool {
+	m := s.Mark(pr.Key())
+	return m != nil && m.Muted
+}
+
+// IsPinned reports whether pr is pinned.
+func (s *State) IsPinned(pr *models.PR) bool {
+	m := s.Mark(pr.Key())
+	return m != nil && m.Pinned
+}
+
+// PruneExpired drops snoozes whose deadline passed before now. Snoozes
+// ended by activity are kept, as activity is only known during a scan.
+func (s *State) PruneExpired(now time.Time) {
+	for key, m := range s.PRs {
+		if m.Snooze != nil && m.Snooze.Until != nil && !now.Before(*m.Snooze.Until) {
+			s.Update(key, func(m *PRMark) { m.Snooze = nil })
+		}
+	}
+}
+
+// Store reads and writes the state file.
+type Store struct {
+	path string
+}
+
+// NewStore creates a store for the state file at path. The file and its
+// directory are created on the first save.
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// Load reads the state. A missing file means nothing is marked yet.
+func (s *Store) Load() (*State, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return New(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	st := New()
+	if err := json.Unmarshal(data, st); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", s.path, err)
+	}
+	if st.Version != version {
+		return nil, fmt.Errorf("%s was written by an incompatible version of prt", s.path)
+	}
+	if st.PRs == nil {
+		st.PRs = make(map[string]*PRMark)
+	}
+	return st, nil
+}
+
+// Save writes the state atomically, so a concurrent run never reads a
+// partial file.
+func (s *Store) Save(st *State) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+
+	st.Version = version
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".state-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
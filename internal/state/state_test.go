@@ -0,0 +1,147 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"prt/internal/models"
+)
+
+func TestState_Update(t *testing.T) {
+	st := New()
+
+	st.Update("org/api#1", func(m *PRMark) { m.Pinned = true })
+	if !st.IsPinned(&models.PR{RepoOwner: "org", RepoName: "api", Number: 1}) {
+		t.Fatal("expected org/api#1 to be pinned")
+	}
+
+	st.Update("org/api#1", func(m *PRMark) { m.Pinned = false })
+	if _, ok := st.PRs["org/api#1"]; ok {
+		t.Error("a mark with nothing set should be dropped")
+	}
+}
+
+func TestState_IsSnoozed(t *testing.T) {
+	at := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	until := at.Add(48 * time.Hour)
+	pr := func(sha string, reviews ...models.Review) *models.PR {
+		return &models.PR{RepoOwner: "org", RepoName: "api", Number: 1, HeadSHA: sha, Reviews: reviews}
+	}
+	review := func(author string, submitted time.Time) models.Review {
+		return models.Review{Author: author, State: models.ReviewStateCommented, Submitted: submitted}
+	}
+
+	tests := []struct {
+		name   string
+		snooze Snooze
+		pr     *models.PR
+		now    time.Time
+		want   bool
+	}{
+		{"before the deadline", Snooze{At: at, Until: &until}, pr("a"), at.Add(time.Hour), true},
+		{"deadline passed", Snooze{At: at, Until: &until}, pr("a"), until, false},
+		{"deadline ignores activity without until-update", Snooze{At: at, Until: &until, HeadSHA: "a"}, pr("b"), at.Add(time.Hour), true},
+		{"no activity", Snooze{At: at, UntilUpdate: true, HeadSHA: "a"}, pr("a", review("bob", at.Add(-time.Hour))), at.Add(1000 * time.Hour), true},
+		{"new commits", Snooze{At: at, UntilUpdate: true, HeadSHA: "a"}, pr("b"), at.Add(time.Hour), false},
+		{"unknown head commit", Snooze{At: at, UntilUpdate: true}, pr("b"), at.Add(time.Hour), true},
+		{"new review", Snooze{At: at, UntilUpdate: true, HeadSHA: "a"}, pr("a", review("bob", at.Add(time.Hour))), at.Add(2 * time.Hour), false},
+		{"my own review", Snooze{At: at, UntilUpdate: true, HeadSHA: "a"}, pr("a", review("me", at.Add(time.Hour))), at.Add(2 * time.Hour), true},
+		{"both, deadline first", Snooze{At: at, Until: &until, UntilUpdate: true, HeadSHA: "a"}, pr("a"), until.Add(time.Hour), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := New()
+			snooze := tt.snooze
+			st.Update("org/api#1", func(m *PRMark) { m.Snooze = &snooze })
+
+			if got := st.IsSnoozed(tt.pr, "me", tt.now); got != tt.want {
+				t.Errorf("IsSnoozed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if New().IsSnoozed(pr("a"), "me", at) {
+		t.Error("a PR without a mark should not be snoozed")
+	}
+}
+
+func TestState_PruneExpired(t *testing.T) {
+	now := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	st := New()
+	st.Update("org/api#1", func(m *PRMark) { m.Snooze = &Snooze{At: past, Until: &past} })
+	st.Update("org/api#2", func(m *PRMark) { m.Snooze = &Snooze{At: past, Until: &past}; m.Pinned = true })
+	st.Update("org/api#3", func(m *PRMark) { m.Snooze = &Snooze{At: past, Until: &future} })
+	st.Update("org/api#4", func(m *PRMark) { m.Snooze = &Snooze{At: past, UntilUpdate: true} })
+
+	st.PruneExpired(now)
+
+	if _, ok := st.PRs["org/api#1"]; ok {
+		t.Error("expired snooze should be dropped")
+	}
+	if m := st.PRs["org/api#2"]; m == nil || m.Snooze != nil || !m.Pinned {
+		t.Errorf("org/api#2 = %+v, want pinned without a snooze", m)
+	}
+	for _, key := range []string{"org/api#3", "org/api#4"} {
+		if m := st.PRs[key]; m == nil || m.Snooze == nil {
+			t.Errorf("%s should still be snoozed", key)
+		}
+	}
+}
+
+func TestStore_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prt", "state.json")
+	store := NewStore(path)
+
+	st, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() of a missing file error = %v", err)
+	}
+	if len(st.PRs) != 0 {
+		t.Errorf("missing file should load as empty, got %+v", st.PRs)
+	}
+
+	until := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	st.Update("org/api#1", func(m *PRMark) { m.Muted = true })
+	st.Update("org/api#2", func(m *PRMark) { m.Snooze = &Snooze{At: until.Add(-time.Hour), Until: &until, HeadSHA: "abc"} })
+	if err := store.Save(st); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !loaded.PRs["org/api#1"].Muted {
+		t.Error("org/api#1 should be muted")
+	}
+	snooze := loaded.PRs["org/api#2"].Snooze
+	if snooze == nil || snooze.Until == nil || !snooze.Until.Equal(until) || snooze.HeadSHA != "abc" {
+		t.Errorf("snooze = %+v, want until %v on abc", snooze, until)
+	}
+
+	files, _ := os.ReadDir(filepath.Dir(path))
+	if len(files) != 1 {
+		t.Errorf("expected only the state file, found %d files", len(files))
+	}
+}
+
+func TestStore_Load_Invalid(t *testing.T) {
+	dir := t.TempDir()
+
+	for name, content := range map[string]string{
+		"garbage":     "not json",
+		"new version": `{"version": 99, "prs": {}}`,
+	} {
+		path := filepath.Join(dir, strings.ReplaceAll(name, " ", "-")+".json")
+		os.WriteFile(path, []byte(content), 0644)
+		if _, err := NewStore(path).Load(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package state
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"prt/internal/history"
+)
+
+// ParseUntil parses a snooze --until value relative to now. It accepts:
+//
+//	12h, 90m, 2d, 1w          a duration from now
+//	2025-01-02, 2025-01-02 09:00, RFC 3339
+//
+// A date without a time means the start of that day.
+func ParseUntil(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+
+	if d, err := history.ParseDuration(s); err == nil {
+		if d <= 0 {
+			return time.Time{}, fmt.Errorf("invalid --until %q: duration must be positive", s)
+		}
+		return now.Add(d), nil
+	}
+
+	if t, err := history.ParseTime(s, now.Location()); err == nil {
+		if !t.After(now) {
+			return time.Time{}, fmt.Errorf("invalid --until %q: must be in the future", s)
+		}
+		return t, nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid --until %q (expected a duration like 12h or 2d, or a date like 2025-01-02)", s)
+}
@@ -0,0 +1,38 @@
+package state
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseUntil(t *testing.T) {
+	now := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{input: "12h", want: now.Add(12 * time.Hour)},
+		{input: "3d", want: now.Add(72 * time.Hour)},
+		{input: "1w", want: now.Add(7 * 24 * time.Hour)},
+		{input: "2025-01-08", want: time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC)},
+		{input: "2025-01-08 14:30", want: time.Date(2025, 1, 8, 14, 30, 0, 0, time.UTC)},
+		{input: "2025-01-05", wantErr: true},
+		{input: "0d", wantErr: true},
+		{input: "next week", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseUntil(tt.input, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseUntil(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("ParseUntil(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,462 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"prt/internal/display"
+	"prt/internal/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TUI-only styles, in the palette of display/styles.go.
+var (
+	// selectedStyle marks the PR under the cursor
+	selectedStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("15")). // White
+			Background(lipgloss.Color("57"))  // Purple
+
+	// helpStyle renders the key bindings in the footer
+	helpStyle = display.MetaStyle
+)
+
+// Model is the Bubble Tea model of the interactive dashboard.
+type Model struct {
+	opts Options
+
+	rows     []row
+	visible  []int // Indexes into rows after filtering
+	cursor   int   // Index into visible of the selected PR row; -1 if none
+	offset   int   // First line shown when the list is taller than the screen
+	expanded map[string]bool
+
+	filter    string
+	filtering bool // Typing into the filter
+
+	width  int
+	height int
+
+	message string // Transient feedback, e.g. "Copied ..."
+}
+
+// New creates the model for result.
+func New(result *models.ScanResult, opts Options) Model {
+	m := Model{
+		opts:     opts.withDefaults(),
+		rows:     buildRows(result, opts.GroupBy, opts.ShowOtherPRs),
+		expanded: make(map[string]bool),
+	}
+	m.applyFilter()
+	return m
+}
+
+// Init implements tea.Model.
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model.
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.scrollToCursor()
+		return m, nil
+
+	case tea.KeyMsg:
+		m.message = ""
+		if m.filtering {
+			return m.updateFilter(msg)
+		}
+		return m.updateNormal(msg)
+	}
+
+	return m, nil
+}
+
+// updateFilter handles keys while typing a filter. The list is filtered
+// live as the filter changes.
+func (m Model) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEsc:
+		m.filtering = false
+		m.filter = ""
+	case tea.KeyEnter:
+		m.filtering = false
+		return m, nil
+	case tea.KeyBackspace:
+		if r := []rune(m.filter); len(r) > 0 {
+			m.filter = string(r[:len(r)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.filter += string(msg.Runes)
+	default:
+		return m, nil
+	}
+
+	m.applyFilter()
+	return m, nil
+}
+
+// updateNormal handles navigation and action keys.
+func (m Model) updateNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	case "j", "down":
+		m.move(1)
+	case "k", "up":
+		m.move(-1)
+	case "g", "home":
+		m.cursor = m.firstPR(0, 1)
+	case "G", "end":
+		m.cursor = m.firstPR(len(m.visible)-1, -1)
+	case "tab":
+		m.jumpSection(1)
+	case "shift+tab":
+		m.jumpSection(-1)
+	case "enter", " ":
+		if pr := m.Selected(); pr != nil {
+			m.expanded[pr.Key()] = !m.expanded[pr.Key()]
+		}
+	case "/":
+		m.filtering = true
+	case "esc":
+		if m.filter != "" {
+			m.filter = ""
+			m.applyFilter()
+		}
+	case "o":
+		if pr := m.Selected(); pr != nil {
+			if err := m.opts.OpenURL(pr.URL); err != nil {
+				m.message = err.Error()
+			} else {
+				m.message = "Opened " + pr.URL
+			}
+		}
+	case "y":
+		if pr := m.Selected(); pr != nil {
+			if err := m.opts.CopyURL(pr.URL); err != nil {
+				m.message = err.Error()
+			} else {
+				m.message = "Copied " + pr.URL
+			}
+		}
+	}
+
+	m.scrollToCursor()
+	return m, nil
+}
+
+// Selected returns the PR under the cursor, or nil if no PR is shown.
+func (m Model) Selected() *models.PR {
+	if m.cursor < 0 || m.cursor >= len(m.visible) {
+		return nil
+	}
+	return m.rows[m.visible[m.cursor]].pr
+}
+
+// applyFilter recomputes the visible rows, keeping the selection on the
+// same PR when it is still shown.
+func (m *Model) applyFilter() {
+	selected := m.Selected()
+	m.visible = filterRows(m.rows, m.filter)
+	m.cursor = m.firstPR(0, 1)
+	m.offset = 0
+
+	if selected != nil {
+		for i, idx := range m.visible {
+			if m.rows[idx].pr == selected {
+				m.cursor = i
+				break
+			}
+		}
+	}
+	m.scrollToCursor()
+}
+
+// firstPR returns the index of the first visible PR row at or after start
+// in direction dir (1 or -1), or -1 if there is none.
+func (m Model) firstPR(start, dir int) int {
+	for i := start; i >= 0 && i < len(m.visible); i += dir {
+		if m.rows[m.visible[i]].kind == rowPR {
+			return i
+		}
+	}
+	return -1
+}
+
+// move moves the cursor by n PR rows, stopping at the ends.
+func (m *Model) move(n int) {
+	if m.cursor < 0 {
+		return
+	}
+	dir := 1
+	if n < 0 {
+		dir, n = -1, -n
+	}
+	for ; n > 0; n-- {
+		next := m.firstPR(m.cursor+dir, dir)
+		if next < 0 {
+			return
+		}
+		m.cursor = next
+	}
+}
+
+// jumpSection moves the cursor to the first PR of the next (dir 1) or
+// previous (dir -1) section that has any, wrapping around.
+func (m *Model) jumpSection(dir int) {
+	if m.cursor < 0 {
+		return
+	}
+	current := m.rows[m.visible[m.cursor]].section
+
+	// Collect the first visible PR of each section, in order
+	var firsts []int
+	seen := make(map[int]bool)
+	for i, idx := range m.visible {
+		r := m.rows[idx]
+		if r.kind == rowPR && !seen[r.section] {
+			seen[r.section] = true
+			firsts = append(firsts, i)
+		}
+	}
+
+	pos := 0
+	for i, first := range firsts {
+		if m.rows[m.visible[first]].section == current {
+			pos = i
+		}
+	}
+	pos = (pos + dir + len(firsts)) % len(firsts)
+	m.cursor = firsts[pos]
+}
+
+// View implements tea.Model.
+func (m Model) View() string {
+	lines, cursorLine := m.listLines()
+
+	// Keep the footer on screen and the cursor within the list area
+	listHeight := len(lines)
+	if m.height > 0 {
+		listHeight = max(m.height-2, 1)
+	}
+	offset := clampOffset(m.offset, cursorLine, listHeight, len(lines))
+	end := min(offset+listHeight, len(lines))
+
+	var b strings.Builder
+	for _, line := range lines[offset:end] {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	b.WriteString(m.footer())
+	return b.String()
+}
+
+// scrollToCursor updates the stored scroll offset so the cursor stays
+// visible; View applies the same clamping for the current size.
+func (m *Model) scrollToCursor() {
+	if m.height <= 0 {
+		return
+	}
+	lines, cursorLine := m.listLines()
+	m.offset = clampOffset(m.offset, cursorLine, max(m.height-2, 1), len(lines))
+}
+
+// clampOffset returns the scroll offset closest to offset that shows the
+// cursor line and doesn't scroll past the end.
+func clampOffset(offset, cursorLine, height, total int) int {
+	if cursorLine >= 0 {
+		if cursorLine < offset {
+			offset = cursorLine
+		}
+		// Show the PR's status line along with its title
+		if cursorLine+1 >= offset+height {
+			offset = cursorLine + 2 - height
+		}
+	}
+	if offset > total-height {
+		offset = total - height
+	}
+	return max(offset, 0)
+}
+
+// listLines renders the visible rows and returns the line index of the
+// selected PR's title (-1 if none).
+func (m Model) listLines() ([]string, int) {
+	lines := []string{display.TitleStyle.Render("PRT") + " " + strings.Repeat("═", 60), ""}
+	cursorLine := -1
+
+	for i, idx := range m.visible {
+		r := m.rows[idx]
+		switch r.kind {
+		case rowSection:
+			if i > 0 {
+				lines = append(lines, "")
+			}
+			lines = append(lines, display.RenderSectionHeader(r.icon, r.text, m.opts.ShowIcons), "")
+		case rowEmpty:
+			lines = append(lines, display.EmptyStyle.Render(r.text))
+		case rowGroup:
+			if i > 0 && m.rows[m.visible[i-1]].kind == rowPR {
+				lines = append(lines, "")
+			}
+			style := display.RepoStyle
+			if strings.HasPrefix(r.text, "[@") {
+				style = display.AuthorStyle
+			}
+			lines = append(lines, style.Render(r.text))
+		case rowPR:
+			if i == m.cursor {
+				cursorLine = len(lines)
+			}
+			lines = append(lines, m.prLines(r, i == m.cursor)...)
+		}
+	}
+
+	return lines, cursorLine
+}
+
+// prLines renders a PR row: its title, its status, and its details when
+// expanded.
+func (m Model) prLines(r row, selected bool) []string {
+	pr := r.pr
+
+	title := fmt.Sprintf("#%d %s", pr.Number, pr.Title)
+	switch {
+	case selected:
+		title = selectedStyle.Render(title)
+	case r.blocked:
+		title = display.BlockedStyle.Render(title)
+	default:
+		title = display.NumberStyle.Render(fmt.Sprintf("#%d", pr.Number)) + " " + pr.Title
+	}
+	if r.orphan {
+		title += " " + display.RenderOrphanIndicator(m.opts.ShowIcons)
+	}
+
+	indent := r.continuation + "    "
+	lines := []string{
+		r.prefix + title,
+		indent + display.StatusLine(pr, m.opts.ShowIcons),
+	}
+
+	if m.expanded[pr.Key()] {
+		for _, detail := range details(pr) {
+			lines = append(lines, indent+detail)
+		}
+	}
+
+	return lines
+}
+
+// details renders the expanded view of a PR: branches, URL, reviewers,
+// reviews, and CI status with each check.
+func details(pr *models.PR) []string {
+	var lines []string
+
+	branches := display.BranchStyle.Render(pr.HeadBranch) + display.MetaStyle.Render(" → ") + display.BranchStyle.Render(pr.BaseBranch)
+	if pr.Author != "" {
+		branches = display.AuthorStyle.Render("@"+pr.Author) + display.MetaStyle.Render(" · ") + branches
+	}
+	lines = append(lines, branches, display.URLStyle.Render(pr.URL))
+
+	if len(pr.Assignees) > 0 {
+		lines = append(lines, display.MetaStyle.Render("Assignees: "+strings.Join(pr.Assignees, ", ")))
+	}
+	if len(pr.ReviewRequests) > 0 {
+		lines = append(lines, display.MetaStyle.Render("Review requested: "+strings.Join(pr.ReviewRequests, ", ")))
+	}
+
+	if len(pr.Reviews) == 0 {
+		lines = append(lines, display.MetaStyle.Render("Reviews: none yet"))
+	} else {
+		lines = append(lines, display.MetaStyle.Render("Reviews:"))
+		for _, review := range pr.Reviews {
+			lines = append(lines, "  "+formatReview(review))
+		}
+	}
+
+	lines = append(lines, display.MetaStyle.Render("Checks: ")+formatChecks(pr.CIStatus))
+	for _, check := range pr.Checks {
+		lines = append(lines, "  "+formatCheck(check))
+	}
+
+	return lines
+}
+
+// formatReview renders one review, colored by its state.
+func formatReview(review models.Review) string {
+	var state string
+	switch review.State {
+	case models.ReviewStateApproved:
+		state = display.ApprovedStyle.Render("approved")
+	case models.ReviewStateChangesRequested:
+		state = display.ChangesRequestedStyle.Render("requested changes")
+	case models.ReviewStateCommented:
+		state = display.MetaStyle.Render("commented")
+	case models.ReviewStateDismissed:
+		state = display.MetaStyle.Render("dismissed")
+	default:
+		state = display.MetaStyle.Render(strings.ToLower(string(review.State)))
+	}
+
+	line := display.AuthorStyle.Render("@"+review.Author) + " " + state
+	if !review.Submitted.IsZero() {
+		line += display.MetaStyle.Render(" · " + review.Submitted.Format("Jan 2 15:04"))
+	}
+	return line
+}
+
+// formatChecks renders the combined CI status.
+func formatChecks(status models.CIStatus) string {
+	switch status {
+	case models.CIStatusPassing:
+		return display.CIPassingStyle.Render("passing")
+	case models.CIStatusFailing:
+		return display.CIFailingStyle.Render("failing")
+	case models.CIStatusPending:
+		return display.CIPendingStyle.Render("pending")
+	default:
+		return display.MetaStyle.Render("none")
+	}
+}
+
+// formatCheck renders one check, colored by its outcome, noting whether
+// it is required and whether it is ignored.
+func formatCheck(check models.Check) string {
+	line := check.Name + " " + formatChecks(check.Status())
+	switch {
+	case check.Required == nil:
+	case *check.Required:
+		line += display.MetaStyle.Render(" · required")
+	default:
+		line += display.MetaStyle.Render(" · optional")
+	}
+	if check.Ignored {
+		line += display.MetaStyle.Render(" · ignored")
+	}
+	return line
+}
+
+// footer renders the filter prompt, feedback, or key help.
+func (m Model) footer() string {
+	switch {
+	case m.filtering:
+		return "/" + m.filter + "█"
+	case m.message != "":
+		return helpStyle.Render(m.message)
+	}
+
+	help := "j/k move · tab section · enter expand · / filter · o open · y copy URL · q quit"
+	if m.filter != "" {
+		help = fmt.Sprintf("filter: %q (esc to clear) · ", m.filter) + help
+	}
+	return helpStyle.Render(help)
+}
@@ -0,0 +1,246 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"prt/internal/config"
+	"prt/internal/display"
+	"prt/internal/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// press sends keys to the model, one message per key. Special keys are
+// given by name ("enter", "esc", "tab", ...); anything else is typed.
+func press(m Model, keys ...string) Model {
+	special := map[string]tea.KeyType{
+		"enter":     tea.KeyEnter,
+		"esc":       tea.KeyEsc,
+		"tab":       tea.KeyTab,
+		"shift+tab": tea.KeyShiftTab,
+		"backspace": tea.KeyBackspace,
+		"down":      tea.KeyDown,
+		"up":        tea.KeyUp,
+	}
+	for _, k := range keys {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		if t, ok := special[k]; ok {
+			msg = tea.KeyMsg{Type: t}
+		}
+		next, _ := m.Update(msg)
+		m = next.(Model)
+	}
+	return m
+}
+
+func selectedKey(m Model) string {
+	if pr := m.Selected(); pr != nil {
+		return pr.Key()
+	}
+	return ""
+}
+
+func newTestModel(opts Options) Model {
+	display.DisableColors()
+	opts.GroupBy = config.GroupByProject
+	return New(testResult(), opts)
+}
+
+func TestModel_Navigation(t *testing.T) {
+	m := newTestModel(Options{})
+
+	if got := selectedKey(m); got != "org/api#1" {
+		t.Fatalf("initial selection = %q, want the first PR", got)
+	}
+
+	tests := []struct {
+		keys []string
+		want string
+	}{
+		{[]string{"j"}, "org/api#2"},
+		{[]string{"j", "j"}, "org/web#5"},
+		{[]string{"down", "down", "down"}, "org/web#9"},
+		{[]string{"j", "j", "j", "j", "j"}, "org/web#9"}, // Stops at the end
+		{[]string{"j", "k"}, "org/api#1"},
+		{[]string{"k"}, "org/api#1"}, // Stops at the start
+		{[]string{"G"}, "org/web#9"},
+		{[]string{"G", "g"}, "org/api#1"},
+		{[]string{"tab"}, "org/web#9"},        // Next section with PRs
+		{[]string{"tab", "tab"}, "org/api#1"}, // Wraps around
+		{[]string{"shift+tab"}, "org/web#9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.keys, " "), func(t *testing.T) {
+			if got := selectedKey(press(m, tt.keys...)); got != tt.want {
+				t.Errorf("selected = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModel_Expand(t *testing.T) {
+	m := newTestModel(Options{})
+	m = press(m, "G")
+
+	if strings.Contains(m.View(), "@carol approved") {
+		t.Fatal("reviews should be hidden until the PR is expanded")
+	}
+
+	m = press(m, "enter")
+	view := m.View()
+	for _, want := range []string{"@carol approved", "https://github.com/org/web/pull/9", "Checks: none", "@bob"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expanded view should contain %q:\n%s", want, view)
+		}
+	}
+
+	m = press(m, "enter")
+	if strings.Contains(m.View(), "@carol approved") {
+		t.Error("enter should collapse an expanded PR")
+	}
+}
+
+func TestModel_Filter(t *testing.T) {
+	m := newTestModel(Options{})
+
+	m = press(m, "/", "s", "t", "y")
+	if !m.filtering || m.filter != "sty" {
+		t.Fatalf("filter = %q (filtering=%v), want live filter \"sty\"", m.filter, m.filtering)
+	}
+	if got := selectedKey(m); got != "org/web#5" {
+		t.Errorf("selected = %q, want the only match org/web#5", got)
+	}
+	if view := m.View(); strings.Contains(view, "Add API") || !strings.Contains(view, "/sty") {
+		t.Errorf("view should show only matches and the prompt:\n%s", view)
+	}
+
+	// Keys are typed into the filter, not treated as commands
+	m = press(m, "backspace", "backspace", "backspace", "d", "e", "p", "s", "enter")
+	if m.filtering || m.filter != "deps" || selectedKey(m) != "org/web#9" {
+		t.Errorf("after enter: filter = %q (filtering=%v), selected %q", m.filter, m.filtering, selectedKey(m))
+	}
+	if !strings.Contains(m.View(), `filter: "deps"`) {
+		t.Error("footer should show the active filter")
+	}
+
+	m = press(m, "esc")
+	if m.filter != "" || selectedKey(m) != "org/web#9" {
+		t.Errorf("esc should clear the filter and keep the selection, got filter %q, selected %q", m.filter, selectedKey(m))
+	}
+
+	// No matches leaves nothing selected
+	m = press(m, "/", "z", "z", "z", "enter", "j", "o")
+	if m.Selected() != nil {
+		t.Errorf("expected no selection, got %q", selectedKey(m))
+	}
+}
+
+func TestModel_Actions(t *testing.T) {
+	var opened, copied string
+	m := newTestModel(Options{
+		OpenURL: func(url string) error { opened = url; return nil },
+		CopyURL: func(url string) error { copied = url; return nil },
+	})
+
+	m = press(m, "j", "o")
+	if opened != "https://github.com/org/api/pull/2" {
+		t.Errorf("opened %q", opened)
+	}
+	if !strings.Contains(m.View(), "Opened https://github.com/org/api/pull/2") {
+		t.Error("footer should confirm the URL was opened")
+	}
+
+	m = press(m, "y")
+	if copied != "https://github.com/org/api/pull/2" {
+		t.Errorf("copied %q", copied)
+	}
+
+	m.opts.OpenURL = func(string) error { return errors.New("no browser") }
+	m = press(m, "o")
+	if !strings.Contains(m.View(), "no browser") {
+		t.Error("footer should show the error from opening the browser")
+	}
+}
+
+func TestModel_Quit(t *testing.T) {
+	m := newTestModel(Options{})
+
+	for _, msg := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("q")},
+		{Type: tea.KeyCtrlC},
+	} {
+		_, cmd := m.Update(msg)
+		if cmd == nil {
+			t.Fatalf("%s should quit", msg)
+		}
+		if _, ok := cmd().(tea.QuitMsg); !ok {
+			t.Errorf("%s should quit", msg)
+		}
+	}
+}
+
+func TestModel_ScrollsToCursor(t *testing.T) {
+	m := newTestModel(Options{})
+	next, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 8})
+	m = next.(Model)
+
+	m = press(m, "G")
+	view := m.View()
+	if lines := strings.Count(view, "\n") + 1; lines > 8 {
+		t.Errorf("view has %d lines, want at most the window height", lines)
+	}
+	if !strings.Contains(view, "Bump deps") {
+		t.Errorf("view should scroll to the selected PR:\n%s", view)
+	}
+
+	m = press(m, "g")
+	if !strings.Contains(m.View(), "Add API") {
+		t.Errorf("view should scroll back to the top:\n%s", m.View())
+	}
+}
+
+func TestClampOffset(t *testing.T) {
+	tests := []struct {
+		name                                    string
+		offset, cursorLine, height, total, want int
+	}{
+		{"cursor visible", 0, 3, 10, 20, 0},
+		{"cursor above", 5, 2, 10, 20, 2},
+		{"cursor below", 0, 15, 10, 20, 7},
+		{"past the end", 15, 16, 10, 20, 10},
+		{"fits on screen", 3, 2, 10, 5, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := clampOffset(tt.offset, tt.cursorLine, tt.height, tt.total); got != tt.want {
+				t.Errorf("clampOffset() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatCheck(t *testing.T) {
+	display.DisableColors()
+	required, optional := true, false
+
+	tests := []struct {
+		check models.Check
+		want  string
+	}{
+		{models.Check{Name: "build", State: "COMPLETED", Conclusion: "SUCCESS"}, "build passing"},
+		{models.Check{Name: "test", State: "IN_PROGRESS", Required: &required}, "test pending · required"},
+		{models.Check{Name: "codecov", State: "FAILURE", Required: &optional, Ignored: true}, "codecov failing · optional · ignored"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := formatCheck(tt.check); got != tt.want {
+				t.Errorf("formatCheck() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"prt/internal/config"
+	"prt/internal/display"
+	"prt/internal/models"
+)
+
+// rowKind identifies what a row in the list displays.
+type rowKind int
+
+const (
+	rowSection rowKind = iota // Section header (MY PRS, ...)
+	rowGroup                  // Repository or author header
+	rowPR                     // A pull request
+	rowEmpty                  // Placeholder for an empty section
+)
+
+// row is one entry of the flattened dashboard. PR rows carry the tree
+// prefixes that place them in their stack, mirroring display.Render.
+type row struct {
+	kind    rowKind
+	section int    // Index of the section the row belongs to
+	text    string // Header text, or the message of an empty section
+	icon    string // Section icon
+
+	pr           *models.PR
+	prefix       string // Tree prefix of the title line
+	continuation string // Tree prefix of the detail lines
+	blocked      bool   // Stacked PR waiting on its parent
+	orphan       bool   // Stacked PR whose parent was merged or closed
+}
+
+// section is one category of the ScanResult.
+type section struct {
+	title string
+	icon  string
+	prs   []*models.PR
+}
+
+// sections returns the dashboard sections in display order.
+func sections(result *models.ScanResult, showOtherPRs bool) []section {
+	var s []section
+	for _, sec := range result.DisplaySections() {
+		if sec.Key == models.SectionOtherPRs && !showOtherPRs {
+			continue
+		}
+		s = append(s, section{display.SectionTitle(sec), display.SectionIcon(sec), result.SectionPRs(sec)})
+	}
+	return s
+}
+
+// buildRows flattens the result into rows: a header per section, a header
+// per repository (or author), and the PRs below it, with stacked PRs
+// nested under their parents.
+func buildRows(result *models.ScanResult, groupBy string, showOtherPRs bool) []row {
+	var rows []row
+
+	for i, sec := range sections(result, showOtherPRs) {
+		rows = append(rows, row{kind: rowSection, section: i, text: sec.title, icon: sec.icon})
+		if len(sec.prs) == 0 {
+			msg := "  None"
+			if sec.title == "NEEDS MY ATTENTION" {
+				msg = "  None - you're all caught up!"
+			}
+			rows = append(rows, row{kind: rowEmpty, section: i, text: msg})
+			continue
+		}
+
+		groups, names := groupPRs(sec.prs, groupBy)
+		for _, name := range names {
+			rows = append(rows, row{kind: rowGroup, section: i, text: name})
+			rows = appendPRRows(rows, i, groups[name], result.Stacks)
+		}
+	}
+
+	return rows
+}
+
+// groupPRs groups PRs by repository or, in author mode, by author, and
+// returns the group headers sorted alphabetically.
+func groupPRs(prs []*models.PR, groupBy string) (map[string][]*models.PR, []string) {
+	groups := make(map[string][]*models.PR)
+	for _, pr := range prs {
+		name := fmt.Sprintf("[%s]", pr.RepoFullName())
+		if groupBy == config.GroupByAuthor {
+			author := pr.Author
+			if author == "" {
+				author = "unknown"
+			}
+			name = fmt.Sprintf("[@%s]", author)
+		}
+		groups[name] = append(groups[name], pr)
+	}
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return groups, names
+}
+
+// appendPRRows appends a group's PRs in input order. Stack roots bring
+// their whole tree with them; stack children are only shown under their
+// root, as in the static output.
+func appendPRRows(rows []row, sectionIdx int, prs []*models.PR, stacks map[string]*models.Stack) []row {
+	type item struct {
+		pr   *models.PR
+		root *models.StackNode
+	}
+
+	var items []item
+	for _, pr := range prs {
+		node := stackNode(pr, stacks)
+		if node != nil && node.Parent != nil {
+			continue // Rendered by its root
+		}
+		items = append(items, item{pr: pr, root: node})
+	}
+
+	for i, it := range items {
+		isLast := i == len(items)-1
+		if it.root != nil {
+			rows = appendStackRows(rows, sectionIdx, it.root, "", isLast)
+			continue
+		}
+		rows = append(rows, prRow(sectionIdx, it.pr, "", isLast, false, false))
+	}
+	return rows
+}
+
+// appendStackRows appends node and its descendants with tree prefixes.
+func appendStackRows(rows []row, sectionIdx int, node *models.StackNode, prefix string, isLast bool) []row {
+	if node == nil || node.PR == nil {
+		return rows
+	}
+
+	r := prRow(sectionIdx, node.PR, prefix, isLast, len(node.Children) > 0, node.IsBlocked())
+	r.orphan = node.IsOrphan
+	rows = append(rows, r)
+
+	childPrefix := prefix + display.TreeStyle.Render(display.TreeVertical) + "   "
+	if isLast {
+		childPrefix = prefix + display.TreeIndent
+	}
+	for i, child := range node.Children {
+		rows = appendStackRows(rows, sectionIdx, child, childPrefix, i == len(node.Children)-1)
+	}
+	return rows
+}
+
+// prRow builds the row for a PR at one level of a tree.
+func prRow(sectionIdx int, pr *models.PR, prefix string, isLast, hasChildren, blocked bool) row {
+	branch := display.TreeStyle.Render(display.TreeBranch)
+	continuation := prefix + display.TreeStyle.Render(display.TreeVertical) + "   "
+	if isLast {
+		branch = display.TreeStyle.Render(display.TreeLastBranch)
+		continuation = prefix + display.TreeIndent
+	}
+	if hasChildren {
+		continuation += display.TreeStyle.Render(display.TreeVertical) + "   "
+	}
+
+	return row{
+		kind:         rowPR,
+		section:      sectionIdx,
+		pr:           pr,
+		prefix:       prefix + branch + " ",
+		continuation: continuation,
+		blocked:      blocked,
+	}
+}
+
+// stackNode returns the stack node of pr, or nil if it isn't stacked.
+func stackNode(pr *models.PR, stacks map[string]*models.Stack) *models.StackNode {
+	stack := stacks[pr.RepoFullName()]
+	if stack == nil {
+		return nil
+	}
+	for _, node := range stack.AllNodes {
+		if node.PR != nil && node.PR.Number == pr.Number {
+			return node
+		}
+	}
+	return nil
+}
+
+// matches reports whether pr matches the filter text: a case-insensitive
+// substring of its title, author, repository, branches, or number.
+func matches(pr *models.PR, filter string) bool {
+	if filter == "" {
+		return true
+	}
+	filter = strings.ToLower(filter)
+
+	fields := []string{
+		pr.Title,
+		pr.Author,
+		pr.RepoFullName(),
+		pr.HeadBranch,
+		pr.BaseBranch,
+		fmt.Sprintf("#%d", pr.Number),
+	}
+	for _, f := range fields {
+		if strings.Contains(strings.ToLower(f), filter) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterRows returns the indexes of the rows visible under filter. PR rows
+// that don't match are hidden, along with group headers left empty;
+// section headers are always shown so the layout stays familiar.
+func filterRows(rows []row, filter string) []int {
+	var visible []int
+	pendingGroup := -1
+
+	for i, r := range rows {
+		switch r.kind {
+		case rowSection, rowEmpty:
+			pendingGroup = -1
+			visible = append(visible, i)
+		case rowGroup:
+			pendingGroup = i
+		case rowPR:
+			if !matches(r.pr, filter) {
+				continue
+			}
+			if pendingGroup >= 0 {
+				visible = append(visible, pendingGroup)
+				pendingGroup = -1
+			}
+			visible = append(visible, i)
+		}
+	}
+
+	return visible
+}
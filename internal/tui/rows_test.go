@@ -0,0 +1,171 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"prt/internal/config"
+	"prt/internal/models"
+)
+
+// testResult builds a result with a stack in org/api (#1 <- #2), a
+// standalone PR in org/web, and a team PR by bob.
+func testResult() *models.ScanResult {
+	now := time.Now()
+	root := &models.StackNode{PR: &models.PR{Number: 1, Title: "Add API", Author: "me", RepoOwner: "org", RepoName: "api",
+		HeadBranch: "api", BaseBranch: "main", State: models.PRStateOpen, CreatedAt: now, URL: "https://github.com/org/api/pull/1"}}
+	child := &models.StackNode{PR: &models.PR{Number: 2, Title: "Use API", Author: "me", RepoOwner: "org", RepoName: "api",
+		HeadBranch: "use-api", BaseBranch: "api", State: models.PRStateOpen, CreatedAt: now, URL: "https://github.com/org/api/pull/2"},
+		Parent: root, Depth: 1}
+	root.Children = []*models.StackNode{child}
+
+	result := models.NewScanResult()
+	result.MyPRs = []*models.PR{
+		root.PR,
+		child.PR,
+		{Number: 5, Title: "Fix styles", Author: "me", RepoOwner: "org", RepoName: "web", State: models.PRStateOpen,
+			CreatedAt: now, URL: "https://github.com/org/web/pull/5"},
+	}
+	result.TeamPRs = []*models.PR{
+		{Number: 9, Title: "Bump deps", Author: "bob", RepoOwner: "org", RepoName: "web", State: models.PRStateOpen,
+			CreatedAt: now, URL: "https://github.com/org/web/pull/9",
+			Reviews: []models.Review{{Author: "carol", State: models.ReviewStateApproved, Submitted: now}}},
+	}
+	result.Stacks["org/api"] = &models.Stack{Roots: []*models.StackNode{root}, AllNodes: []*models.StackNode{root, child}}
+	return result
+}
+
+// rowSummary describes rows compactly for comparison.
+func rowSummary(rows []row) []string {
+	var out []string
+	for _, r := range rows {
+		switch r.kind {
+		case rowSection:
+			out = append(out, "section "+r.text)
+		case rowGroup:
+			out = append(out, "group "+r.text)
+		case rowEmpty:
+			out = append(out, "empty")
+		case rowPR:
+			out = append(out, r.pr.Key())
+		}
+	}
+	return out
+}
+
+func TestBuildRows(t *testing.T) {
+	rows := buildRows(testResult(), config.GroupByProject, false)
+
+	want := []string{
+		"section MY PRS",
+		"group [org/api]", "org/api#1", "org/api#2",
+		"group [org/web]", "org/web#5",
+		"section NEEDS MY ATTENTION", "empty",
+		"section TEAM PRS",
+		"group [org/web]", "org/web#9",
+	}
+	got := rowSummary(rows)
+	if len(got) != len(want) {
+		t.Fatalf("buildRows() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("row %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	// The stack child is nested one level below its root
+	if len(rows[3].prefix) <= len(rows[2].prefix) {
+		t.Errorf("expected child prefix %q to be deeper than root prefix %q", rows[3].prefix, rows[2].prefix)
+	}
+}
+
+func TestBuildRows_ByAuthorWithOtherPRs(t *testing.T) {
+	result := testResult()
+	result.OtherPRs = []*models.PR{{Number: 3, RepoOwner: "org", RepoName: "api"}}
+
+	got := rowSummary(buildRows(result, config.GroupByAuthor, true))
+
+	wantContains := []string{"group [@me]", "group [@bob]", "section OTHER PRS", "group [@unknown]"}
+	for _, w := range wantContains {
+		found := false
+		for _, g := range got {
+			if g == w {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("buildRows() = %v, missing %q", got, w)
+		}
+	}
+}
+
+func TestBuildRows_CustomSections(t *testing.T) {
+	result := testResult()
+	result.Sections = []*models.Section{
+		{Key: "security", Name: "Security", PRs: []*models.PR{{Number: 7, Author: "eve", RepoOwner: "org", RepoName: "api"}}},
+		{Key: models.SectionTeamPRs, Name: "Team PRs"},
+	}
+
+	got := rowSummary(buildRows(result, config.GroupByProject, false))
+
+	want := []string{
+		"section SECURITY", "group [org/api]", "org/api#7",
+		"section TEAM PRS", "group [org/web]", "org/web#9",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("buildRows() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("row %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestMatches(t *testing.T) {
+	pr := &models.PR{Number: 42, Title: "Add Rate Limiting", Author: "alice", RepoOwner: "org", RepoName: "api",
+		HeadBranch: "rate-limit", BaseBranch: "main"}
+
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{"", true},
+		{"rate", true},
+		{"ALICE", true},
+		{"org/api", true},
+		{"#42", true},
+		{"main", true},
+		{"bob", false},
+	}
+
+	for _, tt := range tests {
+		if got := matches(pr, tt.filter); got != tt.want {
+			t.Errorf("matches(%q) = %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+}
+
+func TestFilterRows(t *testing.T) {
+	rows := buildRows(testResult(), config.GroupByProject, false)
+
+	var got []string
+	for _, i := range filterRows(rows, "deps") {
+		got = append(got, rowSummary(rows[i:i+1])...)
+	}
+
+	want := []string{
+		"section MY PRS",
+		"section NEEDS MY ATTENTION", "empty",
+		"section TEAM PRS", "group [org/web]", "org/web#9",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("filterRows() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("row %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Package tui provides the interactive terminal dashboard (prt -i).
+// It browses the same ScanResult that display.Render prints, using the
+// same styles.
+package tui
+
+import (
+	"io"
+	"os"
+
+	"prt/internal/browser"
+	"prt/internal/models"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Options configures the interactive dashboard.
+type Options struct {
+	ShowIcons    bool   // Show emoji icons for sections and status
+	ShowOtherPRs bool   // Show "Other PRs" section (external contributors, bots)
+	GroupBy      string // Group PRs by: "project" (default) or "author"
+
+	// OpenURL opens a PR in the browser. Defaults to browser.Open.
+	OpenURL func(url string) error
+	// CopyURL copies a PR's URL to the clipboard. Defaults to an OSC 52
+	// escape sequence, which works over SSH and in tmux.
+	CopyURL func(url string) error
+}
+
+// withDefaults fills in the default actions.
+func (o Options) withDefaults() Options {
+	if o.OpenURL == nil {
+		o.OpenURL = browser.Open
+	}
+	if o.CopyURL == nil {
+		o.CopyURL = func(url string) error { return copyOSC52(os.Stderr, url) }
+	}
+	return o
+}
+
+// copyOSC52 asks the terminal to put text on the system clipboard.
+func copyOSC52(w io.Writer, text string) error {
+	seq := osc52.New(text)
+	if os.Getenv("TMUX") != "" {
+		seq = seq.Tmux()
+	}
+	_, err := seq.WriteTo(w)
+	return err
+}
+
+// Run shows the interactive dashboard for result until the user quits.
+func Run(result *models.ScanResult, opts Options) error {
+	p := tea.NewProgram(New(result, opts), tea.WithAltScreen())
+	_, err := p.Run()
+	return err
+}
@@ -0,0 +1,37 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestCopyOSC52(t *testing.T) {
+	const url = "https://github.com/org/repo/pull/1"
+	encoded := base64.StdEncoding.EncodeToString([]byte(url))
+
+	t.Setenv("TMUX", "")
+	var buf bytes.Buffer
+	if err := copyOSC52(&buf, url); err != nil {
+		t.Fatalf("copyOSC52() error = %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "\x1b]52;c;"+encoded) {
+		t.Errorf("copyOSC52() wrote %q", buf.String())
+	}
+
+	// Inside tmux the sequence is wrapped in a passthrough
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1,0")
+	buf.Reset()
+	copyOSC52(&buf, url)
+	if !strings.HasPrefix(buf.String(), "\x1bPtmux;") {
+		t.Errorf("copyOSC52() in tmux wrote %q", buf.String())
+	}
+}
+
+func TestOptions_WithDefaults(t *testing.T) {
+	opts := Options{}.withDefaults()
+	if opts.OpenURL == nil || opts.CopyURL == nil {
+		t.Error("expected default actions to be set")
+	}
+}